@@ -0,0 +1,89 @@
+package rendezvous
+
+// AddNodes inserts new nodes into the existing clusters without regenerating
+// the topology. Clusters below the cluster size are filled first and new
+// clusters are only created when every cluster is full, so clusters that do
+// not receive a node keep their members. Nodes already in the skeleton are
+// ignored. With a zone placement policy or an assignment strategy the
+// clusters are generated again, so their placement holds.
+func (sr *SkeletonRendezvous) AddNodes(nodes []string) {
+	sr.updateTopology(func() {
+		sr.addNodes(nodes)
+	})
+}
+
+func (sr *SkeletonRendezvous) addNodes(nodes []string) {
+	// filling clusters could break the zone placement or the placement of
+	// a strategy, so such clusters are generated again.
+	if !sr.packedInOrder() {
+		sr.setNodes(nodes)
+
+		return
+	}
+
+	lookup := make(map[string]bool, len(sr.Nodes))
+
+	for _, node := range sr.Nodes {
+		lookup[node] = true
+	}
+
+	nodes = append(make([]string, 0, len(nodes)), nodes...)
+	sr.orderNodes(nodes)
+
+	clusterIndex := 0
+
+	for _, node := range nodes {
+		if lookup[node] {
+			continue
+		}
+
+		lookup[node] = true
+
+		for clusterIndex < len(sr.Clusters) && len(sr.Clusters[clusterIndex]) >= sr.options.clusterSize {
+			clusterIndex++
+		}
+
+		if clusterIndex == len(sr.Clusters) {
+			sr.Clusters = append(sr.Clusters, make([]string, 0, sr.options.clusterSize))
+		}
+
+		sr.Clusters[clusterIndex] = append(sr.Clusters[clusterIndex], node)
+		sr.Nodes = append(sr.Nodes, node)
+	}
+
+	sr.register(nodes)
+
+	sr.VirtualNodes = sr.countVirtualNodes(len(sr.Clusters), sr.options.fanOut)
+	sr.topologyChanged()
+}
+
+// SyncNodes makes the nodes of the skeleton match the given nodes in one
+// change, the missing nodes are removed like RemoveNodes and the new nodes
+// are added like AddNodes.
+func (sr *SkeletonRendezvous) SyncNodes(nodes []string) {
+	sr.updateTopology(func() {
+		sr.syncNodes(nodes)
+	})
+}
+
+func (sr *SkeletonRendezvous) syncNodes(nodes []string) {
+	present := make(map[string]bool, len(nodes))
+
+	for _, node := range nodes {
+		present[node] = true
+	}
+
+	removed := make([]string, 0)
+
+	for _, node := range sr.Nodes {
+		if !present[node] {
+			removed = append(removed, node)
+		}
+	}
+
+	if len(removed) > 0 {
+		sr.removeNodes(removed)
+	}
+
+	sr.addNodes(nodes)
+}
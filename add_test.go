@@ -0,0 +1,85 @@
+package rendezvous
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddNodes(t *testing.T) {
+	t.Run("should fill under capacity cluster first", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(2))
+
+		assert.NoError(t, err)
+
+		sr.SetClusters([][]string{{"jg1", "jg2"}, {"jg3"}, {"jg4", "jg5"}})
+		sr.AddNodes([]string{"jg6"})
+
+		assert.Equal(t, [][]string{{"jg1", "jg2"}, {"jg3", "jg6"}, {"jg4", "jg5"}}, sr.Clusters)
+		assert.Equal(t, []string{"jg1", "jg2", "jg3", "jg4", "jg5", "jg6"}, sr.Nodes)
+	})
+
+	t.Run("should create new cluster only when every cluster is full", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(2))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+		sr.AddNodes([]string{"jg5", "jg6", "jg7"})
+
+		assert.Equal(t, [][]string{{"jg1", "jg2"}, {"jg3", "jg4"}, {"jg5", "jg6"}, {"jg7"}}, sr.Clusters)
+		assert.Equal(t, 2, sr.VirtualNodes)
+		assert.NoError(t, sr.Validate())
+	})
+
+	t.Run("should ignore nodes already in skeleton", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(2))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3"})
+		sr.AddNodes([]string{"jg2", "jg4", "jg4"})
+
+		assert.Equal(t, []string{"jg1", "jg2", "jg3", "jg4"}, sr.Nodes)
+		assert.Equal(t, [][]string{{"jg1", "jg2", "jg3"}, {"jg4"}}, sr.Clusters)
+	})
+
+	t.Run("should only move keys to the added node", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(2), HashAlgorithm(newMixedHash()))
+
+		assert.NoError(t, err)
+
+		sr.SetClusters([][]string{{"jg1", "jg2"}, {"jg3"}, {"jg4", "jg5"}})
+
+		before := make([]string, 1000)
+
+		for i := range before {
+			before[i] = mustFindNode(t, sr, "key-"+strconv.Itoa(i))
+		}
+
+		sr.AddNodes([]string{"jg6"})
+
+		for i, node := range before {
+			after := mustFindNode(t, sr, "key-"+strconv.Itoa(i))
+
+			if after != node {
+				assert.Equal(t, "jg6", after)
+			}
+		}
+	})
+}
+
+func TestSyncNodes(t *testing.T) {
+	t.Run("should remove missing nodes and add new ones", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(ClusterSize(2))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+		sr.SyncNodes([]string{"jg4", "jg2", "jg5", "jg1"})
+
+		assert.Equal(t, [][]string{{"jg1", "jg2", "jg4"}, {"jg5"}}, sr.Clusters)
+		assert.NoError(t, sr.Validate())
+	})
+}
@@ -0,0 +1,136 @@
+package rendezvous
+
+import (
+	"fmt"
+)
+
+// AntiAffinity decides which replicas of a key must not share a failure
+// domain.
+type AntiAffinity int
+
+const (
+	// DistinctClusters places every replica in a different cluster, so
+	// losing a cluster loses at most one replica
+	DistinctClusters AntiAffinity = iota
+
+	// DistinctZones places every replica in a different cluster and a
+	// different zone, the zone is read from the zone label of the node
+	DistinctZones
+)
+
+// FindReplicas find up to n nodes for the key in distinct failure domains,
+// ordered by preference. Down and draining nodes are skipped like in FindN,
+// so the first node is the pinned node of the key or the node selected by
+// FindNode, the others are the best available nodes of the clusters with the
+// highest score for the key. Fewer than n nodes are returned when there are
+// not enough clusters or zones with an available node.
+func (sr *SkeletonRendezvous) FindReplicas(key string, n int, affinity AntiAffinity) ([]string, error) {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+
+	if n < 1 {
+		return nil, fmt.Errorf("%w: n must be at least 1, got %d", ErrInvalidOption, n)
+	}
+
+	if affinity != DistinctClusters && affinity != DistinctZones {
+		return nil, fmt.Errorf("%w: unknown anti affinity %d", ErrInvalidOption, affinity)
+	}
+
+	order, err := sr.clusterOrder(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	unavailable := sr.unavailableNodes()
+	usedClusters := make(map[int]bool)
+	usedZones := make(map[string]bool)
+
+	best := func(cluster []string) (string, float64, bool) {
+		for _, ranked := range sr.rankNodes(key, cluster) {
+			if _, ok := unavailable[ranked.node]; ok {
+				continue
+			}
+
+			if affinity == DistinctZones && usedZones[sr.zoneOf(ranked.node)] {
+				continue
+			}
+
+			return ranked.node, ranked.score, true
+		}
+
+		return "", 0, false
+	}
+
+	primary, ok := sr.pinnedNode(key, unavailable)
+	primaryCluster := -1
+
+	if ok {
+		primaryCluster = sr.clusterOfNode(primary)
+	} else {
+		// like FindNode, a cluster without available node falls back to
+		// the next cluster of the branch walk.
+		for _, clusterIndex := range order {
+			if primary, _, ok = best(sr.Clusters[clusterIndex]); ok {
+				primaryCluster = clusterIndex
+
+				break
+			}
+		}
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("%w: every node is unavailable", ErrNoNodes)
+	}
+
+	usedClusters[primaryCluster] = true
+
+	nodes := append(make([]string, 0, n), primary)
+	usedZones[sr.zoneOf(primary)] = true
+
+	for len(nodes) < n {
+		var selectedNode string
+		var highestScore float64
+
+		selectedCluster := -1
+
+		for i, cluster := range sr.Clusters {
+			if usedClusters[i] {
+				continue
+			}
+
+			if node, score, ok := best(cluster); ok && (selectedCluster < 0 || outranks(node, score, selectedNode, highestScore)) {
+				selectedNode, highestScore, selectedCluster = node, score, i
+			}
+		}
+
+		if selectedCluster < 0 {
+			break
+		}
+
+		nodes = append(nodes, selectedNode)
+		usedClusters[selectedCluster] = true
+		usedZones[sr.zoneOf(selectedNode)] = true
+	}
+
+	return nodes, nil
+}
+
+// clusterOfNode returns the index of the cluster holding the node, or -1
+// when the node is in no cluster.
+func (sr *SkeletonRendezvous) clusterOfNode(node string) int {
+	for i, cluster := range sr.Clusters {
+		for _, clusterNode := range cluster {
+			if clusterNode == node {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+// zoneOf returns the zone of the node from its labels.
+func (sr *SkeletonRendezvous) zoneOf(node string) string {
+	return sr.nodeInfo[node].Labels[sr.options.zoneLabel]
+}
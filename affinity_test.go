@@ -0,0 +1,132 @@
+package rendezvous
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindReplicas(t *testing.T) {
+	t.Run("should place replicas in distinct clusters", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4", "jg5", "jg6", "jg7", "jg8"})
+
+		clusterOf := make(map[string]int)
+
+		for i, cluster := range sr.Clusters {
+			for _, node := range cluster {
+				clusterOf[node] = i
+			}
+		}
+
+		for i := 0; i < 100; i++ {
+			key := "key-" + strconv.Itoa(i)
+
+			replicas, err := sr.FindReplicas(key, 3, DistinctClusters)
+
+			assert.NoError(t, err)
+			assert.Len(t, replicas, 3)
+			assert.Equal(t, mustFindNode(t, sr, key), replicas[0])
+
+			clusters := make(map[int]bool)
+
+			for _, replica := range replicas {
+				clusters[clusterOf[replica]] = true
+			}
+
+			assert.Len(t, clusters, 3)
+		}
+	})
+
+	t.Run("should return fewer replicas than clusters", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		replicas, err := sr.FindReplicas("key-1", 3, DistinctClusters)
+
+		assert.NoError(t, err)
+		assert.Len(t, replicas, 2)
+	})
+
+	t.Run("should place replicas in distinct zones", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		// every cluster holds a node of zone a, only two zones exist
+		sr.SetNodeList([]Node{
+			{ID: "jg1", Labels: map[string]string{"zone": "a"}},
+			{ID: "jg2", Labels: map[string]string{"zone": "b"}},
+			{ID: "jg3", Labels: map[string]string{"zone": "a"}},
+			{ID: "jg4", Labels: map[string]string{"zone": "b"}},
+			{ID: "jg5", Labels: map[string]string{"zone": "a"}},
+			{ID: "jg6", Labels: map[string]string{"zone": "a"}},
+		})
+
+		for i := 0; i < 100; i++ {
+			replicas, err := sr.FindReplicas("key-"+strconv.Itoa(i), 3, DistinctZones)
+
+			assert.NoError(t, err)
+			assert.Len(t, replicas, 2)
+
+			info0, _ := sr.NodeInfo(replicas[0])
+			info1, _ := sr.NodeInfo(replicas[1])
+
+			assert.NotEqual(t, info0.Labels["zone"], info1.Labels["zone"])
+		}
+	})
+
+	t.Run("should skip unavailable nodes and keep pins", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4", "jg5", "jg6", "jg7", "jg8"})
+
+		sr.MarkDown("jg1")
+		sr.MarkDraining("jg5")
+
+		for i := 0; i < 100; i++ {
+			key := "key-" + strconv.Itoa(i)
+
+			replicas, err := sr.FindReplicas(key, 4, DistinctClusters)
+
+			assert.NoError(t, err)
+			assert.NotContains(t, replicas, "jg1")
+			assert.NotContains(t, replicas, "jg5")
+			assert.Equal(t, mustFindNode(t, sr, key), replicas[0])
+		}
+
+		assert.NoError(t, sr.PinKey("key-1", "jg8"))
+
+		replicas, err := sr.FindReplicas("key-1", 2, DistinctClusters)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "jg8", replicas[0])
+		assert.NotEqual(t, clusterOf(sr, "jg8"), clusterOf(sr, replicas[1]))
+
+		sr.MarkDown(sr.Nodes...)
+
+		_, err = sr.FindReplicas("key-1", 2, DistinctClusters)
+		assert.ErrorIs(t, err, ErrNoNodes)
+	})
+
+	t.Run("should return error for invalid arguments or empty skeleton", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		_, err = sr.FindReplicas("key-1", 1, DistinctClusters)
+		assert.ErrorIs(t, err, ErrNoNodes)
+
+		sr.SetNodes([]string{"jg1", "jg2"})
+
+		_, err = sr.FindReplicas("key-1", 0, DistinctClusters)
+		assert.ErrorIs(t, err, ErrInvalidOption)
+
+		_, err = sr.FindReplicas("key-1", 1, AntiAffinity(5))
+		assert.ErrorIs(t, err, ErrInvalidOption)
+	})
+}
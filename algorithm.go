@@ -0,0 +1,112 @@
+package rendezvous
+
+import (
+	"fmt"
+	"hash"
+	"hash/crc64"
+	"hash/fnv"
+	"reflect"
+	"sync"
+)
+
+// hashAlgorithm is a registered hash algorithm, either a hash constructor
+// or a stateless hash function.
+type hashAlgorithm struct {
+	newHash func() hash.Hash64
+	sum     func([]byte) uint64
+}
+
+// hashAlgorithms is the registry of known hash algorithms by name, so
+// the algorithm used by a skeleton can be recorded and rebuilt elsewhere.
+var hashAlgorithms = map[string]hashAlgorithm{
+	"fnv64":  {newHash: fnv.New64},
+	"fnv64a": {newHash: fnv.New64a},
+	"crc64-iso": {newHash: func() hash.Hash64 {
+		return crc64.New(crc64.MakeTable(crc64.ISO))
+	}},
+	"crc64-ecma": {newHash: func() hash.Hash64 {
+		return crc64.New(crc64.MakeTable(crc64.ECMA))
+	}},
+}
+
+// hashAlgorithmsMu guards the registry against registering while it is read.
+var hashAlgorithmsMu sync.RWMutex
+
+// RegisterHashFunc registers the stateless hash function under the name, so
+// skeletons using it record the name and their snapshots are restored with
+// the same function. It is meant to be called from the init function of the
+// package providing the hash, registering a nil function or a name twice
+// panics.
+func RegisterHashFunc(name string, hash func([]byte) uint64) {
+	hashAlgorithmsMu.Lock()
+	defer hashAlgorithmsMu.Unlock()
+
+	if hash == nil {
+		panic("rendezvous: hash function " + name + " is nil")
+	}
+
+	if _, ok := hashAlgorithms[name]; ok {
+		panic("rendezvous: hash algorithm " + name + " is already registered")
+	}
+
+	hashAlgorithms[name] = hashAlgorithm{sum: hash}
+}
+
+// HashAlgorithmByName sets the hash algorithm from its registered name,
+// such as "fnv64", "fnv64a", "crc64-iso", "crc64-ecma" or a name given to
+// RegisterHashFunc.
+func HashAlgorithmByName(name string) Option {
+	return func(o *Options) error {
+		hashAlgorithmsMu.RLock()
+		algorithm, ok := hashAlgorithms[name]
+		hashAlgorithmsMu.RUnlock()
+
+		if !ok {
+			return fmt.Errorf("%w: unknown hash algorithm %q", ErrInvalidOption, name)
+		}
+
+		if algorithm.sum != nil {
+			o.hash = nil
+			o.hashFunc = algorithm.sum
+			o.newHash = nil
+		} else {
+			o.hash = algorithm.newHash()
+			o.hashFunc = nil
+			o.newHash = algorithm.newHash
+		}
+
+		o.hashName = name
+
+		return nil
+	}
+}
+
+// Algorithm returns the registered name of the hash algorithm, or an empty
+// string when the algorithm is not known by name.
+func (sr *SkeletonRendezvous) Algorithm() string {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+
+	return sr.options.hashName
+}
+
+// hashAlgorithmName finds the registered name of the hash by its type, it
+// returns an empty string when none or more than one algorithm match.
+func hashAlgorithmName(h hash.Hash64) string {
+	hashAlgorithmsMu.RLock()
+	defer hashAlgorithmsMu.RUnlock()
+
+	var matchedName string
+
+	for name, algorithm := range hashAlgorithms {
+		if algorithm.newHash != nil && reflect.TypeOf(algorithm.newHash()) == reflect.TypeOf(h) {
+			if matchedName != "" {
+				return ""
+			}
+
+			matchedName = name
+		}
+	}
+
+	return matchedName
+}
@@ -0,0 +1,75 @@
+package rendezvous
+
+import (
+	"hash/crc64"
+	"hash/fnv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// the registry is global, so the test hash is registered once per process.
+func init() {
+	RegisterHashFunc("test-fnv64a", fnv64aSum)
+}
+
+func TestAlgorithm(t *testing.T) {
+	t.Run("should name default algorithm", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "fnv64", sr.Algorithm())
+	})
+
+	t.Run("should set algorithm by name", func(t *testing.T) {
+		for _, name := range []string{"fnv64", "fnv64a", "crc64-iso", "crc64-ecma"} {
+			sr, err := NewSkeletonRendezvous(HashAlgorithmByName(name))
+
+			assert.NoError(t, err)
+			assert.Equal(t, name, sr.Algorithm())
+		}
+	})
+
+	t.Run("should reject unknown algorithm name", func(t *testing.T) {
+		_, err := NewSkeletonRendezvous(HashAlgorithmByName("md5"))
+
+		assert.ErrorIs(t, err, ErrInvalidOption)
+	})
+
+	t.Run("should set registered hash function by name", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(HashAlgorithmByName("test-fnv64a"))
+		assert.NoError(t, err)
+		assert.Equal(t, "test-fnv64a", sr.Algorithm())
+
+		expected, err := NewSkeletonRendezvous(HashAlgorithmByName("fnv64a"))
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+		expected.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		assert.Equal(t, mustFindNode(t, expected, "key"), mustFindNode(t, sr, "key"))
+
+		assert.Panics(t, func() { RegisterHashFunc("test-fnv64a", fnv64aSum) })
+		assert.Panics(t, func() { RegisterHashFunc("fnv64", fnv64aSum) })
+	})
+
+	t.Run("should detect name of known algorithm instance", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(HashAlgorithm(fnv.New64a()))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "fnv64a", sr.Algorithm())
+
+		sr.SetHash(crc64.New(crc64.MakeTable(crc64.ISO)))
+		assert.Equal(t, "", sr.Algorithm())
+	})
+
+	t.Run("should not equal when algorithm differs", func(t *testing.T) {
+		a, err := NewSkeletonRendezvous(HashAlgorithmByName("crc64-iso"))
+		assert.NoError(t, err)
+
+		b, err := NewSkeletonRendezvous(HashAlgorithmByName("crc64-ecma"))
+		assert.NoError(t, err)
+
+		assert.False(t, a.Equal(b))
+	})
+}
@@ -0,0 +1,129 @@
+// Package analyze measures how keys are distributed over the nodes of a
+// skeleton rendezvous, to validate fan out and cluster size choices before
+// rolling them out.
+package analyze
+
+import (
+	"fmt"
+	"math"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+)
+
+// Report is the key distribution of a skeleton.
+type Report struct {
+	// Keys is the number of analyzed keys
+	Keys int
+
+	// NodeCounts is the number of keys placed on each node, nodes without
+	// any key are counted as 0
+	NodeCounts map[string]int
+
+	// ClusterCounts is the number of keys placed in each cluster
+	ClusterCounts []int
+
+	// Min is the number of keys of the least loaded node
+	Min int
+
+	// Max is the number of keys of the most loaded node
+	Max int
+
+	// Mean is the average number of keys per node
+	Mean float64
+
+	// StdDev is the standard deviation of the number of keys per node
+	StdDev float64
+
+	// Skew is how much the most loaded node exceeds the mean, in percent
+	Skew float64
+}
+
+// Keys places every key of the corpus and reports their distribution. The
+// keys are placed on a clone of the skeleton, so with bounded load they are
+// assigned from no load without counting on the skeleton.
+func Keys(sr *rendezvous.SkeletonRendezvous, keys []string) (Report, error) {
+	return Generated(sr, len(keys), func(i int) string {
+		return keys[i]
+	})
+}
+
+// Generated places n keys built by the generator and reports their
+// distribution, n must be at least 1. The keys are placed on a clone of
+// the skeleton like Keys.
+func Generated(sr *rendezvous.SkeletonRendezvous, n int, generate func(i int) string) (Report, error) {
+	if n < 1 {
+		return Report{}, fmt.Errorf("%w: n must be at least 1, got %d", rendezvous.ErrInvalidOption, n)
+	}
+
+	sr = sr.Clone()
+
+	clusters := sr.ClusterNodes()
+
+	report := Report{
+		Keys:          n,
+		NodeCounts:    make(map[string]int),
+		ClusterCounts: make([]int, len(clusters)),
+	}
+
+	clusterOf := make(map[string]int)
+
+	for i, cluster := range clusters {
+		for _, node := range cluster {
+			report.NodeCounts[node.ID] = 0
+			clusterOf[node.ID] = i
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		node, err := sr.FindNode(generate(i))
+
+		if err != nil {
+			return Report{}, err
+		}
+
+		report.NodeCounts[node]++
+
+		if cluster, ok := clusterOf[node]; ok {
+			report.ClusterCounts[cluster]++
+		}
+	}
+
+	report.summarize()
+
+	return report, nil
+}
+
+// summarize computes the statistics over the node counts.
+func (r *Report) summarize() {
+	if len(r.NodeCounts) == 0 {
+		return
+	}
+
+	first := true
+
+	for _, count := range r.NodeCounts {
+		if first || count < r.Min {
+			r.Min = count
+		}
+
+		if first || count > r.Max {
+			r.Max = count
+		}
+
+		first = false
+	}
+
+	r.Mean = float64(r.Keys) / float64(len(r.NodeCounts))
+
+	variance := 0.0
+
+	for _, count := range r.NodeCounts {
+		variance += (float64(count) - r.Mean) * (float64(count) - r.Mean)
+	}
+
+	r.StdDev = math.Sqrt(variance / float64(len(r.NodeCounts)))
+
+	if r.Mean > 0 {
+		r.Skew = (float64(r.Max) - r.Mean) / r.Mean * 100
+	}
+}
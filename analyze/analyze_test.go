@@ -0,0 +1,121 @@
+package analyze
+
+import (
+	"strconv"
+	"testing"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeys(t *testing.T) {
+	t.Run("should count keys per node and cluster", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		sr.SetClusters([][]string{{"jg1"}, {"jg2"}})
+
+		keys := make([]string, 0, 100)
+
+		for i := 0; i < 100; i++ {
+			keys = append(keys, "key-"+strconv.Itoa(i))
+		}
+
+		report, err := Keys(sr, keys)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 100, report.Keys)
+		assert.Equal(t, []int{report.NodeCounts["jg1"], report.NodeCounts["jg2"]}, report.ClusterCounts)
+		assert.Equal(t, 100, report.NodeCounts["jg1"]+report.NodeCounts["jg2"])
+		assert.Equal(t, 50.0, report.Mean)
+		assert.LessOrEqual(t, report.Min, report.Max)
+	})
+
+	t.Run("should not count the keys on the skeleton with bounded load", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous(rendezvous.ClusterSize(4), rendezvous.BoundedLoad(0.25))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		keys := make([]string, 0, 100)
+
+		for i := 0; i < 100; i++ {
+			keys = append(keys, "key-"+strconv.Itoa(i))
+		}
+
+		report, err := Keys(sr, keys)
+
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, report.Max, 32)
+		assert.Empty(t, sr.Loads())
+	})
+
+	t.Run("should return error of empty skeleton", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		_, err = Keys(sr, []string{"key"})
+
+		assert.ErrorIs(t, err, rendezvous.ErrNoNodes)
+	})
+}
+
+func TestGenerated(t *testing.T) {
+	t.Run("should summarize mean and skew", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		sr.SetClusters([][]string{{"jg1"}})
+		sr.SetNodes([]string{"jg2"})
+
+		report, err := Generated(sr, 10, func(i int) string {
+			return "key-" + strconv.Itoa(i)
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, report.NodeCounts, 2)
+		assert.Equal(t, 5.0, report.Mean)
+		assert.InDelta(t, float64(report.Max-5)/5*100, report.Skew, 1e-9)
+	})
+
+	t.Run("should count nodes without keys", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous(rendezvous.ClusterSize(4))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		report, err := Generated(sr, 1, func(i int) string {
+			return "key"
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, report.Min)
+		assert.Equal(t, 1, report.Max)
+		assert.Equal(t, 300.0, report.Skew)
+	})
+
+	t.Run("should return error for n below 1", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2"})
+
+		_, err = Generated(sr, 0, func(i int) string {
+			return "key"
+		})
+
+		assert.ErrorIs(t, err, rendezvous.ErrInvalidOption)
+
+		_, err = Generated(sr, -1, func(i int) string {
+			return "key"
+		})
+
+		assert.ErrorIs(t, err, rendezvous.ErrInvalidOption)
+	})
+}
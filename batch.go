@@ -0,0 +1,176 @@
+package rendezvous
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// contextCheckInterval is the number of keys a batch looks up between two
+// checks of its context.
+const contextCheckInterval = 64
+
+// FindNodes find the selected node of each key like FindNode, holding the
+// lock and resolving the unavailable nodes once for the whole batch. It
+// returns the first error of a key.
+func (sr *SkeletonRendezvous) FindNodes(keys []string) (map[string]string, error) {
+	return sr.FindNodesContext(context.Background(), keys)
+}
+
+// FindNodesContext is FindNodes stopping with the error of the context once
+// the context is done.
+func (sr *SkeletonRendezvous) FindNodesContext(ctx context.Context, keys []string) (map[string]string, error) {
+	var events []LookupEvent
+
+	// deferred first, the observers are notified once the lock is released.
+	defer func() { sr.notifyLookups(events) }()
+
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+
+	unavailable := sr.unavailableNodes()
+	nodes := make(map[string]string, len(keys))
+
+	for i, key := range keys {
+		if i%contextCheckInterval == 0 && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		start := sr.lookupStart()
+		node, err := sr.findNodeIn(key, unavailable)
+		sr.recordLookup(&events, key, node, err, start)
+
+		if err != nil {
+			return nil, err
+		}
+
+		nodes[key] = node
+	}
+
+	return nodes, nil
+}
+
+// GroupByNode find the selected node of each key like FindNodes and
+// returns the keys grouped by node, in the same order as the given keys,
+// for fanning out a request per node.
+func (sr *SkeletonRendezvous) GroupByNode(keys []string) (map[string][]string, error) {
+	return sr.GroupByNodeContext(context.Background(), keys)
+}
+
+// GroupByNodeContext is GroupByNode stopping with the error of the context
+// once the context is done.
+func (sr *SkeletonRendezvous) GroupByNodeContext(ctx context.Context, keys []string) (map[string][]string, error) {
+	var events []LookupEvent
+
+	// deferred first, the observers are notified once the lock is released.
+	defer func() { sr.notifyLookups(events) }()
+
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+
+	unavailable := sr.unavailableNodes()
+	groups := make(map[string][]string)
+
+	for i, key := range keys {
+		if i%contextCheckInterval == 0 && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		start := sr.lookupStart()
+		node, err := sr.findNodeIn(key, unavailable)
+		sr.recordLookup(&events, key, node, err, start)
+
+		if err != nil {
+			return nil, err
+		}
+
+		groups[node] = append(groups[node], key)
+	}
+
+	return groups, nil
+}
+
+// AssignAll find the selected node of each key like FindNodes, splitting
+// the keys across parallelism goroutines for very large key sets. A
+// parallelism below 1 uses GOMAXPROCS goroutines. The workers serialize
+// on a hash set by HashAlgorithm, while hashes set by HashFactory or
+// HashFunc let them hash in parallel. It returns the first error of a key.
+func (sr *SkeletonRendezvous) AssignAll(keys []string, parallelism int) (map[string]string, error) {
+	return sr.AssignAllContext(context.Background(), keys, parallelism)
+}
+
+// AssignAllContext is AssignAll stopping every goroutine with the error of
+// the context once the context is done.
+func (sr *SkeletonRendezvous) AssignAllContext(ctx context.Context, keys []string, parallelism int) (map[string]string, error) {
+	var events []LookupEvent
+
+	// deferred first, the observers are notified once the lock is released.
+	defer func() { sr.notifyLookups(events) }()
+
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+
+	if parallelism < 1 {
+		parallelism = runtime.GOMAXPROCS(0)
+	}
+
+	if parallelism > len(keys) {
+		parallelism = len(keys)
+	}
+
+	unavailable := sr.unavailableNodes()
+	assigned := make([]string, len(keys))
+	errs := make([]error, parallelism)
+	workerEvents := make([][]LookupEvent, parallelism)
+
+	var wg sync.WaitGroup
+
+	for worker := 0; worker < parallelism; worker++ {
+		wg.Add(1)
+
+		// the workers read the topology under the read lock of the caller.
+		go func(worker int) {
+			defer wg.Done()
+
+			start := worker * len(keys) / parallelism
+
+			for i := start; i < (worker+1)*len(keys)/parallelism; i++ {
+				if (i-start)%contextCheckInterval == 0 && ctx.Err() != nil {
+					errs[worker] = ctx.Err()
+					return
+				}
+
+				start := sr.lookupStart()
+				node, err := sr.findNodeIn(keys[i], unavailable)
+				sr.recordLookup(&workerEvents[worker], keys[i], node, err, start)
+
+				if err != nil {
+					errs[worker] = err
+					return
+				}
+
+				assigned[i] = node
+			}
+		}(worker)
+	}
+
+	wg.Wait()
+
+	for _, recorded := range workerEvents {
+		events = append(events, recorded...)
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	nodes := make(map[string]string, len(keys))
+
+	for i, key := range keys {
+		nodes[key] = assigned[i]
+	}
+
+	return nodes, nil
+}
@@ -0,0 +1,247 @@
+package rendezvous
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindNodes(t *testing.T) {
+	keys := make([]string, 100)
+
+	for i := range keys {
+		keys[i] = "key-" + strconv.Itoa(i)
+	}
+
+	t.Run("should find the node of each key", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4", "jg5"})
+		sr.MarkDown("jg2")
+
+		nodes, err := sr.FindNodes(keys)
+		assert.NoError(t, err)
+		assert.Len(t, nodes, len(keys))
+
+		for _, key := range keys {
+			assert.Equal(t, mustFindNode(t, sr, key), nodes[key])
+		}
+	})
+
+	t.Run("should group keys by node", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4", "jg5"})
+
+		groups, err := sr.GroupByNode(keys)
+		assert.NoError(t, err)
+
+		count := 0
+
+		for node, nodeKeys := range groups {
+			assert.Equal(t, sr.KeysForNode(node, keys), nodeKeys)
+
+			count += len(nodeKeys)
+		}
+
+		assert.Equal(t, len(keys), count)
+	})
+
+	t.Run("should return ErrNoNodes on empty skeleton", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		_, err = sr.FindNodes(keys)
+		assert.ErrorIs(t, err, ErrNoNodes)
+
+		_, err = sr.GroupByNode(keys)
+		assert.ErrorIs(t, err, ErrNoNodes)
+	})
+}
+
+func TestAssignAll(t *testing.T) {
+	keys := make([]string, 1000)
+
+	for i := range keys {
+		keys[i] = "key-" + strconv.Itoa(i)
+	}
+
+	t.Run("should assign every key like FindNodes", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4", "jg5"})
+		sr.MarkDraining("jg3")
+
+		expected, err := sr.FindNodes(keys)
+		assert.NoError(t, err)
+
+		for _, parallelism := range []int{0, 1, 3, 8, 5000} {
+			nodes, err := sr.AssignAll(keys, parallelism)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, nodes)
+		}
+	})
+
+	t.Run("should assign no keys", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		nodes, err := sr.AssignAll(nil, 4)
+		assert.NoError(t, err)
+		assert.Empty(t, nodes)
+	})
+
+	t.Run("should return ErrNoNodes on empty skeleton", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		_, err = sr.AssignAll(keys, 4)
+		assert.ErrorIs(t, err, ErrNoNodes)
+	})
+}
+
+func BenchmarkFindNodes(b *testing.B) {
+	sr, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(4), MinClusterSize(2))
+
+	assert.NoError(b, err)
+
+	nodes := make([]string, 0)
+
+	for i := 0; i < 64; i++ {
+		nodes = append(nodes, "jg"+strconv.Itoa(i))
+	}
+
+	sr.SetNodes(nodes)
+
+	keys := make([]string, 1000)
+
+	for i := range keys {
+		keys[i] = "key-" + strconv.Itoa(i)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		sr.FindNodes(keys)
+	}
+}
+
+func BenchmarkAssignAll(b *testing.B) {
+	sr, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(4), MinClusterSize(2))
+
+	assert.NoError(b, err)
+
+	nodes := make([]string, 0)
+
+	for i := 0; i < 64; i++ {
+		nodes = append(nodes, "jg"+strconv.Itoa(i))
+	}
+
+	sr.SetNodes(nodes)
+
+	keys := make([]string, 100000)
+
+	for i := range keys {
+		keys[i] = "key-" + strconv.Itoa(i)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		sr.AssignAll(keys, 0)
+	}
+}
+
+func TestBatchContext(t *testing.T) {
+	keys := make([]string, 1000)
+
+	for i := range keys {
+		keys[i] = "key-" + strconv.Itoa(i)
+	}
+
+	// cancelling skeleton cancels the context on the first scored node and
+	// counts the scored nodes.
+	cancelling := func(t *testing.T) (*SkeletonRendezvous, context.Context, *int64) {
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+
+		var scored int64
+
+		sr, err := NewSkeletonRendezvous(ScoreFunc(func(node, key string) uint64 {
+			atomic.AddInt64(&scored, 1)
+			cancel()
+
+			return uint64(len(node)) << 60
+		}))
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		return sr, ctx, &scored
+	}
+
+	t.Run("should look up every key with a live context", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		expected, err := sr.FindNodes(keys)
+		assert.NoError(t, err)
+
+		nodes, err := sr.FindNodesContext(context.Background(), keys)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, nodes)
+
+		nodes, err = sr.AssignAllContext(context.Background(), keys, 4)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, nodes)
+
+		groups, err := sr.GroupByNodeContext(context.Background(), keys)
+		assert.NoError(t, err)
+
+		for node, grouped := range groups {
+			for _, key := range grouped {
+				assert.Equal(t, node, expected[key])
+			}
+		}
+	})
+
+	t.Run("should stop FindNodesContext once the context is done", func(t *testing.T) {
+		sr, ctx, scored := cancelling(t)
+
+		nodes, err := sr.FindNodesContext(ctx, keys)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Nil(t, nodes)
+		assert.Less(t, atomic.LoadInt64(scored), int64(len(keys)))
+	})
+
+	t.Run("should stop GroupByNodeContext once the context is done", func(t *testing.T) {
+		sr, ctx, scored := cancelling(t)
+
+		groups, err := sr.GroupByNodeContext(ctx, keys)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Nil(t, groups)
+		assert.Less(t, atomic.LoadInt64(scored), int64(len(keys)))
+	})
+
+	t.Run("should stop AssignAllContext once the context is done", func(t *testing.T) {
+		sr, ctx, scored := cancelling(t)
+
+		nodes, err := sr.AssignAllContext(ctx, keys, 4)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Nil(t, nodes)
+		assert.Less(t, atomic.LoadInt64(scored), int64(len(keys)))
+	})
+}
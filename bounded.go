@@ -0,0 +1,138 @@
+package rendezvous
+
+import (
+	"fmt"
+	"math"
+)
+
+// BoundedLoad enables bounded load selection, every FindNode counts as an
+// assignment of the key to the selected node and a node which already holds
+// more than (1+epsilon) times its share of the load of its cluster is skipped
+// in favor of the next highest score. The share of a node is proportional to
+// its weight, so a node with weight 2 is allowed twice the load of a node
+// with weight 1. The load is reset when the topology changes. PeekNode and
+// the methods inspecting the placement, such as Watch, KeysForNode,
+// SimulateRemove and Diff, do not count assignments.
+func BoundedLoad(epsilon float64) Option {
+	return func(o *Options) error {
+		if epsilon < 0 || math.IsNaN(epsilon) {
+			return fmt.Errorf("%w: bounded load epsilon must not be negative, got %v", ErrInvalidOption, epsilon)
+		}
+
+		o.boundedLoad = true
+		o.loadEpsilon = epsilon
+
+		return nil
+	}
+}
+
+// Loads returns the number of keys assigned to each node since the last
+// topology change or ResetLoads, it is only counted with BoundedLoad and
+// includes the loads reported through ReportLoad.
+func (sr *SkeletonRendezvous) Loads() map[string]int {
+	sr.loadMu.Lock()
+	defer sr.loadMu.Unlock()
+
+	loads := make(map[string]int, len(sr.loads))
+
+	for node, load := range sr.loads {
+		loads[node] = load
+	}
+
+	return loads
+}
+
+// ReportLoad sets the load of the node reported by the node itself or by the
+// caller, replacing the number of keys counted for it. Bounded load selection
+// consumes the reported load like a counted one, a negative load is taken as
+// 0. It returns ErrNodeNotFound when the node does not exist.
+func (sr *SkeletonRendezvous) ReportLoad(node string, load int) error {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+
+	if !sr.hasNode(node) {
+		return fmt.Errorf("%w: %s", ErrNodeNotFound, node)
+	}
+
+	sr.loadMu.Lock()
+	defer sr.loadMu.Unlock()
+
+	if sr.loads == nil {
+		sr.loads = make(map[string]int)
+	}
+
+	sr.loads[node] = int(math.Max(float64(load), 0))
+
+	return nil
+}
+
+// PeekNode find selected node like FindNode, but with BoundedLoad the lookup
+// does not count as an assignment, so the placement can be inspected without
+// skewing the balance. Without BoundedLoad it is the same as FindNode.
+func (sr *SkeletonRendezvous) PeekNode(key string) (string, error) {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+
+	return sr.peekNode(key)
+}
+
+// ResetLoads forgets every key assignment counted for bounded load.
+func (sr *SkeletonRendezvous) ResetLoads() {
+	sr.loadMu.Lock()
+	defer sr.loadMu.Unlock()
+
+	sr.loads = nil
+}
+
+// findBoundedNode selects the highest ranked node of the cluster which load
+// is still under its capacity, and counts the assignment when assign is set.
+func (sr *SkeletonRendezvous) findBoundedNode(key string, nodes []string, assign bool) string {
+	if len(nodes) == 0 {
+		return ""
+	}
+
+	ranked := sr.rankNodes(key, nodes)
+
+	sr.loadMu.Lock()
+	defer sr.loadMu.Unlock()
+
+	if sr.loads == nil {
+		sr.loads = make(map[string]int)
+	}
+
+	clusterLoad := 0
+	clusterWeight := 0.0
+
+	for _, node := range nodes {
+		clusterLoad += sr.loads[node]
+		clusterWeight += sr.nodeWeight(node)
+	}
+
+	selectedNode := ranked[0].node
+
+	for _, candidate := range ranked {
+		if sr.loads[candidate.node] < sr.nodeCapacity(candidate.node, clusterLoad+1, clusterWeight, len(nodes)) {
+			selectedNode = candidate.node
+
+			break
+		}
+	}
+
+	if assign {
+		sr.loads[selectedNode]++
+	}
+
+	return selectedNode
+}
+
+// nodeCapacity returns how many keys the node may hold out of the cluster
+// load, by the weight share of the node in its cluster.
+func (sr *SkeletonRendezvous) nodeCapacity(node string, clusterLoad int, clusterWeight float64, clusterSize int) int {
+	share := 1 / float64(clusterSize)
+
+	if clusterWeight > 0 {
+		share = sr.nodeWeight(node) / clusterWeight
+	}
+
+	return int(math.Ceil((1 + sr.options.loadEpsilon) * float64(clusterLoad) * share))
+}
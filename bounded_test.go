@@ -0,0 +1,157 @@
+package rendezvous
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoundedLoad(t *testing.T) {
+	t.Run("should not exceed capacity for a uniform key stream", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(ClusterSize(8), BoundedLoad(0.25))
+
+		assert.NoError(t, err)
+
+		nodes := make([]string, 0)
+
+		for i := 0; i < 8; i++ {
+			nodes = append(nodes, "node-"+strconv.Itoa(i))
+		}
+
+		sr.SetNodes(nodes)
+
+		for i := 0; i < 8000; i++ {
+			sr.FindNode("key-" + strconv.Itoa(i))
+
+			loads := sr.Loads()
+			capacity := int(math.Ceil(1.25 * float64(i+1) / 8))
+
+			for _, load := range loads {
+				assert.LessOrEqual(t, load, capacity)
+			}
+		}
+
+		total := 0
+
+		for _, load := range sr.Loads() {
+			total += load
+		}
+
+		assert.Equal(t, 8000, total)
+	})
+
+	t.Run("should bound load of each node by its weight", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(ClusterSize(4), BoundedLoad(0.1))
+
+		assert.NoError(t, err)
+
+		weights := map[string]float64{"jg1": 4, "jg2": 2, "jg3": 1, "jg4": 1}
+
+		sr.SetNodesWeighted(weights)
+
+		for i := 0; i < 8000; i++ {
+			sr.FindNode("key-" + strconv.Itoa(i))
+		}
+
+		loads := sr.Loads()
+
+		for node, weight := range weights {
+			assert.LessOrEqual(t, loads[node], int(math.Ceil(1.1*8000*weight/8)), node)
+		}
+
+		assert.Greater(t, loads["jg1"], loads["jg3"])
+	})
+
+	t.Run("should skip node with reported load over capacity", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(ClusterSize(2), BoundedLoad(0))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2"})
+
+		ranked, err := sr.FindN("key", 2)
+
+		assert.NoError(t, err)
+		assert.NoError(t, sr.ReportLoad(ranked[0], 100))
+
+		assert.Equal(t, ranked[1], mustFindNode(t, sr, "key"))
+		assert.Equal(t, map[string]int{ranked[0]: 100, ranked[1]: 1}, sr.Loads())
+	})
+
+	t.Run("should reject load of unknown node", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(BoundedLoad(0))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1"})
+
+		assert.ErrorIs(t, sr.ReportLoad("jg2", 1), ErrNodeNotFound)
+		assert.NoError(t, sr.ReportLoad("jg1", -5))
+		assert.Equal(t, map[string]int{"jg1": 0}, sr.Loads())
+	})
+
+	t.Run("should reset loads when topology changes", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(BoundedLoad(0.1))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2"})
+		sr.FindNode("key")
+
+		assert.Equal(t, 1, len(sr.Loads()))
+
+		sr.SetNodes([]string{"jg3"})
+
+		assert.Empty(t, sr.Loads())
+	})
+
+	t.Run("should not count lookups which inspect the placement", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(ClusterSize(4), BoundedLoad(0.25))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		other, err := NewSkeletonRendezvous(ClusterSize(4), BoundedLoad(0.25))
+
+		assert.NoError(t, err)
+
+		other.SetNodes([]string{"jg1", "jg2", "jg3"})
+
+		keys := make([]string, 0, 100)
+
+		for i := 0; i < 100; i++ {
+			keys = append(keys, "key-"+strconv.Itoa(i))
+		}
+
+		sr.Watch(keys, func(key, oldNode, newNode string) {})
+		sr.KeysForNode("jg1", keys)
+		sr.SimulateRemove([]string{"jg1"}, keys)
+		Diff(sr, other, keys)
+
+		for _, key := range keys {
+			_, err := sr.PeekNode(key)
+
+			assert.NoError(t, err)
+
+			_, err = sr.FindPreviousNode(key)
+
+			assert.NoError(t, err)
+		}
+
+		assert.Empty(t, sr.Loads())
+
+		node, err := sr.FindNode("key-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int{node: 1}, sr.Loads())
+	})
+
+	t.Run("should reject negative epsilon", func(t *testing.T) {
+		_, err := NewSkeletonRendezvous(BoundedLoad(-1))
+
+		assert.ErrorIs(t, err, ErrInvalidOption)
+	})
+}
@@ -0,0 +1,105 @@
+package rendezvous
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+)
+
+// LookupCache memoizes the node of up to size keys found by FindNode, the
+// least recently used keys are evicted first. The cache is emptied on every
+// change of the skeleton and is not used with bounded load, since the node
+// of a key then depends on the loads.
+func LookupCache(size int) Option {
+	return func(o *Options) error {
+		if size < 0 {
+			return fmt.Errorf("%w: lookup cache size must not be negative, got %d", ErrInvalidOption, size)
+		}
+
+		o.lookupCacheSize = size
+
+		return nil
+	}
+}
+
+// lookupCache is a least recently used cache of the node by key.
+type lookupCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type cacheEntry struct {
+	key  string
+	node string
+}
+
+// newLookupCache creates the cache of size keys, nil when size is 0.
+func newLookupCache(size int) *lookupCache {
+	if size == 0 {
+		return nil
+	}
+
+	return &lookupCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element, size),
+	}
+}
+
+func (c *lookupCache) get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+
+	if !ok {
+		return "", false
+	}
+
+	c.order.MoveToFront(element)
+
+	return element.Value.(*cacheEntry).node, true
+}
+
+func (c *lookupCache) add(key string, node string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*cacheEntry).node = node
+		c.order.MoveToFront(element)
+
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, node: node})
+
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// purge empties the cache, a nil cache is ignored.
+func (c *lookupCache) purge() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.order.Init()
+	c.entries = make(map[string]*list.Element, c.size)
+}
+
+func (c *lookupCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
@@ -0,0 +1,73 @@
+package rendezvous
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupCache(t *testing.T) {
+	t.Run("should memoize lookups up to the size", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(LookupCache(2))
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		node := mustFindNode(t, sr, "key-1")
+
+		assert.Equal(t, node, mustFindNode(t, sr, "key-1"))
+		assert.Equal(t, 1, sr.cache.len())
+
+		mustFindNode(t, sr, "key-2")
+		mustFindNode(t, sr, "key-1")
+		mustFindNode(t, sr, "key-3")
+
+		assert.Equal(t, 2, sr.cache.len())
+
+		_, ok := sr.cache.get("key-2")
+		assert.False(t, ok)
+
+		cached, ok := sr.cache.get("key-1")
+		assert.True(t, ok)
+		assert.Equal(t, node, cached)
+	})
+
+	t.Run("should be emptied on changes", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(LookupCache(100))
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		for i := 0; i < 20; i++ {
+			mustFindNode(t, sr, "key-"+strconv.Itoa(i))
+		}
+
+		node := mustFindNode(t, sr, "key-1")
+
+		sr.MarkDown(node)
+
+		assert.Equal(t, 0, sr.cache.len())
+		assert.NotEqual(t, node, mustFindNode(t, sr, "key-1"))
+
+		sr.RemoveNodes([]string{"jg1"})
+
+		assert.Equal(t, 0, sr.cache.len())
+	})
+
+	t.Run("should not be used with bounded load", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(LookupCache(100), BoundedLoad(0.25))
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		mustFindNode(t, sr, "key-1")
+
+		assert.Equal(t, 0, sr.cache.len())
+	})
+
+	t.Run("should reject negative size", func(t *testing.T) {
+		_, err := NewSkeletonRendezvous(LookupCache(-1))
+		assert.ErrorIs(t, err, ErrInvalidOption)
+	})
+}
@@ -0,0 +1,146 @@
+package rendezvous
+
+import "math"
+
+// SetClusterWeights sets the weight of each cluster keyed by cluster index,
+// a cluster with weight 2 receives twice as many keys as a cluster with
+// weight 1. Clusters without weight in the list default to 1 and negative
+// weights are treated as 0. Passing nil restores unweighted selection.
+func (sr *SkeletonRendezvous) SetClusterWeights(weights []float64) {
+	sr.update(func() {
+		sr.setClusterWeights(weights)
+	})
+}
+
+func (sr *SkeletonRendezvous) setClusterWeights(weights []float64) {
+	sr.clusterWeights = nil
+
+	if weights != nil {
+		sr.clusterWeights = append(make([]float64, 0, len(weights)), weights...)
+	}
+
+	sr.refreshBranchWeights()
+}
+
+// refreshBranchWeights spreads the cluster weights over every branch
+// position of the skeleton and stores them as prefix sums, so the weight
+// of any subtree can be read in constant time during the branch walk.
+func (sr *SkeletonRendezvous) refreshBranchWeights() {
+	if sr.clusterWeights == nil && sr.nodeWeights == nil && sr.options.overflowPolicy != BalanceOverflow && !sr.options.rampsUp() {
+		sr.branchWeights = nil
+
+		return
+	}
+
+	positions := int(math.Pow(float64(sr.options.fanOut), float64(sr.VirtualNodes)))
+
+	owners := make([]int, positions)
+	shares := make([]int, len(sr.Clusters))
+
+	for position := range owners {
+		owners[position] = sr.clusterIndex(position)
+
+		if owners[position] >= 0 && owners[position] < len(sr.Clusters) {
+			shares[owners[position]]++
+		}
+	}
+
+	sr.branchWeights = make([]float64, positions+1)
+
+	for position, owner := range owners {
+		weight := 0.0
+
+		if owner >= 0 && owner < len(sr.Clusters) {
+			weight = sr.clusterWeight(owner) / float64(shares[owner])
+		}
+
+		sr.branchWeights[position+1] = sr.branchWeights[position] + weight
+	}
+}
+
+// clusterWeight returns the weight of the cluster, when no weight is set
+// for clusters the cluster weighs the sum of its node weights.
+func (sr *SkeletonRendezvous) clusterWeight(index int) float64 {
+	if sr.clusterWeights == nil {
+		weight := 0.0
+
+		for _, node := range sr.Clusters[index] {
+			weight += sr.nodeWeight(node) * sr.nodeRamp(node)
+		}
+
+		return weight
+	}
+
+	if index >= len(sr.clusterWeights) {
+		return 1
+	}
+
+	return math.Max(sr.clusterWeights[index], 0)
+}
+
+func (sr *SkeletonRendezvous) selectWeightedBranch(key string, level int, position int) int {
+	span := sr.subtreeSpan(level)
+
+	highestScore := -1.0
+	highestTie := uint64(0)
+	targetBranch := 0
+
+	for j := 0; j < sr.options.fanOut; j++ {
+		hashScore := sr.rank(sr.hashBranch(level, j, key))
+
+		score := weightedScore(hashScore, sr.subtreeWeight(span, position, j))
+		tie := branchTieBreak(hashScore, j)
+
+		if score > highestScore || (score == highestScore && tie > highestTie) {
+			highestScore = score
+			highestTie = tie
+			targetBranch = j
+		}
+	}
+
+	return targetBranch
+}
+
+// subtreeWeights returns the weight of each branch on the given level below
+// the branch position chosen so far.
+func (sr *SkeletonRendezvous) subtreeWeights(level int, position int) []float64 {
+	span := sr.subtreeSpan(level)
+
+	weights := make([]float64, sr.options.fanOut)
+
+	for j := range weights {
+		weights[j] = sr.subtreeWeight(span, position, j)
+	}
+
+	return weights
+}
+
+// subtreeSpan returns how many leaf positions are below a branch of the
+// given level.
+func (sr *SkeletonRendezvous) subtreeSpan(level int) int {
+	if level < len(sr.spans) {
+		return sr.spans[level]
+	}
+
+	return int(math.Pow(float64(sr.options.fanOut), float64(sr.VirtualNodes-level-1)))
+}
+
+// subtreeWeight returns the weight of the branch below the branch position
+// chosen so far.
+func (sr *SkeletonRendezvous) subtreeWeight(span int, position int, branch int) float64 {
+	start := (position*sr.options.fanOut + branch) * span
+
+	return sr.branchWeights[start+span] - sr.branchWeights[start]
+}
+
+// weightedScore turns a hash score into the logarithmic weighted rendezvous
+// score, which selects a candidate with probability proportional to weight.
+func weightedScore(hashScore uint64, weight float64) float64 {
+	if weight <= 0 {
+		return 0
+	}
+
+	unit := (float64(hashScore>>11) + 0.5) / (1 << 53)
+
+	return weight / -math.Log(unit)
+}
@@ -0,0 +1,66 @@
+package rendezvous
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetClusterWeights(t *testing.T) {
+	t.Run("should distribute keys proportionally to cluster weights", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(2), HashAlgorithm(newMixedHash()))
+
+		assert.NoError(t, err)
+
+		sr.SetClusters([][]string{{"jg1"}, {"jg2"}, {"jg3"}, {"jg4"}})
+		sr.SetClusterWeights([]float64{1, 1, 1, 3})
+
+		counts := make(map[string]int)
+
+		for i := 0; i < 6000; i++ {
+			counts[mustFindNode(t, sr, "key-"+strconv.Itoa(i))]++
+		}
+
+		assert.InDelta(t, 1000, counts["jg1"], 200)
+		assert.InDelta(t, 1000, counts["jg2"], 200)
+		assert.InDelta(t, 1000, counts["jg3"], 200)
+		assert.InDelta(t, 3000, counts["jg4"], 300)
+	})
+
+	t.Run("should never select cluster with zero weight", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(2), HashAlgorithm(newMixedHash()))
+
+		assert.NoError(t, err)
+
+		sr.SetClusters([][]string{{"jg1"}, {"jg2"}, {"jg3"}, {"jg4"}})
+		sr.SetClusterWeights([]float64{1, 0})
+
+		for i := 0; i < 1000; i++ {
+			assert.NotEqual(t, "jg2", mustFindNode(t, sr, "key-"+strconv.Itoa(i)))
+		}
+	})
+}
+
+func TestWeightedScore(t *testing.T) {
+	t.Run("should compute the logarithmic weighted score", func(t *testing.T) {
+		assert.InDelta(t, 2/math.Ln2, weightedScore(1<<63, 2), 1e-9)
+		assert.Equal(t, 0.0, weightedScore(1<<63, 0))
+		assert.Less(t, weightedScore(1<<62, 1), weightedScore(1<<63, 1))
+	})
+
+	t.Run("should win in proportion to weight", func(t *testing.T) {
+		random := rand.New(rand.NewSource(1))
+		wins := 0
+
+		for i := 0; i < 30000; i++ {
+			if weightedScore(random.Uint64(), 2) > weightedScore(random.Uint64(), 1) {
+				wins++
+			}
+		}
+
+		assert.InDelta(t, 20000, wins, 300)
+	})
+}
@@ -0,0 +1,381 @@
+// Command rendezvous answers questions about a skeleton rendezvous without
+// writing Go: which clusters a node list generates, which node owns a key,
+// how keys are distributed and how many keys move between two node lists.
+//
+//	rendezvous clusters -nodes jg1,jg2,jg3,jg4
+//	rendezvous lookup -nodes-file nodes.txt user-1 user-2
+//	rendezvous simulate -nodes-file nodes.txt -keys 100000
+//	rendezvous diff -nodes-file nodes.txt -new-nodes-file new-nodes.txt
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+	"github.com/RiskyFeryansyahP/go-skeleton-rendezvous/analyze"
+)
+
+const usage = `usage: rendezvous <command> [flags] [args]
+
+commands:
+  clusters   print the generated clusters
+  lookup     print the node owning each key given as argument
+  simulate   print the distribution of generated keys
+  diff       print how many keys move to the new nodes
+
+run rendezvous <command> -h for the flags of a command`
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, errorMessage(err))
+		}
+
+		os.Exit(2)
+	}
+}
+
+// errorMessage prefixes the error with the command name, unless the error
+// of the package already starts with it.
+func errorMessage(err error) string {
+	message := err.Error()
+
+	if strings.HasPrefix(message, "rendezvous: ") {
+		return message
+	}
+
+	return "rendezvous: " + message
+}
+
+func run(args []string, stdout io.Writer, stderr io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprintln(stderr, usage)
+
+		return flag.ErrHelp
+	}
+
+	commands := map[string]func(*flag.FlagSet, []string, io.Writer) error{
+		"clusters": clusters,
+		"lookup":   lookup,
+		"simulate": simulate,
+		"diff":     diff,
+	}
+
+	command, ok := commands[args[0]]
+
+	if !ok {
+		fmt.Fprintln(stderr, usage)
+
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+
+	flags := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	return command(flags, args[1:], stdout)
+}
+
+// skeletonFlags are the flags building the skeleton of a command.
+type skeletonFlags struct {
+	nodes          string
+	nodesFile      string
+	snapshot       string
+	fanOut         int
+	clusterSize    int
+	minClusterSize int
+	replicas       int
+	depth          int
+	hash           string
+	seed           uint64
+}
+
+func newSkeletonFlags(flags *flag.FlagSet) *skeletonFlags {
+	s := &skeletonFlags{}
+
+	flags.StringVar(&s.nodes, "nodes", "", "comma separated nodes")
+	flags.StringVar(&s.nodesFile, "nodes-file", "", "file of newline delimited nodes")
+	flags.StringVar(&s.snapshot, "snapshot", "", "JSON snapshot of a skeleton, replacing the nodes and options")
+	flags.IntVar(&s.fanOut, "fan-out", 3, "fan out of the branches")
+	flags.IntVar(&s.clusterSize, "cluster-size", 2, "number of nodes in a cluster")
+	flags.IntVar(&s.minClusterSize, "min-cluster-size", 0, "minimum number of nodes in a cluster, 0 keeps the default")
+	flags.IntVar(&s.replicas, "replicas", 1, "virtual copies of each node")
+	flags.IntVar(&s.depth, "depth", 0, "pinned depth of the branch tree, 0 derives it from the clusters")
+	flags.StringVar(&s.hash, "hash", "", "registered hash algorithm, such as fnv64a (default fnv64)")
+	flags.Uint64Var(&s.seed, "seed", 0, "seed mixed into every hash")
+
+	return s
+}
+
+// build creates the skeleton of the flags, nodes and nodesFile are the
+// nodes to set when no snapshot is given.
+func (s *skeletonFlags) build(nodes string, nodesFile string) (*rendezvous.SkeletonRendezvous, error) {
+	options := []rendezvous.Option{
+		rendezvous.FanOut(s.fanOut),
+		rendezvous.ClusterSize(s.clusterSize),
+		rendezvous.Replicas(s.replicas),
+		rendezvous.Seed(s.seed),
+	}
+
+	if s.minClusterSize > 0 {
+		options = append(options, rendezvous.MinClusterSize(s.minClusterSize))
+	}
+
+	if s.depth > 0 {
+		options = append(options, rendezvous.Depth(s.depth))
+	}
+
+	if s.hash != "" {
+		options = append(options, rendezvous.HashAlgorithmByName(s.hash))
+	}
+
+	sr, err := rendezvous.NewSkeletonRendezvous(options...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if s.snapshot != "" {
+		data, err := os.ReadFile(s.snapshot)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return sr, sr.UnmarshalJSON(data)
+	}
+
+	list, err := readNodes(nodes, nodesFile)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return nil, errors.New("no nodes, set -nodes, -nodes-file or -snapshot")
+	}
+
+	sr.SetNodes(list)
+
+	return sr, nil
+}
+
+// readNodes returns the comma separated nodes followed by the nodes of the
+// file, read like SetNodesFromReader.
+func readNodes(nodes string, nodesFile string) ([]string, error) {
+	list := make([]string, 0)
+
+	if nodes != "" {
+		list = append(list, strings.Split(nodes, ",")...)
+	}
+
+	if nodesFile == "" {
+		return list, nil
+	}
+
+	file, err := os.Open(nodesFile)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line != "" && !strings.HasPrefix(line, "#") {
+			list = append(list, line)
+		}
+	}
+
+	return list, scanner.Err()
+}
+
+func clusters(flags *flag.FlagSet, args []string, stdout io.Writer) error {
+	s := newSkeletonFlags(flags)
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	sr, err := s.build(s.nodes, s.nodesFile)
+
+	if err != nil {
+		return err
+	}
+
+	for i, cluster := range sr.ClusterNodes() {
+		ids := make([]string, len(cluster))
+
+		for j, node := range cluster {
+			ids[j] = node.ID
+		}
+
+		fmt.Fprintf(stdout, "cluster %d: %s\n", i, strings.Join(ids, " "))
+	}
+
+	return nil
+}
+
+func lookup(flags *flag.FlagSet, args []string, stdout io.Writer) error {
+	s := newSkeletonFlags(flags)
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() == 0 {
+		return errors.New("no keys to look up")
+	}
+
+	sr, err := s.build(s.nodes, s.nodesFile)
+
+	if err != nil {
+		return err
+	}
+
+	for _, key := range flags.Args() {
+		node, err := sr.FindNode(key)
+
+		if err != nil {
+			return fmt.Errorf("key %s: %w", key, err)
+		}
+
+		fmt.Fprintf(stdout, "%s\t%s\n", key, node)
+	}
+
+	return nil
+}
+
+func simulate(flags *flag.FlagSet, args []string, stdout io.Writer) error {
+	s := newSkeletonFlags(flags)
+
+	keys := flags.Int("keys", 100000, "number of generated keys")
+	prefix := flags.String("prefix", "key-", "prefix of the generated keys")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *keys < 1 {
+		return fmt.Errorf("-keys must be at least 1, got %d", *keys)
+	}
+
+	sr, err := s.build(s.nodes, s.nodesFile)
+
+	if err != nil {
+		return err
+	}
+
+	report, err := analyze.Generated(sr, *keys, func(i int) string {
+		return *prefix + strconv.Itoa(i)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "keys\t%d\nmin\t%d\nmax\t%d\nmean\t%.2f\nstddev\t%.2f\nskew\t%.2f%%\n",
+		report.Keys, report.Min, report.Max, report.Mean, report.StdDev, report.Skew)
+
+	nodes := make([]string, 0, len(report.NodeCounts))
+
+	for node := range report.NodeCounts {
+		nodes = append(nodes, node)
+	}
+
+	sort.Strings(nodes)
+
+	for _, node := range nodes {
+		fmt.Fprintf(stdout, "%s\t%d\n", node, report.NodeCounts[node])
+	}
+
+	return nil
+}
+
+func diff(flags *flag.FlagSet, args []string, stdout io.Writer) error {
+	s := newSkeletonFlags(flags)
+
+	newNodes := flags.String("new-nodes", "", "comma separated new nodes")
+	newNodesFile := flags.String("new-nodes-file", "", "file of newline delimited new nodes")
+	keys := flags.Int("keys", 100000, "number of generated keys")
+	prefix := flags.String("prefix", "key-", "prefix of the generated keys")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *keys < 1 {
+		return fmt.Errorf("-keys must be at least 1, got %d", *keys)
+	}
+
+	oldSkeleton, err := s.build(s.nodes, s.nodesFile)
+
+	if err != nil {
+		return err
+	}
+
+	nodes, err := readNodes(*newNodes, *newNodesFile)
+
+	if err != nil {
+		return fmt.Errorf("new nodes: %w", err)
+	}
+
+	if len(nodes) == 0 {
+		return errors.New("no new nodes, set -new-nodes or -new-nodes-file")
+	}
+
+	// the new nodes join and leave the old skeleton, keeping its options
+	// and its clusters like a membership change would.
+	newSkeleton := oldSkeleton.Clone()
+	newSkeleton.SyncNodes(nodes)
+
+	generated := make([]string, *keys)
+
+	for i := range generated {
+		generated[i] = *prefix + strconv.Itoa(i)
+	}
+
+	moves := rendezvous.Diff(oldSkeleton, newSkeleton, generated)
+
+	type move struct {
+		rendezvous.Move
+		keys int
+	}
+
+	sorted := make([]move, 0, len(moves))
+	moved := 0
+
+	for m, movedKeys := range moves {
+		sorted = append(sorted, move{Move: m, keys: len(movedKeys)})
+		moved += len(movedKeys)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].From != sorted[j].From {
+			return sorted[i].From < sorted[j].From
+		}
+
+		return sorted[i].To < sorted[j].To
+	})
+
+	percent := float64(moved) / float64(*keys) * 100
+
+	fmt.Fprintf(stdout, "moved %d of %d keys (%.2f%%)\n", moved, *keys, percent)
+
+	for _, m := range sorted {
+		fmt.Fprintf(stdout, "%s -> %s\t%d\n", m.From, m.To, m.keys)
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+	"github.com/stretchr/testify/assert"
+)
+
+func runCommand(t *testing.T, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+
+	err := run(args, &stdout, &stderr)
+
+	return stdout.String(), err
+}
+
+func TestRun(t *testing.T) {
+	t.Run("should print the clusters", func(t *testing.T) {
+		out, err := runCommand(t, "clusters", "-nodes", "jg1,jg2,jg3,jg4")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "cluster 0: jg1 jg2\ncluster 1: jg3 jg4\n", out)
+	})
+
+	t.Run("should print the clusters of a single node", func(t *testing.T) {
+		out, err := runCommand(t, "clusters", "-cluster-size", "1", "-nodes", "a,b,c")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "cluster 0: a\ncluster 1: b\ncluster 2: c\n", out)
+	})
+
+	t.Run("should look up keys", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous(rendezvous.HashAlgorithmByName("fnv64a"))
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		node, err := sr.FindNode("user-1")
+		assert.NoError(t, err)
+
+		file := filepath.Join(t.TempDir(), "nodes.txt")
+		assert.NoError(t, os.WriteFile(file, []byte("# nodes\njg1\njg2\njg3\njg4\n"), 0o644))
+
+		out, err := runCommand(t, "lookup", "-nodes-file", file, "-hash", "fnv64a", "user-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "user-1\t"+node+"\n", out)
+	})
+
+	t.Run("should look up keys from a snapshot", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous(rendezvous.FanOut(4))
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4", "jg5"})
+
+		node, err := sr.FindNode("user-1")
+		assert.NoError(t, err)
+
+		data, err := sr.MarshalJSON()
+		assert.NoError(t, err)
+
+		file := filepath.Join(t.TempDir(), "snapshot.json")
+		assert.NoError(t, os.WriteFile(file, data, 0o644))
+
+		out, err := runCommand(t, "lookup", "-snapshot", file, "user-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "user-1\t"+node+"\n", out)
+	})
+
+	t.Run("should simulate the distribution", func(t *testing.T) {
+		out, err := runCommand(t, "simulate", "-nodes", "jg1,jg2,jg3,jg4", "-keys", "1000")
+
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(out, "keys\t1000\n"))
+		assert.Contains(t, out, "\njg4\t")
+	})
+
+	t.Run("should diff two node lists", func(t *testing.T) {
+		out, err := runCommand(t, "diff", "-nodes", "jg1,jg2,jg3,jg4", "-new-nodes", "jg1,jg2,jg3", "-hash", "fnv64a", "-keys", "1000")
+
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(out, "moved "))
+		assert.Contains(t, out, "jg4 -> ")
+	})
+
+	t.Run("should diff a snapshot with its options", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous(rendezvous.FanOut(5), rendezvous.ClusterSize(3), rendezvous.MinClusterSize(1))
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4", "jg5", "jg6", "jg7"})
+
+		data, err := sr.MarshalJSON()
+		assert.NoError(t, err)
+
+		file := filepath.Join(t.TempDir(), "snapshot.json")
+		assert.NoError(t, os.WriteFile(file, data, 0o644))
+
+		out, err := runCommand(t, "diff", "-snapshot", file, "-new-nodes", "jg1,jg2,jg3,jg4,jg5,jg6,jg7", "-keys", "1000")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "moved 0 of 1000 keys (0.00%)\n", out)
+	})
+
+	t.Run("should return error for usage", func(t *testing.T) {
+		_, err := runCommand(t)
+		assert.ErrorIs(t, err, flag.ErrHelp)
+
+		_, err = runCommand(t, "unknown")
+		assert.Error(t, err)
+
+		_, err = runCommand(t, "clusters")
+		assert.Error(t, err)
+
+		_, err = runCommand(t, "lookup", "-nodes", "jg1")
+		assert.Error(t, err)
+
+		_, err = runCommand(t, "clusters", "-nodes", "jg1", "-fan-out", "1")
+		assert.ErrorIs(t, err, rendezvous.ErrInvalidOption)
+	})
+
+	t.Run("should return error for keys below 1", func(t *testing.T) {
+		_, err := runCommand(t, "diff", "-nodes", "jg1,jg2", "-new-nodes", "jg1", "-keys", "-1")
+		assert.Error(t, err)
+
+		_, err = runCommand(t, "simulate", "-nodes", "jg1,jg2", "-keys", "-1")
+		assert.Error(t, err)
+
+		_, err = runCommand(t, "simulate", "-nodes", "jg1,jg2", "-keys", "0")
+		assert.Error(t, err)
+	})
+}
+
+func TestErrorMessage(t *testing.T) {
+	t.Run("should prefix the error with the command name once", func(t *testing.T) {
+		assert.Equal(t, "rendezvous: no keys to look up", errorMessage(errors.New("no keys to look up")))
+		assert.Equal(t, "rendezvous: no nodes", errorMessage(rendezvous.ErrNoNodes))
+	})
+}
@@ -0,0 +1,170 @@
+package rendezvous
+
+import (
+	"hash/fnv"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrentUse(t *testing.T) {
+	t.Run("should share the hash with clones concurrently", func(t *testing.T) {
+		first, err := NewSkeletonRendezvous(HashAlgorithm(fnv.New64a()))
+
+		assert.NoError(t, err)
+
+		reference, err := NewSkeletonRendezvous(HashAlgorithm(fnv.New64a()))
+
+		assert.NoError(t, err)
+
+		nodes := []string{"jg1", "jg2", "jg3", "jg4"}
+
+		first.SetNodes(nodes)
+		reference.SetNodes(nodes)
+
+		second := first.Clone()
+
+		var wg sync.WaitGroup
+
+		for _, sr := range []*SkeletonRendezvous{first, second} {
+			wg.Add(1)
+
+			go func(sr *SkeletonRendezvous) {
+				defer wg.Done()
+
+				for i := 0; i < 500; i++ {
+					key := "key-" + strconv.Itoa(i)
+
+					assert.Equal(t, mustFindNode(t, reference, key), mustFindNode(t, sr, key))
+				}
+			}(sr)
+		}
+
+		wg.Wait()
+	})
+
+	t.Run("should find nodes while topology changes concurrently", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(2), MinClusterSize(2))
+
+		assert.NoError(t, err)
+
+		nodes := make([]string, 0)
+
+		for i := 0; i < 32; i++ {
+			nodes = append(nodes, "jg"+strconv.Itoa(i))
+		}
+
+		sr.SetNodes(nodes)
+
+		var wg sync.WaitGroup
+
+		for worker := 0; worker < 8; worker++ {
+			wg.Add(1)
+
+			go func(worker int) {
+				defer wg.Done()
+
+				for i := 0; i < 500; i++ {
+					node := mustFindNode(t, sr, "key-"+strconv.Itoa(worker*1000+i))
+
+					assert.Contains(t, nodes, node)
+				}
+			}(worker)
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < 50; i++ {
+				sr.RemoveNodes(nodes[:4])
+				sr.SetNodes(nodes[:4])
+			}
+		}()
+
+		wg.Wait()
+
+		assert.NoError(t, sr.Validate())
+		assert.Equal(t, len(nodes), len(sr.Nodes))
+	})
+
+	t.Run("should produce same placement as sequential lookups", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(2), MinClusterSize(2))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4", "jg5", "jg6"})
+
+		expected := make([]string, 1000)
+
+		for i := range expected {
+			expected[i] = mustFindNode(t, sr, "key-"+strconv.Itoa(i))
+		}
+
+		actual := make([]string, 1000)
+
+		var wg sync.WaitGroup
+
+		for worker := 0; worker < 4; worker++ {
+			wg.Add(1)
+
+			go func(worker int) {
+				defer wg.Done()
+
+				for i := worker; i < len(actual); i += 4 {
+					actual[i] = mustFindNode(t, sr, "key-"+strconv.Itoa(i))
+				}
+			}(worker)
+		}
+
+		wg.Wait()
+
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("should reset loads while topology changes concurrently", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(BoundedLoad(0.25))
+
+		assert.NoError(t, err)
+
+		nodes := []string{"jg1", "jg2", "jg3", "jg4"}
+
+		sr.SetNodes(nodes)
+
+		var wg sync.WaitGroup
+
+		wg.Add(3)
+
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < 200; i++ {
+				sr.SetNodes(nodes[:2+i%3])
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < 200; i++ {
+				sr.ResetLoads()
+				sr.Loads()
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < 200; i++ {
+				mustFindNode(t, sr, "key-"+strconv.Itoa(i))
+			}
+		}()
+
+		wg.Wait()
+
+		assert.NoError(t, sr.Validate())
+	})
+}
@@ -0,0 +1,190 @@
+// Package consul keeps the nodes of a skeleton rendezvous in sync with the
+// healthy instances of a Consul service, using blocking queries of the
+// Consul HTTP API.
+package consul
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+)
+
+// Config selects the service instances fed into the skeleton.
+type Config struct {
+	// Address is the URL of the Consul agent, http://127.0.0.1:8500 by default
+	Address string
+
+	// Service is the name of the watched service
+	Service string
+
+	// Datacenter is the datacenter of the instances, the datacenter of the
+	// agent by default
+	Datacenter string
+
+	// Statuses are the accepted health check statuses, an instance is kept
+	// only when each of its checks has one of them. Only passing by default
+	Statuses []string
+
+	// Token is the ACL token of the queries
+	Token string
+
+	// WaitTime is the longest time a blocking query waits for a change
+	WaitTime time.Duration
+
+	// Client sends the queries, http.DefaultClient by default
+	Client *http.Client
+}
+
+// serviceEntry is an instance returned by the health endpoint.
+type serviceEntry struct {
+	Node struct {
+		Address string
+	}
+	Service struct {
+		Address string
+		Port    int
+	}
+	Checks []struct {
+		Status string
+	}
+}
+
+// Watch sets the healthy instances of the service into the skeleton and
+// keeps them in sync, an instance is a node identified by its host:port. It
+// blocks until the context is done or a query fails.
+func Watch(ctx context.Context, sr *rendezvous.SkeletonRendezvous, config Config) error {
+	index := uint64(0)
+
+	for {
+		nodes, nextIndex, err := query(ctx, config, index)
+
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			return err
+		}
+
+		// the index going backwards means the state of consul was reset.
+		if nextIndex < index {
+			nextIndex = 0
+		}
+
+		if nextIndex != index || index == 0 {
+			sr.SyncNodes(nodes)
+		}
+
+		index = nextIndex
+	}
+}
+
+// query runs a blocking query of the healthy instances after the index.
+func query(ctx context.Context, config Config, index uint64) ([]string, uint64, error) {
+	address := config.Address
+
+	if address == "" {
+		address = "http://127.0.0.1:8500"
+	}
+
+	params := url.Values{}
+	params.Set("index", strconv.FormatUint(index, 10))
+
+	if config.Datacenter != "" {
+		params.Set("dc", config.Datacenter)
+	}
+
+	if config.WaitTime > 0 {
+		params.Set("wait", strconv.FormatInt(config.WaitTime.Milliseconds(), 10)+"ms")
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		address+"/v1/health/service/"+url.PathEscape(config.Service)+"?"+params.Encode(), nil)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if config.Token != "" {
+		request.Header.Set("X-Consul-Token", config.Token)
+	}
+
+	client := config.Client
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	response, err := client.Do(request)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("consul: query of service %s failed with status %d", config.Service, response.StatusCode)
+	}
+
+	var entries []serviceEntry
+
+	if err := json.NewDecoder(response.Body).Decode(&entries); err != nil {
+		return nil, 0, err
+	}
+
+	nextIndex, err := strconv.ParseUint(response.Header.Get("X-Consul-Index"), 10, 64)
+
+	if err != nil {
+		return nil, 0, fmt.Errorf("consul: invalid index: %w", err)
+	}
+
+	return healthyNodes(entries, config.Statuses), nextIndex, nil
+}
+
+// healthyNodes returns the host:port of the instances which checks all have
+// an accepted status.
+func healthyNodes(entries []serviceEntry, statuses []string) []string {
+	if len(statuses) == 0 {
+		statuses = []string{"passing"}
+	}
+
+	accepted := make(map[string]bool, len(statuses))
+
+	for _, status := range statuses {
+		accepted[status] = true
+	}
+
+	nodes := make([]string, 0, len(entries))
+
+	for _, entry := range entries {
+		healthy := true
+
+		for _, check := range entry.Checks {
+			if !accepted[check.Status] {
+				healthy = false
+			}
+		}
+
+		if !healthy {
+			continue
+		}
+
+		host := entry.Service.Address
+
+		if host == "" {
+			host = entry.Node.Address
+		}
+
+		nodes = append(nodes, net.JoinHostPort(host, strconv.Itoa(entry.Service.Port)))
+	}
+
+	return nodes
+}
@@ -0,0 +1,85 @@
+package consul
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatch(t *testing.T) {
+	t.Run("should feed healthy instances of the service", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		responses := []string{
+			`[
+				{"Node": {"Address": "10.0.0.1"}, "Service": {"Port": 80}, "Checks": [{"Status": "passing"}]},
+				{"Node": {"Address": "10.0.0.2"}, "Service": {"Address": "10.1.0.2", "Port": 80}, "Checks": [{"Status": "passing"}]},
+				{"Node": {"Address": "10.0.0.3"}, "Service": {"Port": 80}, "Checks": [{"Status": "passing"}, {"Status": "critical"}]}
+			]`,
+			`[
+				{"Node": {"Address": "10.0.0.1"}, "Service": {"Port": 80}, "Checks": [{"Status": "passing"}]}
+			]`,
+		}
+
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		queries := make([]string, 0)
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			queries = append(queries, r.URL.RequestURI())
+
+			if len(queries) > len(responses) {
+				cancel()
+				<-r.Context().Done()
+
+				return
+			}
+
+			if len(queries) == 2 {
+				assert.Equal(t, []string{"10.0.0.1:80", "10.1.0.2:80"}, sr.Nodes)
+			}
+
+			w.Header().Set("X-Consul-Index", []string{"5", "6"}[len(queries)-1])
+			w.Write([]byte(responses[len(queries)-1]))
+		}))
+		defer server.Close()
+
+		err = Watch(ctx, sr, Config{Address: server.URL, Service: "cache", Datacenter: "dc1", Token: "secret"})
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, []string{"10.0.0.1:80"}, sr.Nodes)
+		assert.Equal(t, "/v1/health/service/cache?dc=dc1&index=0", queries[0])
+		assert.Equal(t, "/v1/health/service/cache?dc=dc1&index=5", queries[1])
+	})
+
+	t.Run("should accept configured check statuses", func(t *testing.T) {
+		entries := []serviceEntry{{}, {}}
+		entries[0].Node.Address = "10.0.0.1"
+		entries[0].Checks = append(entries[0].Checks, struct{ Status string }{Status: "warning"})
+		entries[1].Node.Address = "10.0.0.2"
+		entries[1].Checks = append(entries[1].Checks, struct{ Status string }{Status: "critical"})
+
+		assert.Empty(t, healthyNodes(entries, nil))
+		assert.Equal(t, []string{"10.0.0.1:0"}, healthyNodes(entries, []string{"passing", "warning"}))
+	})
+
+	t.Run("should return error of failed query", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		assert.Error(t, Watch(context.Background(), sr, Config{Address: server.URL, Service: "cache"}))
+	})
+}
@@ -0,0 +1,16 @@
+package rendezvous
+
+import (
+	"context"
+)
+
+// FindNodeContext is FindNode returning the error of the context without
+// looking up the key once the context is done, so a caller that already
+// gave up does not cost a lookup.
+func (sr *SkeletonRendezvous) FindNodeContext(ctx context.Context, key string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	return sr.FindNode(key)
+}
@@ -0,0 +1,32 @@
+package rendezvous
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindNodeContext(t *testing.T) {
+	sr, err := NewSkeletonRendezvous()
+	assert.NoError(t, err)
+
+	sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+	t.Run("should find the node like FindNode", func(t *testing.T) {
+		node, err := sr.FindNodeContext(context.Background(), "key-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, mustFindNode(t, sr, "key-1"), node)
+	})
+
+	t.Run("should return the error of a done context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		node, err := sr.FindNodeContext(ctx, "key-1")
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Empty(t, node)
+	})
+}
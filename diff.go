@@ -0,0 +1,49 @@
+package rendezvous
+
+// Move is the change of owner of keys between two skeletons.
+type Move struct {
+	// From is the node owning the keys before, empty when no node did
+	From string
+
+	// To is the node owning the keys after, empty when no node does
+	To string
+}
+
+// Diff reports which of the given keys change owner from the old skeleton
+// to the new one, grouped by their move. Keys that keep their owner are
+// omitted.
+func Diff(oldSkeleton *SkeletonRendezvous, newSkeleton *SkeletonRendezvous, keys []string) map[Move][]string {
+	moves := make(map[Move][]string)
+
+	if oldSkeleton == newSkeleton {
+		return moves
+	}
+
+	// each skeleton is locked on its own, so two skeletons compared both
+	// ways never wait on each other's lock.
+	oldNodes := oldSkeleton.owners(keys)
+	newNodes := newSkeleton.owners(keys)
+
+	for i, key := range keys {
+		if oldNodes[i] != newNodes[i] {
+			move := Move{From: oldNodes[i], To: newNodes[i]}
+			moves[move] = append(moves[move], key)
+		}
+	}
+
+	return moves
+}
+
+// owners returns the node of each key, empty when no node owns it.
+func (sr *SkeletonRendezvous) owners(keys []string) []string {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+
+	nodes := make([]string, len(keys))
+
+	for i, key := range keys {
+		nodes[i], _ = sr.peekNode(key)
+	}
+
+	return nodes
+}
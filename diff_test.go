@@ -0,0 +1,116 @@
+package rendezvous
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	keys := make([]string, 0, 1000)
+
+	for i := 0; i < 1000; i++ {
+		keys = append(keys, "key-"+strconv.Itoa(i))
+	}
+
+	t.Run("should group moved keys by old and new owner", func(t *testing.T) {
+		before, err := NewSkeletonRendezvous(HashAlgorithm(newMixedHash()))
+
+		assert.NoError(t, err)
+
+		after, err := NewSkeletonRendezvous(HashAlgorithm(newMixedHash()))
+
+		assert.NoError(t, err)
+
+		before.SetClusters([][]string{{"jg1", "jg2"}, {"jg3", "jg4"}})
+		after.SetClusters([][]string{{"jg1", "jg2"}, {"jg3"}})
+
+		moves := Diff(before, after, keys)
+
+		assert.Equal(t, []Move{{From: "jg4", To: "jg3"}}, movesOf(moves))
+
+		for _, key := range moves[Move{From: "jg4", To: "jg3"}] {
+			assert.Equal(t, "jg4", mustFindNode(t, before, key))
+			assert.Equal(t, "jg3", mustFindNode(t, after, key))
+		}
+	})
+
+	t.Run("should report no move for the same skeleton", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3"})
+
+		assert.Empty(t, Diff(sr, sr, keys))
+	})
+
+	t.Run("should report keys of empty skeleton as moved from no node", func(t *testing.T) {
+		before, err := NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		after, err := NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		after.SetNodes([]string{"jg1"})
+
+		assert.Equal(t, map[Move][]string{{From: "", To: "jg1"}: {"key"}}, Diff(before, after, []string{"key"}))
+	})
+	t.Run("should not hold a skeleton while waiting on the other", func(t *testing.T) {
+		a, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		b, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		a.SetNodes([]string{"jg1", "jg2", "jg3"})
+		b.SetNodes([]string{"jg1", "jg2", "jg3"})
+
+		a.mu.Lock()
+
+		compared := make(chan struct{})
+
+		go func() {
+			defer close(compared)
+
+			_ = Diff(b, a, keys[:10])
+		}()
+
+		// let the comparison reach the lock of a
+		time.Sleep(10 * time.Millisecond)
+
+		changed := make(chan struct{})
+
+		go func() {
+			defer close(changed)
+
+			b.SetHealth(map[string]float64{"jg1": 0.5})
+		}()
+
+		select {
+		case <-changed:
+		case <-time.After(time.Second):
+			t.Error("changing b waited on the comparison")
+		}
+
+		a.mu.Unlock()
+
+		<-compared
+		<-changed
+	})
+
+}
+
+func movesOf(moves map[Move][]string) []Move {
+	result := make([]Move, 0, len(moves))
+
+	for move := range moves {
+		result = append(result, move)
+	}
+
+	return result
+}
@@ -0,0 +1,143 @@
+// Package dns keeps the nodes of a skeleton rendezvous in sync with the SRV
+// or A records of a DNS name, such as a headless Kubernetes Service or a
+// Route53 record.
+package dns
+
+import (
+	"context"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+)
+
+// Resolver looks up the records of a name, net.DefaultResolver satisfies it.
+type Resolver interface {
+	LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// Config selects the records resolved into nodes.
+type Config struct {
+	// Name is the resolved DNS name
+	Name string
+
+	// Service and Proto select SRV records _service._proto.name, when the
+	// service is empty the A and AAAA records of the name are resolved
+	Service string
+	Proto   string
+
+	// Port is appended to the addresses of A and AAAA records, addresses
+	// are used alone when it is 0
+	Port int
+
+	// Interval is the time between two resolutions, 30 seconds by default
+	Interval time.Duration
+
+	// Debounce is how long a changed set of records must stay unchanged
+	// before it is applied, changes are applied at once by default
+	Debounce time.Duration
+
+	// Resolver looks up the records, net.DefaultResolver by default
+	Resolver Resolver
+}
+
+// Resolve returns the nodes of the records sorted, a node of an SRV record
+// is target:port.
+func Resolve(ctx context.Context, config Config) ([]string, error) {
+	resolver := config.Resolver
+
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	nodes := make([]string, 0)
+
+	if config.Service != "" {
+		_, records, err := resolver.LookupSRV(ctx, config.Service, config.Proto, config.Name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, record := range records {
+			nodes = append(nodes, net.JoinHostPort(strings.TrimSuffix(record.Target, "."), strconv.Itoa(int(record.Port))))
+		}
+	} else {
+		addresses, err := resolver.LookupHost(ctx, config.Name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, address := range addresses {
+			if config.Port != 0 {
+				address = net.JoinHostPort(address, strconv.Itoa(config.Port))
+			}
+
+			nodes = append(nodes, address)
+		}
+	}
+
+	sort.Strings(nodes)
+
+	return nodes, nil
+}
+
+// Refresh resolves the records at every interval and applies the added and
+// removed nodes to the skeleton once they are stable for the debounce. A
+// failed resolution keeps the current nodes. It blocks until the context
+// is done.
+func Refresh(ctx context.Context, sr *rendezvous.SkeletonRendezvous, config Config) error {
+	interval := config.Interval
+
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var applied, pending []string
+	var pendingSince time.Time
+
+	for {
+		if nodes, err := Resolve(ctx, config); err == nil {
+			switch {
+			case applied != nil && equal(nodes, applied):
+				pending = nil
+			case pending == nil || !equal(nodes, pending):
+				pending = nodes
+				pendingSince = time.Now()
+			}
+
+			if pending != nil && time.Since(pendingSince) >= config.Debounce {
+				sr.SyncNodes(pending)
+				applied, pending = pending, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func equal(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
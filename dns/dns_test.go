@@ -0,0 +1,115 @@
+package dns
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeResolver answers with the current records.
+type fakeResolver struct {
+	mu      sync.Mutex
+	records []*net.SRV
+	hosts   []string
+}
+
+func (r *fakeResolver) LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return "_" + service + "._" + proto + "." + name, r.records, nil
+}
+
+func (r *fakeResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.hosts, nil
+}
+
+func (r *fakeResolver) setHosts(hosts ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.hosts = hosts
+}
+
+func TestResolve(t *testing.T) {
+	t.Run("should resolve SRV records into target and port", func(t *testing.T) {
+		resolver := &fakeResolver{records: []*net.SRV{
+			{Target: "cache-1.cache.svc.", Port: 6379},
+			{Target: "cache-0.cache.svc.", Port: 6379},
+		}}
+
+		nodes, err := Resolve(context.Background(), Config{Name: "cache.svc", Service: "redis", Proto: "tcp", Resolver: resolver})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"cache-0.cache.svc:6379", "cache-1.cache.svc:6379"}, nodes)
+	})
+
+	t.Run("should resolve A records with port", func(t *testing.T) {
+		resolver := &fakeResolver{hosts: []string{"10.0.0.2", "10.0.0.1"}}
+
+		nodes, err := Resolve(context.Background(), Config{Name: "cache.svc", Port: 80, Resolver: resolver})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"10.0.0.1:80", "10.0.0.2:80"}, nodes)
+	})
+}
+
+func TestRefresh(t *testing.T) {
+	t.Run("should apply resolved changes", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		resolver := &fakeResolver{}
+		resolver.setHosts("10.0.0.1", "10.0.0.2")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+
+		go func() {
+			done <- Refresh(ctx, sr, Config{Name: "cache.svc", Interval: time.Millisecond, Resolver: resolver})
+		}()
+
+		assert.Eventually(t, func() bool {
+			return sr.Stats().Nodes == 2
+		}, time.Second, time.Millisecond)
+
+		resolver.setHosts("10.0.0.2")
+
+		assert.Eventually(t, func() bool {
+			node, ok := sr.NodeInfo("10.0.0.1")
+
+			return !ok && node.ID == ""
+		}, time.Second, time.Millisecond)
+
+		cancel()
+
+		assert.ErrorIs(t, <-done, context.Canceled)
+	})
+
+	t.Run("should wait for changes to be stable", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		resolver := &fakeResolver{}
+		resolver.setHosts("10.0.0.1")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go Refresh(ctx, sr, Config{Name: "cache.svc", Interval: time.Millisecond, Debounce: time.Hour, Resolver: resolver})
+
+		time.Sleep(20 * time.Millisecond)
+
+		assert.Equal(t, 0, sr.Stats().Nodes)
+	})
+}
@@ -0,0 +1,14 @@
+package rendezvous
+
+// Epoch returns the generation of the topology, it increases every time the
+// nodes or the clusters change, such as on SetNodes or RemoveNodes. Clients
+// can compare epochs to detect a stale routing table. Restoring a snapshot
+// is a change as well, the epoch becomes the epoch the snapshot was taken
+// at when it is newer, otherwise the current epoch plus one, so the epoch
+// never decreases.
+func (sr *SkeletonRendezvous) Epoch() uint64 {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+
+	return sr.epoch
+}
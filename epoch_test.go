@@ -0,0 +1,59 @@
+package rendezvous
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpoch(t *testing.T) {
+	t.Run("should bump epoch on every membership change", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(0), sr.Epoch())
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3"})
+		assert.Equal(t, uint64(1), sr.Epoch())
+
+		sr.RemoveNodes([]string{"jg2"})
+		assert.Equal(t, uint64(2), sr.Epoch())
+
+		sr.AddNodes([]string{"jg4"})
+		assert.Equal(t, uint64(3), sr.Epoch())
+
+		sr.SetClusters([][]string{{"jg1"}})
+		assert.Equal(t, uint64(4), sr.Epoch())
+	})
+
+	t.Run("should not bump epoch when only scoring changes", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2"})
+		sr.SetHealth(map[string]float64{"jg1": 0.5})
+
+		assert.Equal(t, uint64(1), sr.Epoch())
+	})
+
+	t.Run("should carry epoch in snapshot", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1"})
+		sr.SetNodes([]string{"jg2"})
+
+		data, err := json.Marshal(sr)
+
+		assert.NoError(t, err)
+
+		restored, err := NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+		assert.NoError(t, json.Unmarshal(data, restored))
+		assert.Equal(t, uint64(2), restored.Epoch())
+	})
+}
@@ -0,0 +1,131 @@
+package rendezvous
+
+import (
+	"reflect"
+)
+
+// Equal reports whether both skeletons route keys identically, that is they
+// share the same clusters, nodes, virtual nodes, options and hash algorithm,
+// along with the same node weights, cluster weights, health, node states,
+// pinned keys and ramping up weights.
+func (sr *SkeletonRendezvous) Equal(other *SkeletonRendezvous) bool {
+	if sr == nil || other == nil || sr == other {
+		return sr == other
+	}
+
+	// the other skeleton is copied before locking the skeleton, so two
+	// skeletons compared both ways never wait on each other's lock.
+	other = other.Clone()
+
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+
+	if !sr.options.equal(other.options) {
+		return false
+	}
+
+	if sr.VirtualNodes != other.VirtualNodes {
+		return false
+	}
+
+	if !equalSlices(sr.Nodes, other.Nodes) || len(sr.Clusters) != len(other.Clusters) {
+		return false
+	}
+
+	for i := range sr.Clusters {
+		if !equalSlices(sr.Clusters[i], other.Clusters[i]) {
+			return false
+		}
+	}
+
+	// without weights the branches are walked unweighted, so weights of 1
+	// do not route like no weights.
+	if (sr.nodeWeights == nil) != (other.nodeWeights == nil) || (sr.clusterWeights == nil) != (other.clusterWeights == nil) {
+		return false
+	}
+
+	return equalMaps(sr.nodeWeights, other.nodeWeights) &&
+		equalSlices(sr.clusterWeights, other.clusterWeights) &&
+		equalMaps(sr.health, other.health) &&
+		equalMaps(sr.states, other.states) &&
+		equalMaps(sr.pins, other.pins) &&
+		equalMaps(sr.ramps, other.ramps)
+}
+
+// equal reports whether both options route keys identically.
+func (o Options) equal(other Options) bool {
+	if o.fanOut != other.fanOut ||
+		o.clusterSize != other.clusterSize ||
+		o.minClusterSize != other.minClusterSize ||
+		o.replicas != other.replicas ||
+		o.selectMin != other.selectMin ||
+		o.overflowPolicy != other.overflowPolicy ||
+		o.boundedLoad != other.boundedLoad ||
+		o.loadEpsilon != other.loadEpsilon ||
+		o.disableRedistribution != other.disableRedistribution ||
+		o.stableClusterCount != other.stableClusterCount ||
+		o.seed != other.seed ||
+		o.hashedAssignment != other.hashedAssignment ||
+		o.placement != other.placement ||
+		o.zoneLabel != other.zoneLabel ||
+		o.nodeTTL != other.nodeTTL ||
+		o.depth != other.depth ||
+		o.rampUp != other.rampUp ||
+		o.rampUpEpochs != other.rampUpEpochs {
+		return false
+	}
+
+	if o.hashName != other.hashName {
+		return false
+	}
+
+	if o.hashName == "" && reflect.TypeOf(o.hash) != reflect.TypeOf(other.hash) {
+		return false
+	}
+
+	if (o.scoreFunc == nil) != (other.scoreFunc == nil) ||
+		o.scoreFunc != nil &&
+			reflect.ValueOf(o.scoreFunc).Pointer() != reflect.ValueOf(other.scoreFunc).Pointer() {
+		return false
+	}
+
+	if reflect.TypeOf(o.strategy) != reflect.TypeOf(other.strategy) {
+		return false
+	}
+
+	if (o.hashFunc == nil) != (other.hashFunc == nil) ||
+		o.hashFunc != nil &&
+			reflect.ValueOf(o.hashFunc).Pointer() != reflect.ValueOf(other.hashFunc).Pointer() {
+		return false
+	}
+
+	return true
+}
+
+func equalSlices[T comparable](a []T, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func equalMaps[K comparable, V comparable](a map[K]V, b map[K]V) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for key, value := range a {
+		if other, ok := b[key]; !ok || other != value {
+			return false
+		}
+	}
+
+	return true
+}
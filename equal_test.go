@@ -0,0 +1,142 @@
+package rendezvous
+
+import (
+	"hash/fnv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEqual(t *testing.T) {
+	nodes := []string{"jg1", "jg2", "jg3", "jg4", "jg5"}
+
+	t.Run("should equal when built from the same config and nodes", func(t *testing.T) {
+		a, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(2), MinClusterSize(2))
+		assert.NoError(t, err)
+
+		b, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(2), MinClusterSize(2))
+		assert.NoError(t, err)
+
+		a.SetNodes(nodes)
+		b.SetNodes(nodes)
+
+		assert.True(t, a.Equal(b))
+		assert.True(t, b.Equal(a))
+	})
+
+	t.Run("should not equal when placement differs", func(t *testing.T) {
+		a, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(2), MinClusterSize(2))
+		assert.NoError(t, err)
+
+		b, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(2), MinClusterSize(2))
+		assert.NoError(t, err)
+
+		a.SetNodes(nodes)
+		b.SetNodes([]string{"jg5", "jg4", "jg3", "jg2", "jg1"})
+
+		assert.False(t, a.Equal(b))
+	})
+
+	t.Run("should not equal when options differ", func(t *testing.T) {
+		a, err := NewSkeletonRendezvous(FanOut(3))
+		assert.NoError(t, err)
+
+		b, err := NewSkeletonRendezvous(FanOut(4))
+		assert.NoError(t, err)
+
+		c, err := NewSkeletonRendezvous(FanOut(3), HashAlgorithm(fnv.New64a()))
+		assert.NoError(t, err)
+
+		a.SetNodes(nodes)
+		b.SetNodes(nodes)
+		c.SetNodes(nodes)
+
+		assert.False(t, a.Equal(b))
+		assert.False(t, a.Equal(c))
+		assert.False(t, a.Equal(nil))
+	})
+
+	t.Run("should not equal when the scoring of nodes differs", func(t *testing.T) {
+		changes := map[string]func(sr *SkeletonRendezvous){
+			"node weights": func(sr *SkeletonRendezvous) {
+				sr.SetNodesWeighted(map[string]float64{"jg1": 2, "jg2": 1, "jg3": 1, "jg4": 1, "jg5": 1})
+			},
+			"cluster weights": func(sr *SkeletonRendezvous) {
+				weights := make([]float64, len(sr.Clusters))
+
+				for i := range weights {
+					weights[i] = float64(i + 1)
+				}
+
+				sr.SetClusterWeights(weights)
+			},
+			"health": func(sr *SkeletonRendezvous) { sr.SetHealth(map[string]float64{"jg1": 0.5}) },
+			"states": func(sr *SkeletonRendezvous) { sr.MarkDown("jg1") },
+			"pins":   func(sr *SkeletonRendezvous) { assert.NoError(t, sr.PinKey("key", "jg1")) },
+		}
+
+		for name, change := range changes {
+			a, err := NewSkeletonRendezvous()
+			assert.NoError(t, err)
+
+			b, err := NewSkeletonRendezvous()
+			assert.NoError(t, err)
+
+			a.SetNodes(nodes)
+			b.SetNodes(nodes)
+
+			change(a)
+
+			assert.False(t, a.Equal(b), name)
+			assert.False(t, b.Equal(a), name)
+
+			change(b)
+
+			assert.True(t, a.Equal(b), name)
+		}
+	})
+
+	t.Run("should not hold a skeleton while waiting on the other", func(t *testing.T) {
+		a, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		b, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		a.SetNodes(nodes)
+		b.SetNodes(nodes)
+
+		a.mu.Lock()
+
+		compared := make(chan struct{})
+
+		go func() {
+			defer close(compared)
+
+			_ = b.Equal(a)
+		}()
+
+		// let the comparison reach the lock of a
+		time.Sleep(10 * time.Millisecond)
+
+		changed := make(chan struct{})
+
+		go func() {
+			defer close(changed)
+
+			b.SetHealth(map[string]float64{"jg1": 0.5})
+		}()
+
+		select {
+		case <-changed:
+		case <-time.After(time.Second):
+			t.Error("changing b waited on the comparison")
+		}
+
+		a.mu.Unlock()
+
+		<-compared
+		<-changed
+	})
+}
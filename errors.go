@@ -0,0 +1,39 @@
+package rendezvous
+
+import (
+	"errors"
+)
+
+var (
+	// ErrNoNodes is returned when there is no node to select from
+	ErrNoNodes = errors.New("rendezvous: no nodes")
+
+	// ErrEmptyKey is returned when an empty key is given where the key must
+	// identify an entry, lookups such as FindNode accept the empty key
+	ErrEmptyKey = errors.New("rendezvous: empty key")
+
+	// ErrInvalidOption is returned when an option has an invalid value
+	ErrInvalidOption = errors.New("rendezvous: invalid option")
+
+	// ErrNodeNotFound is returned when the requested node does not exist
+	ErrNodeNotFound = errors.New("rendezvous: node not found")
+
+	// ErrNodeNotInCluster is returned when a node of the skeleton does not
+	// exist in any of its clusters
+	ErrNodeNotInCluster = errors.New("rendezvous: node not in cluster")
+
+	// ErrClusterEmpty is returned when the selected cluster has no nodes
+	ErrClusterEmpty = errors.New("rendezvous: cluster is empty")
+
+	// ErrInvalidTopology is returned when the clusters and virtual nodes
+	// are inconsistent and can not route keys
+	ErrInvalidTopology = errors.New("rendezvous: invalid topology")
+
+	// ErrUnknownEpoch is returned when the requested epoch is neither the
+	// current epoch nor kept in the epoch history
+	ErrUnknownEpoch = errors.New("rendezvous: unknown epoch")
+
+	// ErrUnknownMigration is returned when a migration plan has no migration
+	// for the acknowledged move
+	ErrUnknownMigration = errors.New("rendezvous: unknown migration")
+)
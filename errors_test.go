@@ -0,0 +1,64 @@
+package rendezvous
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrors(t *testing.T) {
+	t.Run("should return ErrInvalidOption for invalid option", func(t *testing.T) {
+		_, err := NewSkeletonRendezvous(FanOut(1))
+		assert.ErrorIs(t, err, ErrInvalidOption)
+
+		_, err = NewSkeletonRendezvous(Replicas(0))
+		assert.ErrorIs(t, err, ErrInvalidOption)
+	})
+
+	t.Run("should return ErrNoNodes on empty skeleton", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		_, err = sr.FindNode("key")
+		assert.ErrorIs(t, err, ErrNoNodes)
+
+		_, err = sr.FindNodeAt("key", 0)
+		assert.ErrorIs(t, err, ErrNoNodes)
+
+		_, err = sr.FindNodeExcluding("key", nil)
+		assert.ErrorIs(t, err, ErrNoNodes)
+	})
+
+	t.Run("should return ErrNoNodes when every node is excluded", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2"})
+
+		_, err = sr.FindNodeExcluding("key", map[string]struct{}{"jg1": {}, "jg2": {}})
+		assert.ErrorIs(t, err, ErrNoNodes)
+	})
+
+	t.Run("should return ErrNodeNotFound for rank out of range", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2"})
+
+		_, err = sr.FindNodeAt("key", 2)
+		assert.ErrorIs(t, err, ErrNodeNotFound)
+	})
+
+	t.Run("should return ErrNoNodes when every cluster has no nodes", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		sr.SetClusters([][]string{{}})
+
+		_, err = sr.FindNode("key")
+		assert.ErrorIs(t, err, ErrNoNodes)
+
+		_, err = sr.FindNodeAt("key", 0)
+		assert.ErrorIs(t, err, ErrNoNodes)
+	})
+}
@@ -0,0 +1,126 @@
+// Package etcd keeps the nodes of a skeleton rendezvous in sync with an etcd
+// prefix holding one key per node, each key attached to the lease of its
+// node. Register keeps the key of a node alive while the node runs, Watch
+// adds and removes the nodes as their keys come and go. Both talk to etcd
+// through Client, such as a wrapper of clientv3.
+package etcd
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+)
+
+// ErrWatchClosed is returned by Watch when the watch channel is closed
+// before the context is done.
+var ErrWatchClosed = errors.New("etcd: watch closed")
+
+// EventType is the kind of change of a key.
+type EventType int
+
+const (
+	// Put is a key created or updated
+	Put EventType = iota
+
+	// Delete is a key deleted or expired along with its lease
+	Delete
+)
+
+// Event is the change of a key under the watched prefix.
+type Event struct {
+	Type EventType
+	Key  string
+}
+
+// Client is the subset of the etcd client used by the adapter.
+type Client interface {
+	// Get returns the keys under the prefix and the revision of the read
+	Get(ctx context.Context, prefix string) ([]string, int64, error)
+
+	// Watch streams the changes under the prefix after the revision, the
+	// channel is closed once the context is done or the watch fails
+	Watch(ctx context.Context, prefix string, revision int64) <-chan []Event
+
+	// Grant creates a lease expiring after ttl seconds without keepalive
+	Grant(ctx context.Context, ttl int64) (int64, error)
+
+	// Put writes the key attached to the lease
+	Put(ctx context.Context, key string, value string, lease int64) error
+
+	// KeepAlive keeps the lease alive, it blocks until the context is done
+	// or the lease is lost
+	KeepAlive(ctx context.Context, lease int64) error
+}
+
+// Watch sets the nodes under the prefix into the skeleton and keeps them in
+// sync with the changes of the prefix, a node is the key without the prefix.
+// It blocks until the context is done, returning its error, or until the
+// watch channel is closed, returning ErrWatchClosed.
+func Watch(ctx context.Context, sr *rendezvous.SkeletonRendezvous, client Client, prefix string) error {
+	keys, revision, err := client.Get(ctx, prefix)
+
+	if err != nil {
+		return err
+	}
+
+	nodes := make([]string, 0, len(keys))
+
+	for _, key := range keys {
+		nodes = append(nodes, strings.TrimPrefix(key, prefix))
+	}
+
+	sr.SyncNodes(nodes)
+
+	for events := range client.Watch(ctx, prefix, revision+1) {
+		apply(sr, prefix, events)
+	}
+
+	// the channel is also closed when the watch fails, such as when the
+	// revision is compacted, the nodes are then no longer kept in sync.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return ErrWatchClosed
+}
+
+// apply changes the nodes of the skeleton in the order of the events, the
+// consecutive events of the same type are applied in a single change.
+func apply(sr *rendezvous.SkeletonRendezvous, prefix string, events []Event) {
+	for start := 0; start < len(events); {
+		end := start
+		nodes := make([]string, 0)
+
+		for ; end < len(events) && events[end].Type == events[start].Type; end++ {
+			nodes = append(nodes, strings.TrimPrefix(events[end].Key, prefix))
+		}
+
+		if events[start].Type == Delete {
+			sr.RemoveNodes(nodes)
+		} else {
+			sr.AddNodes(nodes)
+		}
+
+		start = end
+	}
+}
+
+// Register publishes the node under the prefix with a lease of ttl seconds
+// and keeps the lease alive, so the node disappears from every watcher once
+// the process stops. It blocks until the context is done or the lease is
+// lost.
+func Register(ctx context.Context, client Client, prefix string, node string, ttl int64) error {
+	lease, err := client.Grant(ctx, ttl)
+
+	if err != nil {
+		return err
+	}
+
+	if err := client.Put(ctx, prefix+node, node, lease); err != nil {
+		return err
+	}
+
+	return client.KeepAlive(ctx, lease)
+}
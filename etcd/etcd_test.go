@@ -0,0 +1,125 @@
+package etcd
+
+import (
+	"context"
+	"testing"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClient is an in memory etcd prefix.
+type fakeClient struct {
+	keys     []string
+	revision int64
+	events   chan []Event
+
+	watchedRevision int64
+	leaseTTL        int64
+	puts            map[string]int64
+}
+
+func (c *fakeClient) Get(ctx context.Context, prefix string) ([]string, int64, error) {
+	return c.keys, c.revision, nil
+}
+
+func (c *fakeClient) Watch(ctx context.Context, prefix string, revision int64) <-chan []Event {
+	c.watchedRevision = revision
+
+	return c.events
+}
+
+func (c *fakeClient) Grant(ctx context.Context, ttl int64) (int64, error) {
+	c.leaseTTL = ttl
+
+	return 42, nil
+}
+
+func (c *fakeClient) Put(ctx context.Context, key string, value string, lease int64) error {
+	c.puts[key] = lease
+
+	return nil
+}
+
+func (c *fakeClient) KeepAlive(ctx context.Context, lease int64) error {
+	<-ctx.Done()
+
+	return ctx.Err()
+}
+
+func TestWatch(t *testing.T) {
+	t.Run("should keep nodes in sync with the prefix", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"stale"})
+
+		client := &fakeClient{
+			keys:     []string{"/nodes/jg1", "/nodes/jg2"},
+			revision: 7,
+			events:   make(chan []Event, 2),
+		}
+
+		client.events <- []Event{{Type: Put, Key: "/nodes/jg3"}}
+		client.events <- []Event{{Type: Delete, Key: "/nodes/jg1"}}
+		close(client.events)
+
+		assert.ErrorIs(t, Watch(context.Background(), sr, client, "/nodes/"), ErrWatchClosed)
+		assert.Equal(t, int64(8), client.watchedRevision)
+		assert.ElementsMatch(t, []string{"jg2", "jg3"}, sr.Nodes)
+	})
+
+	t.Run("should apply events of a response in order", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		client := &fakeClient{
+			keys:   []string{"/nodes/jg1", "/nodes/jg2"},
+			events: make(chan []Event, 1),
+		}
+
+		client.events <- []Event{
+			{Type: Delete, Key: "/nodes/jg1"},
+			{Type: Put, Key: "/nodes/jg1"},
+			{Type: Put, Key: "/nodes/jg3"},
+			{Type: Delete, Key: "/nodes/jg3"},
+		}
+		close(client.events)
+
+		assert.ErrorIs(t, Watch(context.Background(), sr, client, "/nodes/"), ErrWatchClosed)
+		assert.ElementsMatch(t, []string{"jg1", "jg2"}, sr.Nodes)
+	})
+
+	t.Run("should return context error once context is done", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		client := &fakeClient{
+			keys:   []string{"/nodes/jg1"},
+			events: make(chan []Event),
+		}
+
+		close(client.events)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		assert.ErrorIs(t, Watch(ctx, sr, client, "/nodes/"), context.Canceled)
+	})
+}
+
+func TestRegister(t *testing.T) {
+	t.Run("should publish node with a lease until context is done", func(t *testing.T) {
+		client := &fakeClient{puts: make(map[string]int64)}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		assert.ErrorIs(t, Register(ctx, client, "/nodes/", "jg1", 10), context.Canceled)
+		assert.Equal(t, int64(10), client.leaseTTL)
+		assert.Equal(t, map[string]int64{"/nodes/jg1": 42}, client.puts)
+	})
+}
@@ -0,0 +1,100 @@
+package rendezvous
+
+import (
+	"fmt"
+)
+
+// FindNodeExcluding find selected node like FindNode, but ignores nodes in
+// the down set and falls back to the next highest score in the selected
+// cluster. When every node of the selected cluster is down, the best node
+// of the next cluster preferred by the branch walk is selected instead, in
+// the order of FindN. A pinned key is selected on its node unless the node
+// is excluded, so retries are able to exclude the node which just failed
+// without changing the topology.
+func (sr *SkeletonRendezvous) FindNodeExcluding(key string, down map[string]struct{}) (string, error) {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+
+	// nodes which are down or draining are excluded as well.
+	if unavailable := sr.unavailableNodes(); len(unavailable) > 0 {
+		excluded := make(map[string]struct{}, len(unavailable)+len(down))
+
+		for node := range unavailable {
+			excluded[node] = struct{}{}
+		}
+
+		for node := range down {
+			excluded[node] = struct{}{}
+		}
+
+		down = excluded
+	}
+
+	if node, ok := sr.pinnedNode(key, down); ok {
+		return node, nil
+	}
+
+	return sr.findNodeExcluding(key, down)
+}
+
+func (sr *SkeletonRendezvous) findNodeExcluding(key string, down map[string]struct{}) (string, error) {
+	nodes, err := sr.findClusterNodes(key)
+
+	if err != nil {
+		return "", err
+	}
+
+	if node, _, ok := sr.findHighestRandomWeightExcluding(key, nodes, down); ok {
+		return node, nil
+	}
+
+	order, err := sr.clusterOrder(key)
+
+	if err != nil {
+		return "", err
+	}
+
+	for _, clusterIndex := range order[1:] {
+		if node, _, ok := sr.findHighestRandomWeightExcluding(key, sr.Clusters[clusterIndex], down); ok {
+			return node, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: every node is excluded", ErrNoNodes)
+}
+
+func (sr *SkeletonRendezvous) findHighestRandomWeightExcluding(key string, nodes []string, down map[string]struct{}) (string, float64, bool) {
+	var highestNode float64
+	var selectedNode string
+
+	found := false
+
+	for _, node := range nodes {
+		if _, ok := down[node]; ok {
+			continue
+		}
+
+		nodeScore := sr.scoreNode(node, key)
+
+		if !found || outranks(node, nodeScore, selectedNode, highestNode) {
+			highestNode = nodeScore
+			selectedNode = node
+			found = true
+		}
+	}
+
+	return selectedNode, highestNode, found
+}
+
+// availableNodes returns the nodes which are not in the unavailable set.
+func availableNodes(nodes []string, unavailable map[string]struct{}) []string {
+	available := make([]string, 0, len(nodes))
+
+	for _, node := range nodes {
+		if _, ok := unavailable[node]; !ok {
+			available = append(available, node)
+		}
+	}
+
+	return available
+}
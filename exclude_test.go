@@ -0,0 +1,110 @@
+package rendezvous
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindNodeExcluding(t *testing.T) {
+	newSkeleton := func(t *testing.T) *SkeletonRendezvous {
+		sr, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(2), MinClusterSize(2))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4", "jg5", "jg6"})
+
+		return sr
+	}
+
+	t.Run("should equal FindNode without excluded nodes", func(t *testing.T) {
+		sr := newSkeleton(t)
+
+		for i := 0; i < 100; i++ {
+			key := "key-" + strconv.Itoa(i)
+
+			node, err := sr.FindNodeExcluding(key, nil)
+
+			assert.NoError(t, err)
+			assert.Equal(t, mustFindNode(t, sr, key), node)
+		}
+	})
+
+	t.Run("should select the pinned node unless excluded", func(t *testing.T) {
+		sr := newSkeleton(t)
+
+		assert.NoError(t, sr.PinKey("key-1", "jg6"))
+
+		node, err := sr.FindNodeExcluding("key-1", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "jg6", node)
+
+		node, err = sr.FindNodeExcluding("key-1", map[string]struct{}{"jg6": {}})
+
+		assert.NoError(t, err)
+		assert.NotEqual(t, "jg6", node)
+	})
+
+	t.Run("should fall back to next node in the same cluster", func(t *testing.T) {
+		sr := newSkeleton(t)
+
+		for i := 0; i < 100; i++ {
+			key := "key-" + strconv.Itoa(i)
+			primary := mustFindNode(t, sr, key)
+
+			node, err := sr.FindNodeExcluding(key, map[string]struct{}{primary: {}})
+
+			assert.NoError(t, err)
+			assert.NotEqual(t, primary, node)
+			assert.Equal(t, clusterOf(sr, primary), clusterOf(sr, node))
+		}
+	})
+
+	t.Run("should fall back to other clusters when whole cluster is down", func(t *testing.T) {
+		sr := newSkeleton(t)
+
+		for i := 0; i < 100; i++ {
+			key := "key-" + strconv.Itoa(i)
+			primary := mustFindNode(t, sr, key)
+
+			down := make(map[string]struct{})
+
+			for _, node := range sr.Clusters[clusterOf(sr, primary)] {
+				down[node] = struct{}{}
+			}
+
+			node, err := sr.FindNodeExcluding(key, down)
+
+			assert.NoError(t, err)
+			assert.NotContains(t, down, node)
+		}
+	})
+
+	t.Run("should return error when every node is excluded", func(t *testing.T) {
+		sr := newSkeleton(t)
+
+		down := make(map[string]struct{})
+
+		for _, node := range sr.Nodes {
+			down[node] = struct{}{}
+		}
+
+		_, err := sr.FindNodeExcluding("key", down)
+
+		assert.Error(t, err)
+	})
+}
+
+func clusterOf(sr *SkeletonRendezvous, node string) int {
+	for i, cluster := range sr.Clusters {
+		for _, member := range cluster {
+			if member == node {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
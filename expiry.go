@@ -0,0 +1,125 @@
+package rendezvous
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// NodeTTL sets how long a node stays registered without a heartbeat. Every
+// node set into the skeleton expires after the ttl unless Heartbeat is
+// called for it, expired nodes are removed by SweepExpired or RunExpiry.
+func NodeTTL(ttl time.Duration) Option {
+	return func(o *Options) error {
+		if ttl <= 0 {
+			return fmt.Errorf("%w: node ttl must be positive, got %v", ErrInvalidOption, ttl)
+		}
+
+		o.nodeTTL = ttl
+
+		return nil
+	}
+}
+
+// Heartbeat refreshes the registration of the node for another ttl. It
+// returns ErrNodeNotFound when the node does not exist.
+func (sr *SkeletonRendezvous) Heartbeat(node string) error {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+
+	if !sr.hasNode(node) {
+		return fmt.Errorf("%w: %s", ErrNodeNotFound, node)
+	}
+
+	sr.register([]string{node})
+
+	return nil
+}
+
+// SweepExpired removes the nodes whose ttl has passed without a heartbeat
+// and returns them. Removing nodes notifies the watchers like RemoveNodes.
+func (sr *SkeletonRendezvous) SweepExpired() []string {
+	sr.mu.RLock()
+	expired := sr.expired()
+	sr.mu.RUnlock()
+
+	// most sweeps find nothing, they do not take the write lock
+	if len(expired) == 0 {
+		return nil
+	}
+
+	sr.updateTopology(func() {
+		// a heartbeat may have arrived since the nodes were found
+		expired = sr.expired()
+
+		if len(expired) > 0 {
+			sr.removeNodes(expired)
+		}
+	})
+
+	return expired
+}
+
+// expired returns the nodes whose ttl has passed, the caller must hold the
+// lock.
+func (sr *SkeletonRendezvous) expired() []string {
+	var expired []string
+
+	now := sr.now()
+
+	for _, node := range sr.Nodes {
+		if deadline, ok := sr.deadlines[node]; ok && !now.Before(deadline) {
+			expired = append(expired, node)
+		}
+	}
+
+	return expired
+}
+
+// RunExpiry sweeps the expired nodes at every interval until the context
+// is done, it blocks so it is usually run in its own goroutine. It returns
+// ErrInvalidOption when the interval is not positive, otherwise the error of
+// the context once it is done.
+func (sr *SkeletonRendezvous) RunExpiry(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("%w: expiry interval must be positive, got %v", ErrInvalidOption, interval)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			sr.SweepExpired()
+		}
+	}
+}
+
+// register refreshes the deadline of the nodes when a node ttl is set, the
+// caller must hold the lock.
+func (sr *SkeletonRendezvous) register(nodes []string) {
+	if sr.options.nodeTTL <= 0 {
+		return
+	}
+
+	if sr.deadlines == nil {
+		sr.deadlines = make(map[string]time.Time)
+	}
+
+	deadline := sr.now().Add(sr.options.nodeTTL)
+
+	for _, node := range nodes {
+		sr.deadlines[node] = deadline
+	}
+}
+
+func (sr *SkeletonRendezvous) now() time.Time {
+	if sr.clock != nil {
+		return sr.clock()
+	}
+
+	return time.Now()
+}
@@ -0,0 +1,125 @@
+package rendezvous
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeTTL(t *testing.T) {
+	newSkeleton := func(t *testing.T, now *time.Time) *SkeletonRendezvous {
+		sr, err := NewSkeletonRendezvous(NodeTTL(time.Minute))
+
+		assert.NoError(t, err)
+
+		sr.clock = func() time.Time {
+			return *now
+		}
+
+		return sr
+	}
+
+	t.Run("should remove nodes without heartbeat", func(t *testing.T) {
+		now := time.Unix(0, 0)
+		sr := newSkeleton(t, &now)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3"})
+
+		now = now.Add(30 * time.Second)
+
+		assert.NoError(t, sr.Heartbeat("jg2"))
+		assert.Empty(t, sr.SweepExpired())
+
+		now = now.Add(45 * time.Second)
+
+		assert.ElementsMatch(t, []string{"jg1", "jg3"}, sr.SweepExpired())
+		assert.Equal(t, []string{"jg2"}, sr.Nodes)
+
+		now = now.Add(time.Minute)
+
+		assert.Equal(t, []string{"jg2"}, sr.SweepExpired())
+		assert.Empty(t, sr.Nodes)
+	})
+
+	t.Run("should refresh nodes set again", func(t *testing.T) {
+		now := time.Unix(0, 0)
+		sr := newSkeleton(t, &now)
+
+		sr.SetNodes([]string{"jg1"})
+
+		now = now.Add(50 * time.Second)
+
+		sr.AddNodes([]string{"jg1", "jg2"})
+
+		now = now.Add(50 * time.Second)
+
+		assert.Empty(t, sr.SweepExpired())
+	})
+
+	t.Run("should fire topology event for expired nodes", func(t *testing.T) {
+		now := time.Unix(0, 0)
+		sr := newSkeleton(t, &now)
+
+		sr.SetNodes([]string{"jg1", "jg2"})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events := sr.WatchTopology(ctx)
+
+		assert.NoError(t, sr.Heartbeat("jg1"))
+
+		now = now.Add(2 * time.Minute)
+
+		sr.SweepExpired()
+
+		assert.ElementsMatch(t, []string{"jg1", "jg2"}, (<-events).NodesRemoved)
+	})
+
+	t.Run("should reject heartbeat of unknown node", func(t *testing.T) {
+		now := time.Unix(0, 0)
+		sr := newSkeleton(t, &now)
+
+		assert.ErrorIs(t, sr.Heartbeat("jg1"), ErrNodeNotFound)
+	})
+
+	t.Run("should sweep in background until context is done", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(NodeTTL(time.Millisecond))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1"})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+
+		go func() {
+			assert.ErrorIs(t, sr.RunExpiry(ctx, time.Millisecond), context.Canceled)
+			close(done)
+		}()
+
+		assert.Eventually(t, func() bool {
+			return sr.Stats().Nodes == 0
+		}, time.Second, time.Millisecond)
+
+		cancel()
+		<-done
+	})
+
+	t.Run("should reject non positive sweep interval", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(NodeTTL(time.Millisecond))
+
+		assert.NoError(t, err)
+
+		assert.ErrorIs(t, sr.RunExpiry(context.Background(), 0), ErrInvalidOption)
+		assert.ErrorIs(t, sr.RunExpiry(context.Background(), -time.Second), ErrInvalidOption)
+	})
+
+	t.Run("should reject non positive ttl", func(t *testing.T) {
+		_, err := NewSkeletonRendezvous(NodeTTL(0))
+
+		assert.ErrorIs(t, err, ErrInvalidOption)
+	})
+}
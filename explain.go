@@ -0,0 +1,92 @@
+package rendezvous
+
+// RouteExplanation describes how a key is routed through the skeleton.
+type RouteExplanation struct {
+	// Key is the explained key
+	Key string
+
+	// Branches is the branch selection on each virtual node level
+	Branches []BranchExplanation
+
+	// Cluster is the index of the selected cluster, -1 when none
+	Cluster int
+
+	// Nodes is the score of each node of the selected cluster
+	Nodes []NodeScore
+
+	// Node is the selected node
+	Node string
+}
+
+// BranchExplanation describes the branch selection on a virtual node level.
+type BranchExplanation struct {
+	// Level is the virtual node level
+	Level int
+
+	// Scores is the hash score of each branch
+	Scores []uint64
+
+	// Weights is the weight of each branch, nil when clusters are not weighted
+	Weights []float64
+
+	// Branch is the selected branch
+	Branch int
+}
+
+// NodeScore is the score of a node for a key.
+type NodeScore struct {
+	Node  string
+	Score float64
+}
+
+// Explain describes how FindNode routes the key, including the branch
+// chosen on each virtual node level with the score of every branch, the
+// selected cluster and the score of every node within it.
+func (sr *SkeletonRendezvous) Explain(key string) RouteExplanation {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+
+	explanation := RouteExplanation{
+		Key:      key,
+		Branches: make([]BranchExplanation, 0, sr.VirtualNodes),
+		Cluster:  -1,
+	}
+
+	position := 0
+
+	for i := 0; i < sr.VirtualNodes; i++ {
+		branch := BranchExplanation{
+			Level:  i,
+			Scores: make([]uint64, 0, sr.options.fanOut),
+			Branch: sr.selectBranch(key, i, position),
+		}
+
+		for j := 0; j < sr.options.fanOut; j++ {
+			branch.Scores = append(branch.Scores, sr.rank(sr.hashBranch(i, j, key)))
+		}
+
+		if sr.branchWeights != nil {
+			branch.Weights = sr.subtreeWeights(i, position)
+		}
+
+		explanation.Branches = append(explanation.Branches, branch)
+		position = position*sr.options.fanOut + branch.Branch
+	}
+
+	clusterIndex, err := sr.findCluster(key)
+
+	if err != nil {
+		return explanation
+	}
+
+	explanation.Cluster = clusterIndex
+	explanation.Nodes = make([]NodeScore, 0, len(sr.Clusters[clusterIndex]))
+
+	for _, node := range sr.Clusters[clusterIndex] {
+		explanation.Nodes = append(explanation.Nodes, NodeScore{Node: node, Score: sr.scoreNode(node, key)})
+	}
+
+	explanation.Node = sr.findHighestRandomWeight(key, sr.Clusters[clusterIndex])
+
+	return explanation
+}
@@ -0,0 +1,70 @@
+package rendezvous
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExplain(t *testing.T) {
+	t.Run("should explain the route chosen by FindNode", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(2), ClusterSize(2), MinClusterSize(2))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4", "jg5", "jg6", "jg7", "jg8"})
+
+		for i := 0; i < 100; i++ {
+			key := "key-" + strconv.Itoa(i)
+
+			explanation := sr.Explain(key)
+
+			assert.Equal(t, key, explanation.Key)
+			assert.Equal(t, sr.VirtualNodes, len(explanation.Branches))
+			assert.Equal(t, mustFindNode(t, sr, key), explanation.Node)
+			assert.Equal(t, clusterOf(sr, explanation.Node), explanation.Cluster)
+
+			for level, branch := range explanation.Branches {
+				assert.Equal(t, level, branch.Level)
+				assert.Equal(t, 2, len(branch.Scores))
+				assert.Nil(t, branch.Weights)
+			}
+
+			highest := explanation.Nodes[0]
+
+			for _, nodeScore := range explanation.Nodes {
+				if nodeScore.Score > highest.Score {
+					highest = nodeScore
+				}
+			}
+
+			assert.Equal(t, explanation.Node, highest.Node)
+		}
+	})
+
+	t.Run("should explain branch weights", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(2))
+
+		assert.NoError(t, err)
+
+		sr.SetClusters([][]string{{"jg1"}, {"jg2"}, {"jg3"}, {"jg4"}})
+		sr.SetClusterWeights([]float64{1, 1, 1, 3})
+
+		explanation := sr.Explain("key")
+
+		assert.Equal(t, []float64{2, 4}, explanation.Branches[0].Weights)
+		assert.Equal(t, mustFindNode(t, sr, "key"), explanation.Node)
+	})
+
+	t.Run("should explain empty skeleton", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		explanation := sr.Explain("key")
+
+		assert.Equal(t, -1, explanation.Cluster)
+		assert.Empty(t, explanation.Node)
+	})
+}
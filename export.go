@@ -0,0 +1,161 @@
+package rendezvous
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// ExportFormat is the graph language of an export.
+type ExportFormat int
+
+const (
+	// ExportDOT writes a Graphviz digraph
+	ExportDOT ExportFormat = iota
+
+	// ExportMermaid writes a Mermaid flowchart
+	ExportMermaid
+)
+
+// Export writes the skeleton as a graph: the root branches into the fan out
+// tree, every leaf position points to the cluster it is mapped into and
+// every cluster points to its nodes. Positions mapped by the overflow
+// policy show as several leaves pointing to the same cluster.
+func (sr *SkeletonRendezvous) Export(w io.Writer, format ExportFormat) error {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+
+	var graph graphWriter
+
+	switch format {
+	case ExportDOT:
+		graph = dotWriter{}
+	case ExportMermaid:
+		graph = mermaidWriter{}
+	default:
+		return fmt.Errorf("%w: unknown export format %d", ErrInvalidOption, format)
+	}
+
+	buffered := bufio.NewWriter(w)
+
+	graph.begin(buffered)
+	graph.vertex(buffered, "root", "root")
+
+	if len(sr.Clusters) > 0 {
+		sr.exportBranches(buffered, graph, "root", 0, 0)
+	}
+
+	for i, cluster := range sr.Clusters {
+		clusterID := "c" + strconv.Itoa(i)
+
+		graph.vertex(buffered, clusterID, "cluster "+strconv.Itoa(i))
+
+		for _, node := range cluster {
+			nodeID := "n" + node
+
+			graph.vertex(buffered, nodeID, node)
+			graph.edge(buffered, clusterID, nodeID)
+		}
+	}
+
+	graph.end(buffered)
+
+	return buffered.Flush()
+}
+
+// exportBranches writes the branches below the branch position chosen so
+// far on the given level, the leaves point to their cluster.
+func (sr *SkeletonRendezvous) exportBranches(w io.Writer, graph graphWriter, parent string, level int, position int) {
+	for j := 0; j < sr.options.fanOut; j++ {
+		branchPosition := position*sr.options.fanOut + j
+
+		if level == sr.VirtualNodes-1 {
+			graph.edge(w, parent, "c"+strconv.Itoa(sr.lookupClusterIndex(branchPosition)))
+
+			continue
+		}
+
+		branchID := "b" + strconv.Itoa(level) + "p" + strconv.Itoa(branchPosition)
+
+		graph.vertex(w, branchID, "level "+strconv.Itoa(level)+" branch "+strconv.Itoa(j))
+		graph.edge(w, parent, branchID)
+
+		sr.exportBranches(w, graph, branchID, level+1, branchPosition)
+	}
+}
+
+// graphWriter writes the vertices and edges of a graph language.
+type graphWriter interface {
+	begin(w io.Writer)
+	vertex(w io.Writer, id string, label string)
+	edge(w io.Writer, from string, to string)
+	end(w io.Writer)
+}
+
+type dotWriter struct{}
+
+func (dotWriter) begin(w io.Writer) {
+	fmt.Fprintln(w, "digraph skeleton {")
+}
+
+func (dotWriter) vertex(w io.Writer, id string, label string) {
+	fmt.Fprintf(w, "\t%s [label=%s];\n", strconv.Quote(id), strconv.Quote(label))
+}
+
+func (dotWriter) edge(w io.Writer, from string, to string) {
+	fmt.Fprintf(w, "\t%s -> %s;\n", strconv.Quote(from), strconv.Quote(to))
+}
+
+func (dotWriter) end(w io.Writer) {
+	fmt.Fprintln(w, "}")
+}
+
+type mermaidWriter struct{}
+
+func (mermaidWriter) begin(w io.Writer) {
+	fmt.Fprintln(w, "flowchart TD")
+}
+
+func (mermaidWriter) vertex(w io.Writer, id string, label string) {
+	fmt.Fprintf(w, "\t%s[\"%s\"]\n", mermaidID(id), mermaidLabel(label))
+}
+
+func (mermaidWriter) edge(w io.Writer, from string, to string) {
+	fmt.Fprintf(w, "\t%s --> %s\n", mermaidID(from), mermaidID(to))
+}
+
+func (mermaidWriter) end(w io.Writer) {}
+
+// mermaidID keeps the letters and digits of an ID, the other characters of
+// node IDs such as host:port are escaped by their byte value.
+func mermaidID(id string) string {
+	escaped := make([]byte, 0, len(id))
+
+	for i := 0; i < len(id); i++ {
+		c := id[i]
+
+		if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' {
+			escaped = append(escaped, c)
+		} else {
+			escaped = append(escaped, fmt.Sprintf("_%02x", c)...)
+		}
+	}
+
+	return string(escaped)
+}
+
+// mermaidLabel escapes the double quotes closing a Mermaid label.
+func mermaidLabel(label string) string {
+	escaped := make([]byte, 0, len(label))
+
+	for i := 0; i < len(label); i++ {
+		if label[i] == '"' {
+			escaped = append(escaped, "#quot;"...)
+		} else {
+			escaped = append(escaped, label[i])
+		}
+	}
+
+	return string(escaped)
+}
@@ -0,0 +1,83 @@
+package rendezvous
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExport(t *testing.T) {
+	t.Run("should export the tree as DOT", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(2))
+		assert.NoError(t, err)
+
+		sr.SetClusters([][]string{{"jg1", "jg2"}, {"jg3"}, {"jg4"}})
+
+		var out bytes.Buffer
+
+		assert.NoError(t, sr.Export(&out, ExportDOT))
+		assert.Equal(t, `digraph skeleton {
+	"root" [label="root"];
+	"b0p0" [label="level 0 branch 0"];
+	"root" -> "b0p0";
+	"b0p0" -> "c0";
+	"b0p0" -> "c1";
+	"b0p1" [label="level 0 branch 1"];
+	"root" -> "b0p1";
+	"b0p1" -> "c2";
+	"b0p1" -> "c0";
+	"c0" [label="cluster 0"];
+	"njg1" [label="jg1"];
+	"c0" -> "njg1";
+	"njg2" [label="jg2"];
+	"c0" -> "njg2";
+	"c1" [label="cluster 1"];
+	"njg3" [label="jg3"];
+	"c1" -> "njg3";
+	"c2" [label="cluster 2"];
+	"njg4" [label="jg4"];
+	"c2" -> "njg4";
+}
+`, out.String())
+	})
+
+	t.Run("should export the tree as Mermaid", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(2))
+		assert.NoError(t, err)
+
+		sr.SetClusters([][]string{{"10.0.0.1:80"}, {"jg\"2"}})
+
+		var out bytes.Buffer
+
+		assert.NoError(t, sr.Export(&out, ExportMermaid))
+		assert.Equal(t, `flowchart TD
+	root["root"]
+	root --> c0
+	root --> c1
+	c0["cluster 0"]
+	n10_2e0_2e0_2e1_3a80["10.0.0.1:80"]
+	c0 --> n10_2e0_2e0_2e1_3a80
+	c1["cluster 1"]
+	njg_222["jg#quot;2"]
+	c1 --> njg_222
+`, out.String())
+	})
+
+	t.Run("should export empty skeleton", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		var out bytes.Buffer
+
+		assert.NoError(t, sr.Export(&out, ExportDOT))
+		assert.Equal(t, "digraph skeleton {\n\t\"root\" [label=\"root\"];\n}\n", out.String())
+	})
+
+	t.Run("should reject unknown format", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		assert.ErrorIs(t, sr.Export(&bytes.Buffer{}, ExportFormat(7)), ErrInvalidOption)
+	})
+}
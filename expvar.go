@@ -0,0 +1,108 @@
+package rendezvous
+
+import (
+	"expvar"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Expvar publishes the counters of the skeleton as an expvar map with the
+// given name, served on /debug/vars: nodes, clusters, virtual_nodes, epoch,
+// lookups, lookup_errors and last_rebuild. The map is published once the
+// skeleton is created, publishing a name twice is an error since expvar
+// names are global.
+func Expvar(name string) Option {
+	return func(o *Options) error {
+		if name == "" {
+			return fmt.Errorf("%w: expvar name is empty", ErrInvalidOption)
+		}
+
+		return Observe(newExpvarObserver(name))(o)
+	}
+}
+
+// expvarMu serializes publishing, so checking whether a name is taken and
+// publishing it do not race between skeletons.
+var expvarMu sync.Mutex
+
+// expvarObserver keeps the counters of the skeleton in expvar variables,
+// which are safe for concurrent use.
+type expvarObserver struct {
+	name string
+	vars *expvar.Map
+
+	nodes        *expvar.Int
+	clusters     *expvar.Int
+	virtualNodes *expvar.Int
+	epoch        *expvar.Int
+	lookups      *expvar.Int
+	lookupErrors *expvar.Int
+	lastRebuild  *expvar.String
+}
+
+func newExpvarObserver(name string) *expvarObserver {
+	observer := &expvarObserver{
+		name:         name,
+		vars:         new(expvar.Map).Init(),
+		nodes:        new(expvar.Int),
+		clusters:     new(expvar.Int),
+		virtualNodes: new(expvar.Int),
+		epoch:        new(expvar.Int),
+		lookups:      new(expvar.Int),
+		lookupErrors: new(expvar.Int),
+		lastRebuild:  new(expvar.String),
+	}
+
+	observer.vars.Set("nodes", observer.nodes)
+	observer.vars.Set("clusters", observer.clusters)
+	observer.vars.Set("virtual_nodes", observer.virtualNodes)
+	observer.vars.Set("epoch", observer.epoch)
+	observer.vars.Set("lookups", observer.lookups)
+	observer.vars.Set("lookup_errors", observer.lookupErrors)
+	observer.vars.Set("last_rebuild", observer.lastRebuild)
+
+	return observer
+}
+
+// publish publishes the map under the name, a name published outside of
+// the package in the meantime makes expvar panic, which is reported as an
+// error as well.
+func (e *expvarObserver) publish() (err error) {
+	expvarMu.Lock()
+	defer expvarMu.Unlock()
+
+	taken := fmt.Errorf("%w: expvar %q is already published", ErrInvalidOption, e.name)
+
+	if expvar.Get(e.name) != nil {
+		return taken
+	}
+
+	defer func() {
+		if recover() != nil {
+			err = taken
+		}
+	}()
+
+	expvar.Publish(e.name, e.vars)
+
+	return nil
+}
+
+func (e *expvarObserver) ObserveLookup(event LookupEvent) {
+	if event.Err != nil {
+		e.lookupErrors.Add(1)
+
+		return
+	}
+
+	e.lookups.Add(1)
+}
+
+func (e *expvarObserver) ObserveTopology(event TopologyEvent, stats RingStats) {
+	e.nodes.Set(int64(stats.Nodes))
+	e.clusters.Set(int64(stats.Clusters))
+	e.virtualNodes.Set(int64(stats.VirtualNodes))
+	e.epoch.Set(int64(event.Epoch))
+	e.lastRebuild.Set(time.Now().UTC().Format(time.RFC3339Nano))
+}
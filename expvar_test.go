@@ -0,0 +1,84 @@
+package rendezvous
+
+import (
+	"expvar"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpvar(t *testing.T) {
+	// expvar names are global, a name per run allows running tests again
+	name := "rendezvous_test_" + strconv.FormatInt(time.Now().UnixNano(), 10)
+
+	t.Run("should publish the counters", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(Expvar(name))
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4", "jg5"})
+
+		mustFindNode(t, sr, "key-1")
+		mustFindNode(t, sr, "key-2")
+
+		vars := expvar.Get(name).(*expvar.Map)
+
+		assert.Equal(t, "5", vars.Get("nodes").String())
+		assert.Equal(t, "2", vars.Get("clusters").String())
+		assert.Equal(t, "1", vars.Get("virtual_nodes").String())
+		assert.Equal(t, "1", vars.Get("epoch").String())
+		assert.Equal(t, "2", vars.Get("lookups").String())
+		assert.Equal(t, "0", vars.Get("lookup_errors").String())
+
+		_, err = time.Parse(time.RFC3339Nano, vars.Get("last_rebuild").(*expvar.String).Value())
+		assert.NoError(t, err)
+	})
+
+	t.Run("should publish only once the skeleton is created", func(t *testing.T) {
+		retried := name + "_retried"
+
+		_, err := NewSkeletonRendezvous(Expvar(retried), MinClusterSize(3))
+		assert.ErrorIs(t, err, ErrInvalidOption)
+		assert.Nil(t, expvar.Get(retried))
+
+		_, err = NewSkeletonRendezvous(Expvar(retried))
+		assert.NoError(t, err)
+		assert.NotNil(t, expvar.Get(retried))
+	})
+
+	t.Run("should publish a name once when created concurrently", func(t *testing.T) {
+		concurrent := name + "_concurrent"
+
+		var wg sync.WaitGroup
+		var created int32
+
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				if _, err := NewSkeletonRendezvous(Expvar(concurrent)); err == nil {
+					atomic.AddInt32(&created, 1)
+				} else {
+					assert.ErrorIs(t, err, ErrInvalidOption)
+				}
+			}()
+		}
+
+		wg.Wait()
+
+		assert.Equal(t, int32(1), created)
+	})
+
+	t.Run("should reject published or empty name", func(t *testing.T) {
+		_, err := NewSkeletonRendezvous(Expvar(name))
+		assert.ErrorIs(t, err, ErrInvalidOption)
+
+		_, err = NewSkeletonRendezvous(Expvar(""))
+		assert.ErrorIs(t, err, ErrInvalidOption)
+	})
+}
@@ -0,0 +1,77 @@
+package rendezvous
+
+// FindNodeWithFilter find selected node like FindNode, restricted to the
+// nodes matching the filter. The best matching node of the selected cluster
+// is chosen, when no node of the selected cluster matches the best matching
+// node of the next cluster preferred by the branch walk is chosen instead,
+// so the selection stays deterministic within the matching nodes. The
+// filter is given the nodes along with their metadata, it returns
+// ErrNoNodes when no node matches.
+func (sr *SkeletonRendezvous) FindNodeWithFilter(key string, filter func(Node) bool) (string, error) {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+
+	excluded := make(map[string]struct{}, len(sr.unavailable))
+
+	for node := range sr.unavailable {
+		excluded[node] = struct{}{}
+	}
+
+	// only the candidates are given to the filter, the nodes of the other
+	// clusters are only filtered when no candidate matches.
+	exclude := func(ids []string) {
+		for _, id := range ids {
+			if _, ok := excluded[id]; ok {
+				continue
+			}
+
+			node, ok := sr.nodeInfo[id]
+
+			if !ok {
+				node = Node{ID: id}
+			}
+
+			if !filter(node.clone()) {
+				excluded[id] = struct{}{}
+			}
+		}
+	}
+
+	if pinned, ok := sr.pins[key]; ok {
+		exclude([]string{pinned})
+
+		if node, ok := sr.pinnedNode(key, excluded); ok {
+			return node, nil
+		}
+	}
+
+	nodes, err := sr.findClusterNodes(key)
+
+	if err != nil {
+		return "", err
+	}
+
+	exclude(nodes)
+
+	if node, _, ok := sr.findHighestRandomWeightExcluding(key, nodes, excluded); ok {
+		return node, nil
+	}
+
+	exclude(sr.Nodes)
+
+	return sr.findNodeExcluding(key, excluded)
+}
+
+// MatchLabels returns a filter matching the nodes which have every label
+// with the given value, such as {"ssd": "true", "region": "eu"}.
+func MatchLabels(labels map[string]string) func(Node) bool {
+	return func(node Node) bool {
+		for label, value := range labels {
+			if nodeValue, ok := node.Labels[label]; !ok || nodeValue != value {
+				return false
+			}
+		}
+
+		return true
+	}
+}
@@ -0,0 +1,110 @@
+package rendezvous
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindNodeWithFilter(t *testing.T) {
+	newSkeleton := func(t *testing.T) *SkeletonRendezvous {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		sr.SetNodeList([]Node{
+			{ID: "jg1", Labels: map[string]string{"region": "eu", "ssd": "true"}},
+			{ID: "jg2", Labels: map[string]string{"region": "us"}},
+			{ID: "jg3", Labels: map[string]string{"region": "eu"}},
+			{ID: "jg4", Labels: map[string]string{"region": "us", "ssd": "true"}},
+		})
+
+		return sr
+	}
+
+	t.Run("should equal FindNode when every node matches", func(t *testing.T) {
+		sr := newSkeleton(t)
+
+		for i := 0; i < 100; i++ {
+			key := "key-" + strconv.Itoa(i)
+
+			node, err := sr.FindNodeWithFilter(key, func(Node) bool { return true })
+
+			assert.NoError(t, err)
+			assert.Equal(t, mustFindNode(t, sr, key), node)
+		}
+	})
+
+	t.Run("should only filter the nodes of the selected cluster when one matches", func(t *testing.T) {
+		sr := newSkeleton(t)
+
+		for i := 0; i < 100; i++ {
+			key := "key-" + strconv.Itoa(i)
+
+			clusterIndex, err := sr.findCluster(key)
+			assert.NoError(t, err)
+
+			filtered := make([]string, 0)
+
+			_, err = sr.FindNodeWithFilter(key, func(node Node) bool {
+				filtered = append(filtered, node.ID)
+
+				return true
+			})
+
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, sr.Clusters[clusterIndex], filtered)
+		}
+	})
+
+	t.Run("should select deterministically among matching nodes", func(t *testing.T) {
+		sr := newSkeleton(t)
+
+		// random keys, sequential keys share a prefix which fnv spreads
+		// poorly over the nodes
+		random := rand.New(rand.NewSource(1))
+		counts := make(map[string]int)
+
+		for i := 0; i < 100; i++ {
+			key := strconv.FormatUint(random.Uint64(), 36)
+
+			node, err := sr.FindNodeWithFilter(key, MatchLabels(map[string]string{"region": "eu"}))
+
+			assert.NoError(t, err)
+			assert.Contains(t, []string{"jg1", "jg3"}, node)
+
+			again, err := sr.FindNodeWithFilter(key, MatchLabels(map[string]string{"region": "eu"}))
+
+			assert.NoError(t, err)
+			assert.Equal(t, node, again)
+
+			counts[node]++
+		}
+
+		assert.Len(t, counts, 2)
+
+		node, err := sr.FindNodeWithFilter("key-1", MatchLabels(map[string]string{"region": "eu", "ssd": "true"}))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "jg1", node)
+	})
+
+	t.Run("should skip unavailable nodes", func(t *testing.T) {
+		sr := newSkeleton(t)
+
+		sr.MarkDown("jg1")
+
+		_, err := sr.FindNodeWithFilter("key-1", MatchLabels(map[string]string{"ssd": "true", "region": "eu"}))
+
+		assert.ErrorIs(t, err, ErrNoNodes)
+	})
+
+	t.Run("should return error when no node matches", func(t *testing.T) {
+		sr := newSkeleton(t)
+
+		_, err := sr.FindNodeWithFilter("key-1", MatchLabels(map[string]string{"region": "ap"}))
+
+		assert.ErrorIs(t, err, ErrNoNodes)
+	})
+}
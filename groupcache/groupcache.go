@@ -0,0 +1,78 @@
+// Package groupcache selects the groupcache peer owning a key with the
+// skeleton rendezvous. The picker is instantiated with the peer type and
+// satisfies the groupcache PeerPicker interface when P is
+// groupcache.ProtoGetter:
+//
+//	picker := skeletongroupcache.NewPeerPicker(sr, self, func(peer string) groupcache.ProtoGetter {
+//		return newGetter(peer)
+//	})
+//
+//	groupcache.RegisterPeerPicker(func() groupcache.PeerPicker { return picker })
+//	picker.Set("http://10.0.0.1:8080", "http://10.0.0.2:8080")
+package groupcache
+
+import (
+	"sync"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+)
+
+// PeerPicker picks the peer owning a key among the nodes of the skeleton.
+type PeerPicker[P any] struct {
+	skeleton *rendezvous.SkeletonRendezvous
+	self     string
+	getter   func(peer string) P
+
+	mu      sync.RWMutex
+	getters map[string]P
+}
+
+// NewPeerPicker creates the picker of the peer self, getter creates the
+// client fetching values from a peer.
+func NewPeerPicker[P any](sr *rendezvous.SkeletonRendezvous, self string, getter func(peer string) P) *PeerPicker[P] {
+	return &PeerPicker[P]{
+		skeleton: sr,
+		self:     self,
+		getter:   getter,
+		getters:  make(map[string]P),
+	}
+}
+
+// Set replaces the peers, which include self. Only the peers joining and
+// leaving move in the skeleton, like HTTPPool.Set of groupcache.
+func (p *PeerPicker[P]) Set(peers ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	getters := make(map[string]P, len(peers))
+
+	for _, peer := range peers {
+		if getter, ok := p.getters[peer]; ok {
+			getters[peer] = getter
+		} else if peer != p.self {
+			getters[peer] = p.getter(peer)
+		}
+	}
+
+	p.getters = getters
+	p.skeleton.SyncNodes(peers)
+}
+
+// PickPeer returns the getter of the peer owning the key, it reports false
+// when the key is owned by self or there are no peers.
+func (p *PeerPicker[P]) PickPeer(key string) (P, bool) {
+	var getter P
+
+	node, err := p.skeleton.FindNode(key)
+
+	if err != nil || node == p.self {
+		return getter, false
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	getter, ok := p.getters[node]
+
+	return getter, ok
+}
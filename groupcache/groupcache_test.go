@@ -0,0 +1,82 @@
+package groupcache
+
+import (
+	"testing"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+	"github.com/stretchr/testify/assert"
+)
+
+// protoGetter mirrors the groupcache ProtoGetter of a peer.
+type protoGetter struct {
+	peer string
+}
+
+// peerPicker is the groupcache PeerPicker interface.
+type peerPicker interface {
+	PickPeer(key string) (*protoGetter, bool)
+}
+
+func TestPeerPicker(t *testing.T) {
+	peers := []string{"http://10.0.0.1:8080", "http://10.0.0.2:8080", "http://10.0.0.3:8080"}
+
+	newGetter := func(peer string) *protoGetter {
+		return &protoGetter{peer: peer}
+	}
+
+	t.Run("should pick the peer owning the key", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		picker := NewPeerPicker(sr, peers[0], newGetter)
+
+		picker.Set(peers...)
+
+		var _ peerPicker = picker
+
+		for _, key := range []string{"a", "b", "c", "d", "e", "f"} {
+			node, err := sr.FindNode(key)
+
+			assert.NoError(t, err)
+
+			getter, ok := picker.PickPeer(key)
+
+			if node == peers[0] {
+				assert.False(t, ok)
+			} else {
+				assert.True(t, ok)
+				assert.Equal(t, node, getter.peer)
+			}
+		}
+	})
+
+	t.Run("should keep the getters of remaining peers", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		picker := NewPeerPicker(sr, peers[0], newGetter)
+
+		picker.Set(peers...)
+
+		getter := picker.getters[peers[1]]
+
+		picker.Set(peers[:2]...)
+
+		assert.Same(t, getter, picker.getters[peers[1]])
+		assert.Len(t, picker.getters, 1)
+	})
+
+	t.Run("should not pick without peers", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		picker := NewPeerPicker(sr, peers[0], newGetter)
+
+		_, ok := picker.PickPeer("a")
+
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,70 @@
+//go:build grpc
+
+package grpc
+
+import (
+	"errors"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/base"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Name is the name of the balancer in the load balancing config of a
+// service config.
+const Name = "skeleton_rendezvous"
+
+// NewBuilder creates the balancer builder picking the ready SubConn of the
+// node owning the affinity key of an RPC. The SubConns are keyed by their
+// address, the nodes of the skeleton are the addresses of the backends.
+func NewBuilder(sr *rendezvous.SkeletonRendezvous, key KeyFunc) balancer.Builder {
+	return base.NewBalancerBuilder(Name, &pickerBuilder{skeleton: sr, key: key}, base.Config{HealthCheck: true})
+}
+
+// Register registers the balancer, it is selected by a channel with the
+// service config {"loadBalancingConfig": [{"skeleton_rendezvous": {}}]}.
+// It must only be called during initialization.
+func Register(sr *rendezvous.SkeletonRendezvous, key KeyFunc) {
+	balancer.Register(NewBuilder(sr, key))
+}
+
+// pickerBuilder builds a picker whenever the set of ready SubConns changes.
+type pickerBuilder struct {
+	skeleton *rendezvous.SkeletonRendezvous
+	key      KeyFunc
+}
+
+func (b *pickerBuilder) Build(info base.PickerBuildInfo) balancer.Picker {
+	if len(info.ReadySCs) == 0 {
+		return base.NewErrPicker(balancer.ErrNoSubConnAvailable)
+	}
+
+	conns := make(map[string]balancer.SubConn, len(info.ReadySCs))
+
+	for conn, connInfo := range info.ReadySCs {
+		conns[connInfo.Address.Addr] = conn
+	}
+
+	return &subConnPicker{picker: NewPicker(b.skeleton, conns, b.key)}
+}
+
+// subConnPicker turns the picked SubConn into the result of the balancer.
+type subConnPicker struct {
+	picker *Picker[balancer.SubConn]
+}
+
+func (p *subConnPicker) Pick(info balancer.PickInfo) (balancer.PickResult, error) {
+	conn, err := p.picker.Pick(info.Ctx, info.FullMethodName)
+
+	if errors.Is(err, ErrNoKey) {
+		return balancer.PickResult{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err != nil {
+		return balancer.PickResult{}, status.Error(codes.Unavailable, err.Error())
+	}
+
+	return balancer.PickResult{SubConn: conn}, nil
+}
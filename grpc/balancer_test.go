@@ -0,0 +1,87 @@
+//go:build grpc
+
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/base"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/status"
+)
+
+// readySubConn is a ready SubConn of the balancer.
+type readySubConn struct {
+	balancer.SubConn
+
+	addr string
+}
+
+func TestBuilder(t *testing.T) {
+	nodes := []string{"10.0.0.1:50051", "10.0.0.2:50051", "10.0.0.3:50051"}
+
+	info := base.PickerBuildInfo{ReadySCs: make(map[balancer.SubConn]base.SubConnInfo)}
+
+	for _, node := range nodes {
+		info.ReadySCs[&readySubConn{addr: node}] = base.SubConnInfo{Address: resolver.Address{Addr: node}}
+	}
+
+	t.Run("should be named after the balancer", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+		assert.Equal(t, Name, NewBuilder(sr, ContextKey()).Name())
+	})
+
+	t.Run("should pick the ready SubConn of the key node", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		sr.SetNodes(nodes)
+
+		picker := (&pickerBuilder{skeleton: sr, key: ContextKey()}).Build(info)
+
+		result, err := picker.Pick(balancer.PickInfo{FullMethodName: "/users.Users/Get", Ctx: WithKey(context.Background(), "user-1")})
+
+		assert.NoError(t, err)
+
+		node, err := sr.FindNode("user-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, node, result.SubConn.(*readySubConn).addr)
+	})
+
+	t.Run("should fail RPCs without affinity key as invalid", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		sr.SetNodes(nodes)
+
+		picker := (&pickerBuilder{skeleton: sr, key: ContextKey()}).Build(info)
+
+		_, err = picker.Pick(balancer.PickInfo{FullMethodName: "/users.Users/Get", Ctx: context.Background()})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("should wait for a SubConn when none is ready", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		sr.SetNodes(nodes)
+
+		picker := (&pickerBuilder{skeleton: sr, key: ContextKey()}).Build(base.PickerBuildInfo{})
+
+		_, err = picker.Pick(balancer.PickInfo{FullMethodName: "/users.Users/Get", Ctx: WithKey(context.Background(), "user-1")})
+
+		assert.ErrorIs(t, err, balancer.ErrNoSubConnAvailable)
+	})
+}
@@ -0,0 +1,107 @@
+// Package grpc routes the RPCs of a sharded gRPC service to the backend
+// owning their affinity key. Built with the grpc tag in a module requiring
+// google.golang.org/grpc, Register installs a balancer picking the ready
+// SubConn of the node owning the key:
+//
+//	skeletongrpc.Register(sr, skeletongrpc.ContextKey())
+//
+//	conn, err := grpc.Dial("dns:///users:50051",
+//		grpc.WithDefaultServiceConfig(`{"loadBalancingConfig": [{"skeleton_rendezvous": {}}]}`))
+//	...
+//	reply, err := client.Get(skeletongrpc.WithKey(ctx, "user-1"), request)
+//
+// The SubConns are keyed by their address, such as host:port, and the
+// skeleton holds the same nodes. Picker selects among connections of any
+// type for other balancers.
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+)
+
+// ErrNoKey is returned when the RPC carries no affinity key.
+var ErrNoKey = errors.New("grpc: no affinity key")
+
+// KeyFunc returns the affinity key of an RPC from its context and full
+// method name, it reports false when the RPC has no key.
+type KeyFunc func(ctx context.Context, method string) (string, bool)
+
+type contextKey struct{}
+
+// WithKey returns a context carrying the affinity key of the RPC.
+func WithKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, contextKey{}, key)
+}
+
+// ContextKey returns the key set by WithKey.
+func ContextKey() KeyFunc {
+	return func(ctx context.Context, method string) (string, bool) {
+		key, ok := ctx.Value(contextKey{}).(string)
+
+		return key, ok
+	}
+}
+
+// Metadata returns the first value of the metadata header, metadata reads
+// the metadata of the context, such as metadata.FromOutgoingContext.
+func Metadata(header string, metadata func(context.Context) (map[string][]string, bool)) KeyFunc {
+	return func(ctx context.Context, method string) (string, bool) {
+		md, ok := metadata(ctx)
+
+		if !ok || len(md[header]) == 0 {
+			return "", false
+		}
+
+		return md[header][0], true
+	}
+}
+
+// Picker picks the connection of the node selected by the skeleton for the
+// affinity key of an RPC.
+type Picker[C any] struct {
+	skeleton *rendezvous.SkeletonRendezvous
+	conns    map[string]C
+	key      KeyFunc
+}
+
+// NewPicker creates the picker over the ready connections by node.
+func NewPicker[C any](sr *rendezvous.SkeletonRendezvous, conns map[string]C, key KeyFunc) *Picker[C] {
+	return &Picker[C]{
+		skeleton: sr,
+		conns:    conns,
+		key:      key,
+	}
+}
+
+// Pick returns the connection of the node owning the affinity key of the
+// RPC. A node without a ready connection is excluded and the key falls
+// back to the next node of its cluster.
+func (p *Picker[C]) Pick(ctx context.Context, method string) (C, error) {
+	var conn C
+
+	key, ok := p.key(ctx, method)
+
+	if !ok {
+		return conn, fmt.Errorf("%w for %s", ErrNoKey, method)
+	}
+
+	node, err := p.skeleton.FindNode(key)
+
+	excluded := make(map[string]struct{})
+
+	for err == nil {
+		if conn, ok := p.conns[node]; ok {
+			return conn, nil
+		}
+
+		excluded[node] = struct{}{}
+
+		node, err = p.skeleton.FindNodeExcluding(key, excluded)
+	}
+
+	return conn, err
+}
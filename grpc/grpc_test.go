@@ -0,0 +1,111 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+	"github.com/stretchr/testify/assert"
+)
+
+// subConn mirrors a balancer SubConn.
+type subConn struct {
+	addr string
+}
+
+func TestPicker(t *testing.T) {
+	nodes := []string{"10.0.0.1:50051", "10.0.0.2:50051", "10.0.0.3:50051"}
+
+	conns := map[string]*subConn{
+		"10.0.0.1:50051": {addr: "10.0.0.1:50051"},
+		"10.0.0.2:50051": {addr: "10.0.0.2:50051"},
+		"10.0.0.3:50051": {addr: "10.0.0.3:50051"},
+	}
+
+	t.Run("should pick the connection of the key node", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		sr.SetNodes(nodes)
+
+		picker := NewPicker(sr, conns, ContextKey())
+
+		conn, err := picker.Pick(WithKey(context.Background(), "user-1"), "/users.Users/Get")
+
+		assert.NoError(t, err)
+
+		node, err := sr.FindNode("user-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, node, conn.addr)
+	})
+
+	t.Run("should read the key from metadata", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		sr.SetNodes(nodes)
+
+		picker := NewPicker(sr, conns, Metadata("x-user-id", func(ctx context.Context) (map[string][]string, bool) {
+			return map[string][]string{"x-user-id": {"user-2"}}, true
+		}))
+
+		conn, err := picker.Pick(context.Background(), "/users.Users/Get")
+
+		assert.NoError(t, err)
+
+		node, err := sr.FindNode("user-2")
+
+		assert.NoError(t, err)
+		assert.Equal(t, node, conn.addr)
+	})
+
+	t.Run("should skip nodes without connection", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		sr.SetNodes(nodes)
+
+		node, err := sr.FindNode("user-1")
+
+		assert.NoError(t, err)
+
+		ready := map[string]*subConn{}
+
+		for id, conn := range conns {
+			if id != node {
+				ready[id] = conn
+			}
+		}
+
+		picker := NewPicker(sr, ready, ContextKey())
+
+		conn, err := picker.Pick(WithKey(context.Background(), "user-1"), "/users.Users/Get")
+
+		assert.NoError(t, err)
+		assert.NotEqual(t, node, conn.addr)
+	})
+
+	t.Run("should return error without key or connection", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		sr.SetNodes(nodes)
+
+		picker := NewPicker(sr, conns, ContextKey())
+
+		_, err = picker.Pick(context.Background(), "/users.Users/Get")
+
+		assert.ErrorIs(t, err, ErrNoKey)
+
+		picker = NewPicker(sr, map[string]*subConn{}, ContextKey())
+
+		_, err = picker.Pick(WithKey(context.Background(), "user-1"), "/users.Users/Get")
+
+		assert.ErrorIs(t, err, rendezvous.ErrNoNodes)
+	})
+}
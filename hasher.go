@@ -0,0 +1,303 @@
+package rendezvous
+
+import (
+	"encoding"
+	"hash"
+	"strconv"
+	"sync"
+)
+
+// keyHasher hashes a key together with a branch or a node of the skeleton.
+type keyHasher interface {
+	sum(target string, key string) uint64
+	sumBranch(level int, branch int, key string) uint64
+	sumReplica(node string, replica int, key string) uint64
+
+	// hashNode returns the hashed form of the node, sumNode hashes a key
+	// with it like sum hashes the key with the node
+	hashNode(node string) hashedNode
+	sumNode(node hashedNode, key string) uint64
+}
+
+// hashedNode is the cached form of a node, the bytes written before the key
+// and, when the hash can be restored, its state once they are written, so
+// scoring the node only hashes the key.
+type hashedNode struct {
+	prefix []byte
+	state  []byte
+}
+
+// hashState returns the state of the hash after writing the prefix, nil when
+// the hash can not be restored or when the state is not shorter than the
+// prefix, restoring it would cost more than hashing the prefix again.
+func hashState(hash hash.Hash64, prefix []byte) []byte {
+	marshaler, ok := hash.(encoding.BinaryMarshaler)
+
+	if !ok {
+		return nil
+	}
+
+	if _, ok := hash.(encoding.BinaryUnmarshaler); !ok {
+		return nil
+	}
+
+	hash.Reset()
+	hash.Write(prefix)
+
+	state, err := marshaler.MarshalBinary()
+
+	if err != nil || len(state) >= len(prefix) {
+		return nil
+	}
+
+	return state
+}
+
+// sumState restores the state of the hash and writes the key, false when
+// the state can not be restored.
+func sumState(hash hash.Hash64, state []byte, key []byte) (uint64, bool) {
+	if err := hash.(encoding.BinaryUnmarshaler).UnmarshalBinary(state); err != nil {
+		return 0, false
+	}
+
+	hash.Write(key)
+
+	return hash.Sum64(), true
+}
+
+// newHasher creates the hasher of the options, a hash function is preferred
+// over pooled hashes, which are preferred over the single stateful hash.
+func newHasher(o Options) keyHasher {
+	if o.hashFunc != nil {
+		return newFuncHasher(o.hashFunc, o.seed)
+	}
+
+	if o.newHash != nil {
+		hasher := newFuncHasher(pooledHash(o.newHash), o.seed)
+		hasher.hashes = &sync.Pool{
+			New: func() any {
+				return o.newHash()
+			},
+		}
+
+		return hasher
+	}
+
+	return newLockedHasher(o.hash, o.seed)
+}
+
+// pooledHash turns a hash constructor into a hash function, the instances
+// are pooled so concurrent calls use distinct instances without allocating.
+func pooledHash(newHash func() hash.Hash64) func([]byte) uint64 {
+	pool := sync.Pool{
+		New: func() any {
+			return newHash()
+		},
+	}
+
+	return func(p []byte) uint64 {
+		h := pool.Get().(hash.Hash64)
+		defer pool.Put(h)
+
+		h.Reset()
+		h.Write(p)
+
+		return h.Sum64()
+	}
+}
+
+// lockedHasher serializes the use of a stateful hash.Hash64, clones share
+// the hasher along with its mutex.
+type lockedHasher struct {
+	mu      sync.Mutex
+	hash    hash.Hash64
+	seed    uint64
+	scratch []byte
+}
+
+func newLockedHasher(hash hash.Hash64, seed uint64) *lockedHasher {
+	return &lockedHasher{hash: hash, seed: seed}
+}
+
+func (h *lockedHasher) sum(target string, key string) uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// write through a reused scratch buffer, converting the strings
+	// into []byte on every call allocates.
+	h.scratch = appendTarget(appendSeed(h.scratch[:0], h.seed), target, key)
+
+	return h.sumScratch()
+}
+
+// sumBranch hash the key with the branch of a level, <level><branch>
+func (h *lockedHasher) sumBranch(level int, branch int, key string) uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.scratch = appendBranch(appendSeed(h.scratch[:0], h.seed), level, branch, key)
+
+	return h.sumScratch()
+}
+
+// sumReplica hash the key with the virtual copy of a node, node#replica
+func (h *lockedHasher) sumReplica(node string, replica int, key string) uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.scratch = appendReplica(appendSeed(h.scratch[:0], h.seed), node, replica, key)
+
+	return h.sumScratch()
+}
+
+func (h *lockedHasher) hashNode(node string) hashedNode {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	prefix := appendTarget(appendSeed(nil, h.seed), node, "")
+
+	return hashedNode{prefix: prefix, state: hashState(h.hash, prefix)}
+}
+
+func (h *lockedHasher) sumNode(node hashedNode, key string) uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if node.state != nil {
+		h.scratch = append(h.scratch[:0], key...)
+
+		if sum, ok := sumState(h.hash, node.state, h.scratch); ok {
+			return sum
+		}
+	}
+
+	h.scratch = append(append(h.scratch[:0], node.prefix...), key...)
+
+	return h.sumScratch()
+}
+
+func (h *lockedHasher) sumScratch() uint64 {
+	h.hash.Reset()
+	h.hash.Write(h.scratch)
+	return h.hash.Sum64()
+}
+
+// funcHasher hashes with a stateless hash function, the scratch buffers are
+// pooled so concurrent lookups neither contend on a lock nor allocate. The
+// hashes are set when the function is built from a hash constructor, so
+// the states of the hashed nodes can be restored.
+type funcHasher struct {
+	hash    func([]byte) uint64
+	hashes  *sync.Pool
+	seed    uint64
+	scratch sync.Pool
+}
+
+func newFuncHasher(hash func([]byte) uint64, seed uint64) *funcHasher {
+	return &funcHasher{
+		hash: hash,
+		seed: seed,
+		scratch: sync.Pool{
+			New: func() any {
+				scratch := make([]byte, 0, 64)
+
+				return &scratch
+			},
+		},
+	}
+}
+
+func (h *funcHasher) sum(target string, key string) uint64 {
+	scratch := h.scratch.Get().(*[]byte)
+	defer h.scratch.Put(scratch)
+
+	*scratch = appendTarget(appendSeed((*scratch)[:0], h.seed), target, key)
+
+	return h.hash(*scratch)
+}
+
+func (h *funcHasher) sumBranch(level int, branch int, key string) uint64 {
+	scratch := h.scratch.Get().(*[]byte)
+	defer h.scratch.Put(scratch)
+
+	*scratch = appendBranch(appendSeed((*scratch)[:0], h.seed), level, branch, key)
+
+	return h.hash(*scratch)
+}
+
+func (h *funcHasher) sumReplica(node string, replica int, key string) uint64 {
+	scratch := h.scratch.Get().(*[]byte)
+	defer h.scratch.Put(scratch)
+
+	*scratch = appendReplica(appendSeed((*scratch)[:0], h.seed), node, replica, key)
+
+	return h.hash(*scratch)
+}
+
+func (h *funcHasher) hashNode(node string) hashedNode {
+	hashed := hashedNode{prefix: appendTarget(appendSeed(nil, h.seed), node, "")}
+
+	if h.hashes != nil {
+		hash := h.hashes.Get().(hash.Hash64)
+		defer h.hashes.Put(hash)
+
+		hashed.state = hashState(hash, hashed.prefix)
+	}
+
+	return hashed
+}
+
+func (h *funcHasher) sumNode(node hashedNode, key string) uint64 {
+	scratch := h.scratch.Get().(*[]byte)
+	defer h.scratch.Put(scratch)
+
+	if node.state != nil {
+		hash := h.hashes.Get().(hash.Hash64)
+		defer h.hashes.Put(hash)
+
+		*scratch = append((*scratch)[:0], key...)
+
+		if sum, ok := sumState(hash, node.state, *scratch); ok {
+			return sum
+		}
+	}
+
+	*scratch = append(append((*scratch)[:0], node.prefix...), key...)
+
+	return h.hash(*scratch)
+}
+
+// appendSeed prefixes the hashed bytes with the seed, the zero seed writes
+// nothing so unseeded skeletons keep their placement.
+func appendSeed(scratch []byte, seed uint64) []byte {
+	if seed == 0 {
+		return scratch
+	}
+
+	for i := 0; i < 8; i++ {
+		scratch = append(scratch, byte(seed>>(8*i)))
+	}
+
+	return scratch
+}
+
+func appendTarget(scratch []byte, target string, key string) []byte {
+	scratch = append(scratch, target...)
+
+	return append(scratch, key...)
+}
+
+func appendBranch(scratch []byte, level int, branch int, key string) []byte {
+	scratch = strconv.AppendInt(scratch, int64(level), 10)
+	scratch = strconv.AppendInt(scratch, int64(branch), 10)
+
+	return append(scratch, key...)
+}
+
+func appendReplica(scratch []byte, node string, replica int, key string) []byte {
+	scratch = append(scratch, node...)
+	scratch = append(scratch, '#')
+	scratch = strconv.AppendInt(scratch, int64(replica), 10)
+
+	return append(scratch, key...)
+}
@@ -0,0 +1,30 @@
+// Package hashes provides fast, well distributed hash functions for the
+// skeleton rendezvous, wired up as options:
+//
+//	sr, err := rendezvous.NewSkeletonRendezvous(hashes.XXHash64())
+package hashes
+
+import rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+
+// the hash functions are registered by name, so the snapshots of skeletons
+// using them are restored with the same function.
+func init() {
+	rendezvous.RegisterHashFunc("xxhash64", SumXXHash64)
+	rendezvous.RegisterHashFunc("murmur3", SumMurmur3)
+	rendezvous.RegisterHashFunc("wyhash", SumWyHash)
+}
+
+// XXHash64 sets xxhash64 as the hash function of the skeleton.
+func XXHash64() rendezvous.Option {
+	return rendezvous.HashAlgorithmByName("xxhash64")
+}
+
+// Murmur3 sets murmur3 as the hash function of the skeleton.
+func Murmur3() rendezvous.Option {
+	return rendezvous.HashAlgorithmByName("murmur3")
+}
+
+// WyHash sets wyhash as the hash function of the skeleton.
+func WyHash() rendezvous.Option {
+	return rendezvous.HashAlgorithmByName("wyhash")
+}
@@ -0,0 +1,80 @@
+package hashes
+
+import (
+	"strconv"
+	"testing"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptions(t *testing.T) {
+	for name, option := range map[string]rendezvous.Option{
+		"xxhash64": XXHash64(),
+		"murmur3":  Murmur3(),
+		"wyhash":   WyHash(),
+	} {
+		t.Run("should spread keys evenly with "+name, func(t *testing.T) {
+			sr, err := rendezvous.NewSkeletonRendezvous(option)
+
+			assert.NoError(t, err)
+
+			sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4", "jg5", "jg6"})
+
+			counts := make(map[string]int)
+
+			for i := 0; i < 6000; i++ {
+				node, err := sr.FindNode("key-" + strconv.Itoa(i))
+
+				assert.NoError(t, err)
+
+				counts[node]++
+			}
+
+			assert.Len(t, counts, 6)
+
+			for node, count := range counts {
+				assert.InDelta(t, 1000, count, 200, node)
+			}
+		})
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	for name, option := range map[string]rendezvous.Option{
+		"xxhash64": XXHash64(),
+		"murmur3":  Murmur3(),
+		"wyhash":   WyHash(),
+	} {
+		t.Run("should restore the placement of "+name+" into a default skeleton", func(t *testing.T) {
+			sr, err := rendezvous.NewSkeletonRendezvous(option)
+
+			assert.NoError(t, err)
+			assert.Equal(t, name, sr.Algorithm())
+
+			sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4", "jg5", "jg6"})
+
+			data, err := sr.MarshalJSON()
+
+			assert.NoError(t, err)
+
+			restored, err := rendezvous.NewSkeletonRendezvous()
+
+			assert.NoError(t, err)
+			assert.NoError(t, restored.UnmarshalJSON(data))
+			assert.Equal(t, name, restored.Algorithm())
+
+			for i := 0; i < 200; i++ {
+				key := "key-" + strconv.Itoa(i)
+
+				expected, err := sr.FindNode(key)
+				assert.NoError(t, err)
+
+				node, err := restored.FindNode(key)
+				assert.NoError(t, err)
+
+				assert.Equal(t, expected, node, key)
+			}
+		})
+	}
+}
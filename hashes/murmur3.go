@@ -0,0 +1,91 @@
+package hashes
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+const (
+	murmurC1 uint64 = 0x87c37b91114253d5
+	murmurC2 uint64 = 0x4cf5ad432745937f
+)
+
+// SumMurmur3 returns the first 64 bits of the x64 128 bit murmur3 of the
+// data with seed 0.
+func SumMurmur3(data []byte) uint64 {
+	n := len(data)
+
+	var h1, h2 uint64
+
+	for ; len(data) >= 16; data = data[16:] {
+		k1 := binary.LittleEndian.Uint64(data[0:8])
+		k2 := binary.LittleEndian.Uint64(data[8:16])
+
+		h1 ^= murmurMixK1(k1)
+		h1 = bits.RotateLeft64(h1, 27)
+		h1 += h2
+		h1 = h1*5 + 0x52dce729
+
+		h2 ^= murmurMixK2(k2)
+		h2 = bits.RotateLeft64(h2, 31)
+		h2 += h1
+		h2 = h2*5 + 0x38495ab5
+	}
+
+	var k1, k2 uint64
+
+	for i := len(data) - 1; i >= 8; i-- {
+		k2 = k2<<8 | uint64(data[i])
+	}
+
+	for i := len(data) - 1; i >= 0; i-- {
+		if i < 8 {
+			k1 = k1<<8 | uint64(data[i])
+		}
+	}
+
+	if len(data) > 8 {
+		h2 ^= murmurMixK2(k2)
+	}
+
+	if len(data) > 0 {
+		h1 ^= murmurMixK1(k1)
+	}
+
+	h1 ^= uint64(n)
+	h2 ^= uint64(n)
+
+	h1 += h2
+	h2 += h1
+
+	h1 = murmurFmix(h1)
+	h2 = murmurFmix(h2)
+
+	h1 += h2
+
+	return h1
+}
+
+func murmurMixK1(k1 uint64) uint64 {
+	k1 *= murmurC1
+	k1 = bits.RotateLeft64(k1, 31)
+
+	return k1 * murmurC2
+}
+
+func murmurMixK2(k2 uint64) uint64 {
+	k2 *= murmurC2
+	k2 = bits.RotateLeft64(k2, 33)
+
+	return k2 * murmurC1
+}
+
+func murmurFmix(k uint64) uint64 {
+	k ^= k >> 33
+	k *= 0xff51afd7ed558ccd
+	k ^= k >> 33
+	k *= 0xc4ceb9fe1a85ec53
+	k ^= k >> 33
+
+	return k
+}
@@ -0,0 +1,29 @@
+package hashes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSumMurmur3(t *testing.T) {
+	t.Run("should match reference vectors", func(t *testing.T) {
+		vectors := map[string]uint64{
+			"":      0,
+			"hello": 0xcbd8a7b341bd9b02,
+			"The quick brown fox jumps over the lazy dog": 0xe34bbc7bbc071b6c,
+		}
+
+		for data, expected := range vectors {
+			assert.Equal(t, expected, SumMurmur3([]byte(data)), data)
+		}
+	})
+}
+
+func BenchmarkSumMurmur3(b *testing.B) {
+	data := []byte("some-benchmark-key")
+
+	for i := 0; i < b.N; i++ {
+		SumMurmur3(data)
+	}
+}
@@ -0,0 +1,72 @@
+package hashes
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// wySecret is the default secret of wyhash final 4.
+var wySecret = [4]uint64{0x2d358dccaa6c78a5, 0x8bb84b93962eacc9, 0x4b33a62ed433d4a3, 0x4d5a2da51de1aa47}
+
+// SumWyHash returns the wyhash final 4 of the data with seed 0.
+func SumWyHash(data []byte) uint64 {
+	n := len(data)
+	seed := wyMix(wySecret[0], wySecret[1])
+
+	var a, b uint64
+
+	switch {
+	case n <= 16 && n >= 4:
+		shift := (n >> 3) << 2
+		a = wyRead4(data)<<32 | wyRead4(data[shift:])
+		b = wyRead4(data[n-4:])<<32 | wyRead4(data[n-4-shift:])
+	case n <= 16 && n > 0:
+		a = uint64(data[0])<<16 | uint64(data[n>>1])<<8 | uint64(data[n-1])
+	case n > 16:
+		p := data
+
+		if len(p) > 48 {
+			see1, see2 := seed, seed
+
+			for len(p) > 48 {
+				seed = wyMix(wyRead8(p)^wySecret[1], wyRead8(p[8:])^seed)
+				see1 = wyMix(wyRead8(p[16:])^wySecret[2], wyRead8(p[24:])^see1)
+				see2 = wyMix(wyRead8(p[32:])^wySecret[3], wyRead8(p[40:])^see2)
+				p = p[48:]
+			}
+
+			seed ^= see1 ^ see2
+		}
+
+		for len(p) > 16 {
+			seed = wyMix(wyRead8(p)^wySecret[1], wyRead8(p[8:])^seed)
+			p = p[16:]
+		}
+
+		// the last 16 bytes may overlap with bytes already mixed
+		tail := data[n-16:]
+		a = wyRead8(tail)
+		b = wyRead8(tail[8:])
+	}
+
+	a ^= wySecret[1]
+	b ^= seed
+
+	hi, lo := bits.Mul64(a, b)
+
+	return wyMix(lo^wySecret[0]^uint64(n), hi^wySecret[1])
+}
+
+func wyMix(a uint64, b uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+
+	return hi ^ lo
+}
+
+func wyRead4(p []byte) uint64 {
+	return uint64(binary.LittleEndian.Uint32(p))
+}
+
+func wyRead8(p []byte) uint64 {
+	return binary.LittleEndian.Uint64(p)
+}
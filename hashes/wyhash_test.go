@@ -0,0 +1,36 @@
+package hashes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSumWyHash(t *testing.T) {
+	t.Run("should match reference vector of empty input", func(t *testing.T) {
+		assert.Equal(t, uint64(0x93228a4de0eec5a2), SumWyHash(nil))
+	})
+
+	t.Run("should hash every input length distinctly", func(t *testing.T) {
+		data := make([]byte, 0, 128)
+		seen := make(map[uint64]int)
+
+		for n := 0; n <= 128; n++ {
+			sum := SumWyHash(data)
+
+			assert.NotContains(t, seen, sum)
+			assert.Equal(t, sum, SumWyHash(append([]byte(nil), data...)))
+
+			seen[sum] = n
+			data = append(data, byte('a'+n%26))
+		}
+	})
+}
+
+func BenchmarkSumWyHash(b *testing.B) {
+	data := []byte("some-benchmark-key")
+
+	for i := 0; i < b.N; i++ {
+		SumWyHash(data)
+	}
+}
@@ -0,0 +1,33 @@
+package hashes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSumXXHash64(t *testing.T) {
+	t.Run("should match reference vectors", func(t *testing.T) {
+		vectors := map[string]uint64{
+			"":                           0xef46db3751d8e999,
+			"a":                          0xd24ec4f1a98c6e5b,
+			"abc":                        0x44bc2cf5ad770999,
+			"message digest":             0x066ed728fceeb3be,
+			"abcdefghijklmnopqrstuvwxyz": 0xcfe1f278fa89835c,
+			"ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789":                   0xaaa46907d3047814,
+			"12345678901234567890123456789012345678901234567890123456789012345678901234567890": 0xe04a477f19ee145d,
+		}
+
+		for data, expected := range vectors {
+			assert.Equal(t, expected, SumXXHash64([]byte(data)), data)
+		}
+	})
+}
+
+func BenchmarkSumXXHash64(b *testing.B) {
+	data := []byte("some-benchmark-key")
+
+	for i := 0; i < b.N; i++ {
+		SumXXHash64(data)
+	}
+}
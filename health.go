@@ -0,0 +1,39 @@
+package rendezvous
+
+// SetHealth sets the health score of nodes between 0.0 and 1.0. The node
+// score is scaled by its health so unhealthy nodes gradually receive less
+// keys, a node at health 0.5 receives roughly half of its usual share.
+// Nodes without health score are treated as fully healthy.
+func (sr *SkeletonRendezvous) SetHealth(health map[string]float64) {
+	sr.update(func() {
+		sr.setHealth(health)
+	})
+}
+
+func (sr *SkeletonRendezvous) setHealth(health map[string]float64) {
+	if health == nil {
+		sr.health = nil
+
+		return
+	}
+
+	sr.health = make(map[string]float64, len(health))
+
+	for node, score := range health {
+		sr.health[node] = score
+	}
+}
+
+func (sr *SkeletonRendezvous) nodeHealth(node string) float64 {
+	score, ok := sr.health[node]
+
+	if !ok || score > 1 {
+		return 1
+	}
+
+	if score < 0 {
+		return 0
+	}
+
+	return score
+}
@@ -0,0 +1,56 @@
+package rendezvous
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetHealth(t *testing.T) {
+	countKeys := func(sr *SkeletonRendezvous) map[string]int {
+		counts := make(map[string]int)
+
+		for i := 0; i < 6000; i++ {
+			counts[mustFindNode(t, sr, "key-"+strconv.Itoa(i))]++
+		}
+
+		return counts
+	}
+
+	t.Run("should keep placement when every node is healthy", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(2), MinClusterSize(2))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		before := countKeys(sr)
+
+		sr.SetHealth(map[string]float64{"jg1": 1, "jg2": 1, "jg3": 1, "jg4": 1})
+
+		assert.Equal(t, before, countKeys(sr))
+	})
+
+	t.Run("should shift keys away as health drops", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(HashAlgorithm(newMixedHash()), ClusterSize(2), MinClusterSize(2))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2"})
+
+		counts := countKeys(sr)
+		assert.InDelta(t, 3000, counts["jg1"], 300)
+
+		sr.SetHealth(map[string]float64{"jg1": 0.5})
+
+		counts = countKeys(sr)
+		assert.InDelta(t, 2000, counts["jg1"], 300)
+
+		sr.SetHealth(map[string]float64{"jg1": 0.1})
+
+		counts = countKeys(sr)
+		assert.InDelta(t, 545, counts["jg1"], 200)
+		assert.Equal(t, 6000, counts["jg1"]+counts["jg2"])
+	})
+}
@@ -0,0 +1,64 @@
+package rendezvous
+
+import (
+	"fmt"
+)
+
+// EpochHistory keeps the topology and the node states of the last epochs
+// before each change, so PreviousOwner answers who owned a key in an epoch
+// which already ended. Every change of the nodes or the clusters copies the
+// topology while a history is kept.
+func EpochHistory(epochs int) Option {
+	return func(o *Options) error {
+		if epochs < 0 {
+			return fmt.Errorf("%w: epoch history must not be negative, got %d", ErrInvalidOption, epochs)
+		}
+
+		o.epochHistory = epochs
+
+		return nil
+	}
+}
+
+// PreviousOwner find the node owning the key in the epoch like
+// FindPreviousNode, treating draining nodes as up, so reads can be proxied to
+// the owner before a drain while the keys migrate. Epochs which ended are
+// answered from the epoch history with the node states at their end. It
+// returns ErrUnknownEpoch when the epoch is not kept.
+func (sr *SkeletonRendezvous) PreviousOwner(key string, epoch uint64) (string, error) {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+
+	if epoch == sr.epoch {
+		return sr.selectNode(key, sr.nodesIn(NodeDown), false)
+	}
+
+	for _, past := range sr.history {
+		if past.epoch == epoch {
+			return past.selectNode(key, past.nodesIn(NodeDown), false)
+		}
+	}
+
+	return "", fmt.Errorf("%w: %d", ErrUnknownEpoch, epoch)
+}
+
+// freeze copies the topology and the node states for the epoch history, the
+// caller must hold the lock. Keys are placed without bounded load, since the
+// loads change with every lookup.
+func (sr *SkeletonRendezvous) freeze() *SkeletonRendezvous {
+	past := sr.clone()
+	past.epoch = sr.epoch
+	past.options.boundedLoad = false
+
+	return past
+}
+
+// remember appends the topology of an ended epoch to the history, dropping
+// the oldest epochs beyond the history size. The caller must hold the lock.
+func (sr *SkeletonRendezvous) remember(past *SkeletonRendezvous) {
+	sr.history = append(sr.history, past)
+
+	if len(sr.history) > sr.options.epochHistory {
+		sr.history = append(sr.history[:0:0], sr.history[len(sr.history)-sr.options.epochHistory:]...)
+	}
+}
@@ -0,0 +1,138 @@
+package rendezvous
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreviousOwner(t *testing.T) {
+	keys := make([]string, 100)
+
+	for i := range keys {
+		keys[i] = "key-" + strconv.Itoa(i)
+	}
+
+	t.Run("should report the owner before the drain in the current epoch", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		owners, err := sr.FindNodes(keys)
+		assert.NoError(t, err)
+
+		sr.MarkDraining("jg1")
+
+		for _, key := range keys {
+			owner, err := sr.PreviousOwner(key, sr.Epoch())
+
+			assert.NoError(t, err)
+			assert.Equal(t, owners[key], owner)
+			assert.NotEqual(t, "jg1", mustFindNode(t, sr, key))
+		}
+	})
+
+	t.Run("should report the owner of an ended epoch", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(EpochHistory(2))
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		owners, err := sr.FindNodes(keys)
+		assert.NoError(t, err)
+
+		drained := sr.Epoch()
+
+		sr.MarkDraining("jg1")
+		sr.RemoveNodes([]string{"jg1"})
+
+		assert.NotEqual(t, drained, sr.Epoch())
+
+		moved := 0
+
+		for _, key := range keys {
+			owner, err := sr.PreviousOwner(key, drained)
+
+			assert.NoError(t, err)
+			assert.Equal(t, owners[key], owner)
+
+			if owner == "jg1" {
+				moved++
+			}
+		}
+
+		assert.NotZero(t, moved)
+	})
+
+	t.Run("should forget the epochs beyond the history", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(EpochHistory(2))
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2"})
+
+		first := sr.Epoch()
+
+		sr.AddNodes([]string{"jg3"})
+		sr.AddNodes([]string{"jg4"})
+
+		_, err = sr.PreviousOwner("key-1", first)
+		assert.NoError(t, err)
+
+		sr.AddNodes([]string{"jg5"})
+
+		_, err = sr.PreviousOwner("key-1", first)
+		assert.ErrorIs(t, err, ErrUnknownEpoch)
+
+		_, err = sr.PreviousOwner("key-1", first+1)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should keep no history by default", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2"})
+
+		previous := sr.Epoch()
+
+		sr.AddNodes([]string{"jg3"})
+
+		_, err = sr.PreviousOwner("key-1", previous)
+		assert.ErrorIs(t, err, ErrUnknownEpoch)
+	})
+
+	t.Run("should not copy the topology when the epoch does not change", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(EpochHistory(2))
+		assert.NoError(t, err)
+
+		plain, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		nodes := make([]string, 64)
+
+		for i := range nodes {
+			nodes[i] = "jg" + strconv.Itoa(i)
+		}
+
+		sr.SetNodes(nodes)
+		plain.SetNodes(nodes)
+
+		drain := func(sr *SkeletonRendezvous) func() {
+			return func() {
+				sr.MarkDraining("jg1")
+				sr.MarkUp("jg1")
+			}
+		}
+
+		assert.Equal(t, testing.AllocsPerRun(100, drain(plain)), testing.AllocsPerRun(100, drain(sr)))
+		assert.Len(t, sr.history, 1)
+	})
+
+	t.Run("should reject a negative history", func(t *testing.T) {
+		_, err := NewSkeletonRendezvous(EpochHistory(-1))
+
+		assert.ErrorIs(t, err, ErrInvalidOption)
+	})
+}
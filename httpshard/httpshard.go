@@ -0,0 +1,184 @@
+// Package httpshard reverse-proxies HTTP requests to the backend owning
+// their shard key, the nodes of the skeleton are the backends.
+package httpshard
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+)
+
+// KeyExtractor returns the shard key of a request, it reports false when
+// the request has no key.
+type KeyExtractor func(r *http.Request) (string, bool)
+
+// Header returns the value of the header.
+func Header(name string) KeyExtractor {
+	return func(r *http.Request) (string, bool) {
+		key := r.Header.Get(name)
+
+		return key, key != ""
+	}
+}
+
+// Cookie returns the value of the cookie.
+func Cookie(name string) KeyExtractor {
+	return func(r *http.Request) (string, bool) {
+		cookie, err := r.Cookie(name)
+
+		if err != nil || cookie.Value == "" {
+			return "", false
+		}
+
+		return cookie.Value, true
+	}
+}
+
+// PathSegment returns the i-th segment of the path, the segment of
+// /users/42 at 1 is 42.
+func PathSegment(i int) KeyExtractor {
+	return func(r *http.Request) (string, bool) {
+		segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+		if i < 0 || i >= len(segments) || segments[i] == "" {
+			return "", false
+		}
+
+		return segments[i], true
+	}
+}
+
+// Query returns the value of the query parameter.
+func Query(name string) KeyExtractor {
+	return func(r *http.Request) (string, bool) {
+		key := r.URL.Query().Get(name)
+
+		return key, key != ""
+	}
+}
+
+// Config configures the proxy.
+type Config struct {
+	// Key extracts the shard key of the requests
+	Key KeyExtractor
+
+	// Target returns the URL of a backend from its node, http://node by
+	// default
+	Target func(node string) (*url.URL, error)
+
+	// Retries is how many next candidates of the key are tried when the
+	// chosen backend can not be reached. The request body is buffered in
+	// memory when retries are enabled
+	Retries int
+
+	// Transport sends the proxied requests, http.DefaultTransport by default
+	Transport http.RoundTripper
+}
+
+// Handler proxies each request to the node selected by the skeleton for
+// its shard key. It responds 400 when the request has no key, 503 when the
+// skeleton has no node and 502 when no backend could be reached.
+type Handler struct {
+	skeleton *rendezvous.SkeletonRendezvous
+	config   Config
+}
+
+// NewHandler creates the proxy handler over the skeleton.
+func NewHandler(sr *rendezvous.SkeletonRendezvous, config Config) *Handler {
+	if config.Target == nil {
+		config.Target = func(node string) (*url.URL, error) {
+			return url.Parse("http://" + node)
+		}
+	}
+
+	return &Handler{
+		skeleton: sr,
+		config:   config,
+	}
+}
+
+// ServeHTTP proxies the request, a backend which can not be reached is
+// excluded and the request is retried on the next node of the key.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key, ok := h.config.Key(r)
+
+	if !ok {
+		http.Error(w, "missing shard key", http.StatusBadRequest)
+		return
+	}
+
+	node, err := h.skeleton.FindNode(key)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	var body []byte
+
+	if h.config.Retries > 0 && r.Body != nil && r.Body != http.NoBody {
+		if body, err = io.ReadAll(r.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	excluded := make(map[string]struct{})
+
+	for attempt := 0; ; attempt++ {
+		err = h.proxy(w, r, node, body, attempt < h.config.Retries)
+
+		if err == nil {
+			return
+		}
+
+		if attempt >= h.config.Retries {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+
+		excluded[node] = struct{}{}
+
+		if node, err = h.skeleton.FindNodeExcluding(key, excluded); err != nil {
+			http.Error(w, "no reachable backend", http.StatusBadGateway)
+			return
+		}
+	}
+}
+
+// proxy sends the request to the node, the error of an unreachable backend
+// is returned instead of responded when the request can be retried.
+func (h *Handler) proxy(w http.ResponseWriter, r *http.Request, node string, body []byte, retry bool) error {
+	target, err := h.config.Target(node)
+
+	if err != nil {
+		return fmt.Errorf("target of node %s: %w", node, err)
+	}
+
+	var proxyErr error
+
+	proxy := httputil.NewSingleHostReverseProxy(target)
+	proxy.Transport = h.config.Transport
+
+	if retry {
+		proxy.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+			proxyErr = err
+		}
+	}
+
+	req := r.Clone(r.Context())
+
+	if body != nil {
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	proxy.ServeHTTP(w, req)
+
+	return proxyErr
+}
@@ -0,0 +1,200 @@
+package httpshard
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+	"github.com/stretchr/testify/assert"
+)
+
+// startBackends starts backends responding with their address, the down
+// backends can not be reached through the returned transport.
+func startBackends(t *testing.T, n int, down int) ([]string, http.RoundTripper) {
+	nodes := make([]string, n)
+	transport := downTransport{}
+
+	for i := range nodes {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+
+			io.WriteString(w, r.Context().Value(http.LocalAddrContextKey).(net.Addr).String()+" "+string(body))
+		}))
+
+		t.Cleanup(server.Close)
+
+		nodes[i] = strings.TrimPrefix(server.URL, "http://")
+
+		if i < down {
+			transport[nodes[i]] = true
+		}
+	}
+
+	return nodes, transport
+}
+
+// downTransport fails to reach the down hosts.
+type downTransport map[string]bool
+
+func (d downTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if d[r.URL.Host] {
+		return nil, errors.New("connection refused")
+	}
+
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func serve(handler http.Handler, r *http.Request) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, r)
+
+	return recorder
+}
+
+func TestHandler(t *testing.T) {
+	t.Run("should proxy to the node of the key", func(t *testing.T) {
+		nodes, transport := startBackends(t, 3, 0)
+
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		sr.SetNodes(nodes)
+
+		handler := NewHandler(sr, Config{Key: PathSegment(1), Transport: transport})
+
+		for _, user := range []string{"1", "2", "3", "4"} {
+			node, err := sr.FindNode(user)
+
+			assert.NoError(t, err)
+
+			recorder := serve(handler, httptest.NewRequest(http.MethodGet, "/users/"+user, nil))
+
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.Equal(t, node+" ", recorder.Body.String())
+		}
+	})
+
+	t.Run("should retry on the next node with the body", func(t *testing.T) {
+		nodes, _ := startBackends(t, 2, 0)
+
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		sr.SetNodes(nodes)
+
+		key := "user-1"
+
+		node, err := sr.FindNode(key)
+
+		assert.NoError(t, err)
+
+		// the node of the key is down, the other node serves the retry
+		transport := downTransport{node: true}
+
+		other := nodes[0]
+
+		if node == other {
+			other = nodes[1]
+		}
+
+		handler := NewHandler(sr, Config{Key: Header("X-User"), Retries: 1, Transport: transport})
+
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("payload"))
+		r.Header.Set("X-User", key)
+
+		recorder := serve(handler, r)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, other+" payload", recorder.Body.String())
+	})
+
+	t.Run("should respond bad gateway without retries", func(t *testing.T) {
+		nodes, transport := startBackends(t, 1, 1)
+
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		sr.SetNodes(nodes)
+
+		handler := NewHandler(sr, Config{Key: Query("user"), Transport: transport})
+
+		recorder := serve(handler, httptest.NewRequest(http.MethodGet, "/?user=1", nil))
+
+		assert.Equal(t, http.StatusBadGateway, recorder.Code)
+	})
+
+	t.Run("should respond bad request without key", func(t *testing.T) {
+		nodes, _ := startBackends(t, 1, 0)
+
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		sr.SetNodes(nodes)
+
+		handler := NewHandler(sr, Config{Key: Cookie("session")})
+
+		recorder := serve(handler, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+
+	t.Run("should respond service unavailable without nodes", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		handler := NewHandler(sr, Config{Key: Query("user")})
+
+		recorder := serve(handler, httptest.NewRequest(http.MethodGet, "/?user=1", nil))
+
+		assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	})
+}
+
+func TestKeyExtractor(t *testing.T) {
+	t.Run("should extract keys from the request", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/users/42?tenant=acme", nil)
+		r.Header.Set("X-User", "42")
+		r.AddCookie(&http.Cookie{Name: "session", Value: "s1"})
+
+		for extractor, expected := range map[*KeyExtractor]string{
+			ptr(Header("X-User")):  "42",
+			ptr(Cookie("session")): "s1",
+			ptr(PathSegment(1)):    "42",
+			ptr(Query("tenant")):   "acme",
+		} {
+			key, ok := (*extractor)(r)
+
+			assert.True(t, ok)
+			assert.Equal(t, expected, key)
+		}
+
+		_, ok := PathSegment(2)(r)
+
+		assert.False(t, ok)
+	})
+
+	t.Run("should use the target of the node", func(t *testing.T) {
+		handler := NewHandler(nil, Config{})
+
+		target, err := handler.config.Target("10.0.0.1:80")
+
+		assert.NoError(t, err)
+		assert.Equal(t, &url.URL{Scheme: "http", Host: "10.0.0.1:80"}, target)
+	})
+}
+
+func ptr(extractor KeyExtractor) *KeyExtractor {
+	return &extractor
+}
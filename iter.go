@@ -0,0 +1,46 @@
+package rendezvous
+
+// NodeIterator yields the nodes for a key in descending preference order,
+// the order of FindN. The nodes are ranked when the iterator is created,
+// the nodes joining afterwards are not yielded. It is not safe for
+// concurrent use.
+type NodeIterator struct {
+	nodes []string
+	err   error
+}
+
+// Iter returns an iterator over the nodes for the key, such as to try the
+// next best node when a node is unreachable. The first node is the node
+// selected by FindNode, the nodes which are draining or down are skipped.
+func (sr *SkeletonRendezvous) Iter(key string) *NodeIterator {
+	var events []LookupEvent
+
+	// deferred first, the observers are notified once the lock is released.
+	defer func() { sr.notifyLookups(events) }()
+
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+
+	start := sr.lookupStart()
+	nodes, err := sr.preferredNodes(key, len(sr.Nodes), sr.unavailableNodes())
+	sr.recordLookup(&events, key, firstNode(nodes), err, start)
+
+	return &NodeIterator{nodes: nodes, err: err}
+}
+
+// Next returns the next node, false once every node has been returned.
+func (it *NodeIterator) Next() (string, bool) {
+	if len(it.nodes) == 0 {
+		return "", false
+	}
+
+	node := it.nodes[0]
+	it.nodes = it.nodes[1:]
+
+	return node, true
+}
+
+// Err returns the error ranking the nodes of the key, such as ErrNoNodes.
+func (it *NodeIterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,70 @@
+package rendezvous
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func collect(it *NodeIterator) []string {
+	nodes := make([]string, 0)
+
+	for node, ok := it.Next(); ok; node, ok = it.Next() {
+		nodes = append(nodes, node)
+	}
+
+	return nodes
+}
+
+func TestIter(t *testing.T) {
+	t.Run("should yield nodes in the order of FindN", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4", "jg5", "jg6"})
+
+		for i := 0; i < 50; i++ {
+			key := "key-" + strconv.Itoa(i)
+
+			expected, err := sr.FindN(key, 6)
+			assert.NoError(t, err)
+
+			it := sr.Iter(key)
+
+			assert.Equal(t, expected, collect(it))
+			assert.NoError(t, it.Err())
+		}
+	})
+
+	t.Run("should yield the nodes ranked when created", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		expected, err := sr.FindN("key-1", 4)
+		assert.NoError(t, err)
+
+		it := sr.Iter("key-1")
+
+		first, ok := it.Next()
+		assert.True(t, ok)
+
+		sr.AddNodes([]string{"jg5", "jg6"})
+
+		assert.Equal(t, expected, append([]string{first}, collect(it)...))
+	})
+
+	t.Run("should report error without nodes", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		it := sr.Iter("key-1")
+
+		_, ok := it.Next()
+
+		assert.False(t, ok)
+		assert.ErrorIs(t, it.Err(), ErrNoNodes)
+	})
+}
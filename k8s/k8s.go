@@ -0,0 +1,122 @@
+// Package k8s keeps the nodes of a skeleton rendezvous in sync with the
+// EndpointSlices of a Kubernetes Service. EndpointSlice mirrors the fields
+// of discovery.k8s.io/v1 the adapter reads, and the slices are fed from an
+// informer of the caller.
+package k8s
+
+import (
+	"context"
+	"net"
+	"sort"
+	"strconv"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+)
+
+// EndpointSlice is the subset of a discovery.k8s.io/v1 EndpointSlice.
+type EndpointSlice struct {
+	Endpoints []Endpoint
+	Ports     []EndpointPort
+}
+
+// Endpoint is a pod backing the Service.
+type Endpoint struct {
+	Addresses  []string
+	Conditions EndpointConditions
+
+	// Hostname is set for pods of a StatefulSet, it identifies the node
+	// since it is stable across restarts of the pod
+	Hostname *string
+}
+
+// EndpointConditions is the readiness of an endpoint.
+type EndpointConditions struct {
+	Ready       *bool
+	Serving     *bool
+	Terminating *bool
+}
+
+// EndpointPort is a port of the endpoints.
+type EndpointPort struct {
+	Name *string
+	Port *int32
+}
+
+// Apply makes the nodes of the skeleton match the endpoints of the slices,
+// which are every slice of the Service. Ready endpoints are marked up,
+// terminating endpoints which still serve are marked draining and the other
+// endpoints are marked down, so readiness changes do not restructure the
+// clusters. The nodes and their states are changed in a single change.
+func Apply(sr *rendezvous.SkeletonRendezvous, slices []EndpointSlice) {
+	states := make(map[string]rendezvous.NodeState)
+
+	for _, slice := range slices {
+		for _, endpoint := range slice.Endpoints {
+			if id, ok := endpointID(endpoint, slice.Ports); ok {
+				states[id] = endpointState(endpoint.Conditions)
+			}
+		}
+	}
+
+	nodes := make([]string, 0, len(states))
+
+	for node := range states {
+		nodes = append(nodes, node)
+	}
+
+	// slices are unordered, sorting keeps the clusters stable.
+	sort.Strings(nodes)
+
+	sr.SyncNodeStates(nodes, states)
+}
+
+// Watch applies every list of slices received until the context is done or
+// the channel is closed, typically sent by the event handler of an
+// EndpointSlice informer filtered by the Service.
+func Watch(ctx context.Context, sr *rendezvous.SkeletonRendezvous, slices <-chan []EndpointSlice) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case list, ok := <-slices:
+			if !ok {
+				return nil
+			}
+
+			Apply(sr, list)
+		}
+	}
+}
+
+// endpointID identifies the endpoint by its hostname, or by its first
+// address along with the first port.
+func endpointID(endpoint Endpoint, ports []EndpointPort) (string, bool) {
+	if endpoint.Hostname != nil && *endpoint.Hostname != "" {
+		return *endpoint.Hostname, true
+	}
+
+	if len(endpoint.Addresses) == 0 {
+		return "", false
+	}
+
+	if len(ports) == 0 || ports[0].Port == nil {
+		return endpoint.Addresses[0], true
+	}
+
+	return net.JoinHostPort(endpoint.Addresses[0], strconv.Itoa(int(*ports[0].Port))), true
+}
+
+// endpointState maps the conditions of an endpoint to a node state, an
+// unknown readiness is taken as ready like Kubernetes does.
+func endpointState(conditions EndpointConditions) rendezvous.NodeState {
+	if conditions.Ready == nil || *conditions.Ready {
+		return rendezvous.NodeUp
+	}
+
+	if conditions.Terminating != nil && *conditions.Terminating &&
+		conditions.Serving != nil && *conditions.Serving {
+		return rendezvous.NodeDraining
+	}
+
+	return rendezvous.NodeDown
+}
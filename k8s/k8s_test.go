@@ -0,0 +1,131 @@
+package k8s
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+	"github.com/RiskyFeryansyahP/go-skeleton-rendezvous/hashes"
+	"github.com/stretchr/testify/assert"
+)
+
+func pointer[T any](value T) *T {
+	return &value
+}
+
+func TestApply(t *testing.T) {
+	t.Run("should map endpoints and their readiness", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		Apply(sr, []EndpointSlice{
+			{
+				Endpoints: []Endpoint{
+					{Hostname: pointer("cache-0"), Conditions: EndpointConditions{Ready: pointer(true)}},
+					{Hostname: pointer("cache-1"), Conditions: EndpointConditions{Ready: pointer(false)}},
+				},
+			},
+			{
+				Endpoints: []Endpoint{
+					{Addresses: []string{"10.0.0.3"}, Conditions: EndpointConditions{
+						Ready: pointer(false), Serving: pointer(true), Terminating: pointer(true),
+					}},
+					{Addresses: []string{"10.0.0.4"}},
+				},
+				Ports: []EndpointPort{{Port: pointer(int32(6379))}},
+			},
+		})
+
+		assert.Equal(t, []string{"10.0.0.3:6379", "10.0.0.4:6379", "cache-0", "cache-1"}, sr.Nodes)
+		assert.Equal(t, rendezvous.NodeUp, sr.State("cache-0"))
+		assert.Equal(t, rendezvous.NodeDown, sr.State("cache-1"))
+		assert.Equal(t, rendezvous.NodeDraining, sr.State("10.0.0.3:6379"))
+		assert.Equal(t, rendezvous.NodeUp, sr.State("10.0.0.4:6379"))
+	})
+
+	t.Run("should keep clusters when only readiness changes", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		slice := EndpointSlice{Endpoints: []Endpoint{
+			{Hostname: pointer("cache-0")},
+			{Hostname: pointer("cache-1")},
+			{Hostname: pointer("cache-2")},
+		}}
+
+		Apply(sr, []EndpointSlice{slice})
+
+		clusters := sr.Clusters
+
+		slice.Endpoints[1].Conditions.Ready = pointer(false)
+
+		Apply(sr, []EndpointSlice{slice})
+
+		assert.Equal(t, clusters, sr.Clusters)
+		assert.Equal(t, rendezvous.NodeDown, sr.State("cache-1"))
+
+		slice.Endpoints[1].Conditions.Ready = pointer(true)
+
+		Apply(sr, []EndpointSlice{slice})
+
+		assert.Equal(t, rendezvous.NodeUp, sr.State("cache-1"))
+	})
+
+	t.Run("should never place keys on an endpoint which is not ready", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous(hashes.XXHash64())
+
+		assert.NoError(t, err)
+
+		slice := EndpointSlice{Endpoints: []Endpoint{
+			{Hostname: pointer("cache-0")},
+			{Hostname: pointer("cache-1")},
+			{Hostname: pointer("cache-2")},
+		}}
+
+		Apply(sr, []EndpointSlice{slice})
+
+		keys := make([]string, 0, 1000)
+
+		for i := 0; i < 1000; i++ {
+			keys = append(keys, "key-"+strconv.Itoa(i))
+		}
+
+		placed := make(map[string]bool)
+
+		sr.Watch(keys, func(key, oldNode, newNode string) {
+			placed[newNode] = true
+		})
+
+		// the new endpoints form their own cluster, which takes keys as soon
+		// as they are ready.
+		slice.Endpoints = append(slice.Endpoints,
+			Endpoint{Hostname: pointer("cache-3"), Conditions: EndpointConditions{Ready: pointer(false)}},
+			Endpoint{Hostname: pointer("cache-4"), Conditions: EndpointConditions{Ready: pointer(false)}},
+		)
+
+		Apply(sr, []EndpointSlice{slice})
+
+		assert.Equal(t, rendezvous.NodeDown, sr.State("cache-3"))
+		assert.False(t, placed["cache-3"])
+		assert.False(t, placed["cache-4"])
+	})
+}
+
+func TestWatch(t *testing.T) {
+	t.Run("should apply slices until channel is closed", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		slices := make(chan []EndpointSlice, 2)
+		slices <- []EndpointSlice{{Endpoints: []Endpoint{{Hostname: pointer("cache-0")}, {Hostname: pointer("cache-1")}}}}
+		slices <- []EndpointSlice{{Endpoints: []Endpoint{{Hostname: pointer("cache-1")}}}}
+		close(slices)
+
+		assert.NoError(t, Watch(context.Background(), sr, slices))
+		assert.Equal(t, []string{"cache-1"}, sr.Nodes)
+	})
+}
@@ -0,0 +1,135 @@
+// Package kafkapart maps message keys to Kafka partitions with the skeleton
+// rendezvous, so growing the partitions of a topic remaps few keys unlike
+// the modulo of the default hash partitioners. Each partition is a node of
+// the skeleton built for the partition count of the topic. Partitioner is
+// wrapped into the partitioner interface of a Kafka client, such as for
+// sarama:
+//
+//	func (p partitioner) Partition(message *sarama.ProducerMessage, n int32) (int32, error) {
+//		key, err := message.Key.Encode()
+//		if err != nil {
+//			return -1, err
+//		}
+//		return p.Partitioner.Partition(key, n)
+//	}
+//
+//	func (p partitioner) RequiresConsistency() bool {
+//		return true
+//	}
+//
+// and for franz-go, where the wrapper is returned by ForTopic:
+//
+//	func (p partitioner) Partition(record *kgo.Record, n int) int {
+//		partition, _ := p.Partitioner.Partition(record.Key, int32(n))
+//		return int(partition)
+//	}
+package kafkapart
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+	"github.com/RiskyFeryansyahP/go-skeleton-rendezvous/hashes"
+)
+
+// Partitioner maps keys to partitions, the nodes of its skeletons are the
+// partitions. Every producer with the same options maps a key to the same
+// partition.
+type Partitioner struct {
+	options []rendezvous.Option
+
+	mu        sync.Mutex
+	skeletons map[int32]*rendezvous.SkeletonRendezvous
+}
+
+// NewPartitioner creates the partitioner, the options configure the
+// skeleton of each partition count. By default the keys are hashed with
+// xxhash64 and the partitions form a single cluster, so adding a partition
+// only moves the keys it takes, about 1/n of them, which are spread evenly.
+// The lookup then scores every partition, the options may set a smaller
+// cluster size to trade the evenness for the cost of topics with
+// thousands of partitions.
+func NewPartitioner(options ...rendezvous.Option) (*Partitioner, error) {
+	if _, err := rendezvous.NewSkeletonRendezvous(skeletonOptions(1, options)...); err != nil {
+		return nil, err
+	}
+
+	return &Partitioner{
+		options:   options,
+		skeletons: make(map[int32]*rendezvous.SkeletonRendezvous),
+	}, nil
+}
+
+// Partition returns the partition of the key among numPartitions.
+func (p *Partitioner) Partition(key []byte, numPartitions int32) (int32, error) {
+	sr, err := p.skeleton(numPartitions)
+
+	if err != nil {
+		return -1, err
+	}
+
+	node, err := sr.FindNode(string(key))
+
+	if err != nil {
+		return -1, err
+	}
+
+	partition, err := strconv.ParseInt(node, 10, 32)
+
+	if err != nil {
+		return -1, err
+	}
+
+	return int32(partition), nil
+}
+
+// RequiresConsistency reports that a key must always be sent to the same
+// partition.
+func (p *Partitioner) RequiresConsistency() bool {
+	return true
+}
+
+// skeleton returns the skeleton of the partition count, which is built once.
+func (p *Partitioner) skeleton(numPartitions int32) (*rendezvous.SkeletonRendezvous, error) {
+	if numPartitions < 1 {
+		return nil, fmt.Errorf("%w: partitions must be at least 1, got %d", rendezvous.ErrInvalidOption, numPartitions)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if sr, ok := p.skeletons[numPartitions]; ok {
+		return sr, nil
+	}
+
+	sr, err := rendezvous.NewSkeletonRendezvous(skeletonOptions(numPartitions, p.options)...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	partitions := make([]string, numPartitions)
+
+	for i := range partitions {
+		partitions[i] = strconv.Itoa(i)
+	}
+
+	sr.SetNodes(partitions)
+	p.skeletons[numPartitions] = sr
+
+	return sr, nil
+}
+
+// skeletonOptions returns the default options for the partition count
+// followed by the given options, which override them.
+func skeletonOptions(numPartitions int32, options []rendezvous.Option) []rendezvous.Option {
+	defaults := []rendezvous.Option{
+		hashes.XXHash64(),
+		rendezvous.ClusterSize(int(numPartitions)),
+		rendezvous.MinClusterSize(1),
+	}
+
+	return append(defaults, options...)
+}
@@ -0,0 +1,125 @@
+package kafkapart
+
+import (
+	"strconv"
+	"testing"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPartitioner(t *testing.T) {
+	t.Run("should map keys to partitions in range", func(t *testing.T) {
+		partitioner, err := NewPartitioner()
+
+		assert.NoError(t, err)
+		assert.True(t, partitioner.RequiresConsistency())
+
+		for i := 0; i < 100; i++ {
+			partition, err := partitioner.Partition([]byte("key-"+strconv.Itoa(i)), 12)
+
+			assert.NoError(t, err)
+			assert.GreaterOrEqual(t, partition, int32(0))
+			assert.Less(t, partition, int32(12))
+		}
+	})
+
+	t.Run("should map keys the same across partitioners", func(t *testing.T) {
+		a, err := NewPartitioner(rendezvous.FanOut(3))
+
+		assert.NoError(t, err)
+
+		b, err := NewPartitioner(rendezvous.FanOut(3))
+
+		assert.NoError(t, err)
+
+		for i := 0; i < 100; i++ {
+			key := []byte("key-" + strconv.Itoa(i))
+
+			partitionA, err := a.Partition(key, 8)
+
+			assert.NoError(t, err)
+
+			partitionB, err := b.Partition(key, 8)
+
+			assert.NoError(t, err)
+			assert.Equal(t, partitionA, partitionB)
+		}
+	})
+
+	t.Run("should spread keys over every partition", func(t *testing.T) {
+		partitioner, err := NewPartitioner()
+
+		assert.NoError(t, err)
+
+		keys := 10000
+
+		for partitions := int32(1); partitions <= 64; partitions++ {
+			counts := make([]int, partitions)
+
+			for i := 0; i < keys; i++ {
+				partition, err := partitioner.Partition([]byte("key-"+strconv.Itoa(i)), partitions)
+
+				assert.NoError(t, err)
+
+				counts[partition]++
+			}
+
+			mean := keys / int(partitions)
+
+			for partition, count := range counts {
+				assert.Greater(t, count, mean/2, "partition %d of %d", partition, partitions)
+				assert.Less(t, count, mean*3/2+10, "partition %d of %d", partition, partitions)
+			}
+		}
+	})
+
+	t.Run("should move only the keys of the added partition when growing", func(t *testing.T) {
+		partitioner, err := NewPartitioner()
+
+		assert.NoError(t, err)
+
+		keys := 5000
+
+		for partitions := int32(1); partitions < 64; partitions++ {
+			moved := 0
+
+			for i := 0; i < keys; i++ {
+				key := []byte("key-" + strconv.Itoa(i))
+
+				before, err := partitioner.Partition(key, partitions)
+
+				assert.NoError(t, err)
+
+				after, err := partitioner.Partition(key, partitions+1)
+
+				assert.NoError(t, err)
+
+				if before != after {
+					assert.Equal(t, partitions, after)
+
+					moved++
+				}
+			}
+
+			// about 1/n of the keys move, modulo moves nearly all of them
+			expected := float64(keys) / float64(partitions+1)
+
+			assert.InDelta(t, expected, float64(moved), expected/2+10, "growing to %d partitions", partitions+1)
+		}
+	})
+
+	t.Run("should return error for invalid partitions or options", func(t *testing.T) {
+		partitioner, err := NewPartitioner()
+
+		assert.NoError(t, err)
+
+		_, err = partitioner.Partition([]byte("key"), 0)
+
+		assert.ErrorIs(t, err, rendezvous.ErrInvalidOption)
+
+		_, err = NewPartitioner(rendezvous.FanOut(1))
+
+		assert.ErrorIs(t, err, rendezvous.ErrInvalidOption)
+	})
+}
@@ -0,0 +1,18 @@
+package rendezvous
+
+// KeysForNode returns the keys which are currently placed on the given
+// node, in the same order as the given keys.
+func (sr *SkeletonRendezvous) KeysForNode(node string, keys []string) []string {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+
+	nodeKeys := make([]string, 0)
+
+	for _, key := range keys {
+		if keyNode, err := sr.peekNode(key); err == nil && keyNode == node {
+			nodeKeys = append(nodeKeys, key)
+		}
+	}
+
+	return nodeKeys
+}
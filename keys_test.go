@@ -0,0 +1,58 @@
+package rendezvous
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeysForNode(t *testing.T) {
+	sr, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(2), MinClusterSize(2))
+
+	assert.NoError(t, err)
+
+	sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4", "jg5", "jg6"})
+
+	keys := make([]string, 0)
+
+	for i := 0; i < 300; i++ {
+		keys = append(keys, "key-"+strconv.Itoa(i))
+	}
+
+	t.Run("should partition keys by node in input order", func(t *testing.T) {
+		total := 0
+
+		for _, node := range sr.Nodes {
+			nodeKeys := sr.KeysForNode(node, keys)
+			total += len(nodeKeys)
+
+			previous := -1
+
+			for _, key := range nodeKeys {
+				assert.Equal(t, node, mustFindNode(t, sr, key))
+
+				index, _ := strconv.Atoi(key[len("key-"):])
+				assert.Greater(t, index, previous)
+				previous = index
+			}
+		}
+
+		assert.Equal(t, len(keys), total)
+	})
+
+	t.Run("should return no keys for unknown node", func(t *testing.T) {
+		assert.Empty(t, sr.KeysForNode("unknown", keys))
+	})
+
+	t.Run("should hand keys of an excluded node to the fallback node", func(t *testing.T) {
+		down := map[string]struct{}{"jg1": {}}
+
+		for _, key := range sr.KeysForNode("jg1", keys) {
+			node, err := sr.FindNodeExcluding(key, down)
+
+			assert.NoError(t, err)
+			assert.NotEqual(t, "jg1", node)
+		}
+	})
+}
@@ -0,0 +1,203 @@
+// Package lease grants single writer leases on the keys owned by the local
+// node of a skeleton rendezvous. A lease carries a fencing token taken from
+// a source shared by every node, such as the revision of the key in the
+// storage, so the storage behind the key can reject the writes of a
+// previous owner holding a lower token:
+//
+//	l := lease.New(sr, "node-1", func(key string) (uint64, error) {
+//		return store.NextRevision(key)
+//	})
+//
+//	granted, err := l.Claim("user-1")
+//
+//	if err != nil {
+//		return err // the key is owned by another node
+//	}
+//
+//	defer l.Release("user-1")
+//
+//	store.Write("user-1", value, granted.Token)
+//
+// A lease is lost as soon as the key moves to another node, Check reports
+// whether a lease is still held before acting on it.
+package lease
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+)
+
+var (
+	// ErrNotOwner is returned when the key is owned by another node
+	ErrNotOwner = errors.New("lease: not the owner of the key")
+
+	// ErrLeaseLost is returned when the lease was released, replaced by a
+	// newer lease or its key moved to another node
+	ErrLeaseLost = errors.New("lease: lease lost")
+)
+
+// Lease is the right of the local node to write a key.
+type Lease struct {
+	// Key is the leased key
+	Key string
+
+	// Token is the fencing token of the lease, taken from the token source
+	Token uint64
+
+	// Epoch is the epoch of the topology the lease was granted in
+	Epoch uint64
+}
+
+// TokenSource returns the fencing token of a new lease on the key. The
+// tokens of a key must increase with every lease granted by any node, so
+// they come from a source shared by the nodes, such as a revision or a
+// counter of the storage, and never from the local process.
+type TokenSource func(key string) (uint64, error)
+
+// Lessor grants the leases of the local node.
+type Lessor struct {
+	skeleton *rendezvous.SkeletonRendezvous
+	self     string
+	tokens   TokenSource
+
+	mu     sync.Mutex
+	leases map[string]Lease
+}
+
+// New creates the lessor of the local node self, the fencing tokens of its
+// leases are taken from tokens.
+func New(sr *rendezvous.SkeletonRendezvous, self string, tokens TokenSource) *Lessor {
+	return &Lessor{
+		skeleton: sr,
+		self:     self,
+		tokens:   tokens,
+		leases:   make(map[string]Lease),
+	}
+}
+
+// Claim grants a lease on the key when the local node owns it. Claiming a
+// held lease returns it again as long as the topology did not change,
+// otherwise a lease with a new token replaces it. The token source is
+// called while the lessor is locked, its error is returned as is.
+func (l *Lessor) Claim(key string) (Lease, error) {
+	if key == "" {
+		return Lease{}, rendezvous.ErrEmptyKey
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	epoch, err := l.owned(key)
+
+	if err != nil {
+		delete(l.leases, key)
+
+		return Lease{}, err
+	}
+
+	if lease, ok := l.leases[key]; ok && lease.Epoch == epoch {
+		return lease, nil
+	}
+
+	token, err := l.tokens(key)
+
+	if err != nil {
+		delete(l.leases, key)
+
+		return Lease{}, err
+	}
+
+	lease := Lease{Key: key, Token: token, Epoch: epoch}
+	l.leases[key] = lease
+
+	return lease, nil
+}
+
+// Release gives up the lease on the key, if any.
+func (l *Lessor) Release(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.leases, key)
+}
+
+// Check returns ErrLeaseLost when the lease is no longer held, the lease is
+// dropped when its key moved to another node.
+func (l *Lessor) Check(lease Lease) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if held, ok := l.leases[lease.Key]; !ok || held.Token != lease.Token {
+		return fmt.Errorf("%w: %s", ErrLeaseLost, lease.Key)
+	}
+
+	if _, err := l.owned(lease.Key); err != nil {
+		delete(l.leases, lease.Key)
+
+		return fmt.Errorf("%w: %v", ErrLeaseLost, err)
+	}
+
+	return nil
+}
+
+// Leases returns the held leases by key.
+func (l *Lessor) Leases() map[string]Lease {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	leases := make(map[string]Lease, len(l.leases))
+
+	for key, lease := range l.leases {
+		leases[key] = lease
+	}
+
+	return leases
+}
+
+// Run drops the leases of the keys moving to another node on every change of
+// the topology until the context is done, it returns the error of the
+// context. Without Run, leases are dropped when they are checked or claimed.
+func (l *Lessor) Run(ctx context.Context) error {
+	for range l.skeleton.WatchTopology(ctx) {
+		l.mu.Lock()
+
+		for key := range l.leases {
+			if _, err := l.owned(key); err != nil {
+				delete(l.leases, key)
+			}
+		}
+
+		l.mu.Unlock()
+	}
+
+	return ctx.Err()
+}
+
+// owned returns the epoch in which the local node owns the key, the caller
+// must hold the lock.
+func (l *Lessor) owned(key string) (uint64, error) {
+	for {
+		epoch := l.skeleton.Epoch()
+		owner, err := l.skeleton.FindNode(key)
+
+		if err != nil {
+			return 0, err
+		}
+
+		// the owner is only known for the epoch when the topology did not
+		// change during the lookup.
+		if l.skeleton.Epoch() != epoch {
+			continue
+		}
+
+		if owner != l.self {
+			return 0, fmt.Errorf("%w: %s is owned by %s", ErrNotOwner, key, owner)
+		}
+
+		return epoch, nil
+	}
+}
@@ -0,0 +1,267 @@
+package lease
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+	"github.com/stretchr/testify/assert"
+)
+
+// counter is a token source shared by the lessors of a test.
+func counter() TokenSource {
+	var token uint64
+
+	return func(key string) (uint64, error) {
+		return atomic.AddUint64(&token, 1), nil
+	}
+}
+
+// ownedKey finds a key owned by the node, which keeps the tests independent
+// of the hash.
+func ownedKey(t *testing.T, sr *rendezvous.SkeletonRendezvous, self string) string {
+	for i := 0; i < 1000; i++ {
+		key := "user-" + strconv.Itoa(i)
+
+		if owner, _ := sr.FindNode(key); owner == self {
+			return key
+		}
+	}
+
+	t.Fatalf("no key owned by %s", self)
+
+	return ""
+}
+
+func TestClaim(t *testing.T) {
+	t.Run("should grant a lease on an owned key", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		l := New(sr, "jg1", counter())
+		key := ownedKey(t, sr, "jg1")
+
+		lease, err := l.Claim(key)
+
+		assert.NoError(t, err)
+		assert.Equal(t, key, lease.Key)
+		assert.Equal(t, sr.Epoch(), lease.Epoch)
+		assert.NoError(t, l.Check(lease))
+		assert.Equal(t, map[string]Lease{key: lease}, l.Leases())
+	})
+
+	t.Run("should refuse a key owned by another node", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		l := New(sr, "jg1", counter())
+		key := ownedKey(t, sr, "jg1")
+		other := New(sr, "jg2", l.tokens)
+
+		_, err = other.Claim(key)
+
+		assert.ErrorIs(t, err, ErrNotOwner)
+		assert.Empty(t, other.Leases())
+	})
+
+	t.Run("should refuse the empty key", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		l := New(sr, "jg1", counter())
+
+		_, err = l.Claim("")
+
+		assert.ErrorIs(t, err, rendezvous.ErrEmptyKey)
+	})
+
+	t.Run("should return the held lease within an epoch", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		l := New(sr, "jg1", counter())
+		key := ownedKey(t, sr, "jg1")
+
+		first, err := l.Claim(key)
+		assert.NoError(t, err)
+
+		second, err := l.Claim(key)
+		assert.NoError(t, err)
+
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("should take the token of every lease from the source", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		l := New(sr, "jg1", counter())
+		key := ownedKey(t, sr, "jg1")
+
+		first, err := l.Claim(key)
+		assert.NoError(t, err)
+
+		l.Release(key)
+
+		second, err := l.Claim(key)
+		assert.NoError(t, err)
+
+		assert.Greater(t, second.Token, first.Token)
+
+		sr.AddNodes([]string{"jg5"})
+
+		if owner, _ := sr.FindNode(key); owner != "jg1" {
+			return
+		}
+
+		third, err := l.Claim(key)
+		assert.NoError(t, err)
+
+		assert.Greater(t, third.Token, second.Token)
+		assert.Greater(t, third.Epoch, second.Epoch)
+		assert.ErrorIs(t, l.Check(second), ErrLeaseLost)
+		assert.NoError(t, l.Check(third))
+	})
+
+	t.Run("should grant the next owner a greater token", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		l := New(sr, "jg1", counter())
+		key := ownedKey(t, sr, "jg1")
+
+		first, err := l.Claim(key)
+		assert.NoError(t, err)
+
+		sr.RemoveNodes([]string{"jg1"})
+
+		owner, err := sr.FindNode(key)
+		assert.NoError(t, err)
+
+		// the next owner has seen fewer changes of the topology
+		next, err := rendezvous.NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		next.SetNodes(sr.Nodes)
+
+		second, err := New(next, owner, l.tokens).Claim(key)
+		assert.NoError(t, err)
+
+		assert.LessOrEqual(t, second.Epoch, first.Epoch)
+		assert.Greater(t, second.Token, first.Token)
+	})
+
+	t.Run("should return the error of the token source", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		l := New(sr, "jg1", counter())
+		key := ownedKey(t, sr, "jg1")
+
+		unavailable := errors.New("store unavailable")
+
+		l.tokens = func(key string) (uint64, error) {
+			return 0, unavailable
+		}
+
+		_, err = l.Claim(key)
+
+		assert.ErrorIs(t, err, unavailable)
+		assert.Empty(t, l.Leases())
+	})
+}
+
+func TestRelease(t *testing.T) {
+	t.Run("should lose the released lease", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		l := New(sr, "jg1", counter())
+		key := ownedKey(t, sr, "jg1")
+
+		lease, err := l.Claim(key)
+		assert.NoError(t, err)
+
+		l.Release(key)
+
+		assert.ErrorIs(t, l.Check(lease), ErrLeaseLost)
+		assert.Empty(t, l.Leases())
+	})
+}
+
+func TestCheck(t *testing.T) {
+	t.Run("should lose the lease when the key moves", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		l := New(sr, "jg1", counter())
+		key := ownedKey(t, sr, "jg1")
+
+		lease, err := l.Claim(key)
+		assert.NoError(t, err)
+
+		sr.RemoveNodes([]string{"jg1"})
+
+		err = l.Check(lease)
+
+		assert.ErrorIs(t, err, ErrLeaseLost)
+		assert.Empty(t, l.Leases())
+	})
+}
+
+func TestRun(t *testing.T) {
+	t.Run("should drop the leases of moved keys", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		l := New(sr, "jg1", counter())
+		key := ownedKey(t, sr, "jg1")
+
+		_, err = l.Claim(key)
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+
+		go func() {
+			done <- l.Run(ctx)
+		}()
+
+		// the watch may start after the removal, changing the nodes until the
+		// lease is dropped keeps the test free of races.
+		assert.Eventually(t, func() bool {
+			sr.SyncNodes([]string{"jg2", "jg3", "jg4", "jg5"})
+			sr.SyncNodes([]string{"jg2", "jg3", "jg4"})
+
+			return len(l.Leases()) == 0
+		}, time.Second, time.Millisecond)
+
+		cancel()
+
+		assert.ErrorIs(t, <-done, context.Canceled)
+	})
+}
@@ -0,0 +1,102 @@
+package rendezvous
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// LogLevels are the levels the events of the skeleton are logged at.
+type LogLevels struct {
+	// Topology is the level of the changes of the nodes or the clusters
+	Topology slog.Level
+
+	// Overflow is the level of the branch positions mapped by the overflow
+	// policy after a change
+	Overflow slog.Level
+
+	// Warning is the level of the warnings about the options
+	Warning slog.Level
+
+	// Lookup is the level of the failed lookups
+	Lookup slog.Level
+}
+
+// DefaultLogLevels logs topology changes at info, overflow corrections at
+// debug, option warnings at warn and failed lookups at debug.
+var DefaultLogLevels = LogLevels{
+	Topology: slog.LevelInfo,
+	Overflow: slog.LevelDebug,
+	Warning:  slog.LevelWarn,
+	Lookup:   slog.LevelDebug,
+}
+
+// Logger logs the events of the skeleton with the default levels.
+func Logger(logger *slog.Logger) Option {
+	return LoggerLevels(logger, DefaultLogLevels)
+}
+
+// LoggerLevels logs the events of the skeleton at the given levels.
+func LoggerLevels(logger *slog.Logger, levels LogLevels) Option {
+	return func(o *Options) error {
+		if logger == nil {
+			return fmt.Errorf("%w: logger is nil", ErrInvalidOption)
+		}
+
+		return Observe(&logObserver{logger: logger, levels: levels})(o)
+	}
+}
+
+// logObserver logs the events it observes, it may be shared by skeletons
+// and keeps no state of its own.
+type logObserver struct {
+	logger *slog.Logger
+	levels LogLevels
+}
+
+func (l *logObserver) observeOptions(o Options, warnings []string) {
+	for _, warning := range warnings {
+		l.logger.Log(context.Background(), l.levels.Warning, "rendezvous: "+warning)
+	}
+}
+
+// observesLookups reports whether failed lookups are logged, otherwise the
+// lookups are not timed for the logger.
+func (l *logObserver) observesLookups() bool {
+	return l.logger.Enabled(context.Background(), l.levels.Lookup)
+}
+
+func (l *logObserver) ObserveLookup(event LookupEvent) {
+	if event.Err == nil {
+		return
+	}
+
+	l.logger.Log(context.Background(), l.levels.Lookup, "rendezvous: lookup failed",
+		slog.String("key", event.Key),
+		slog.Uint64("epoch", event.Epoch),
+		slog.Any("error", event.Err))
+}
+
+func (l *logObserver) ObserveTopology(event TopologyEvent, stats RingStats) {
+	ctx := context.Background()
+
+	l.logger.Log(ctx, l.levels.Topology, "rendezvous: topology changed",
+		slog.Uint64("epoch", event.Epoch),
+		slog.Any("nodes_added", event.NodesAdded),
+		slog.Any("nodes_removed", event.NodesRemoved),
+		slog.Bool("clusters_rebuilt", event.ClustersRebuilt),
+		slog.Int("nodes", stats.Nodes),
+		slog.Int("clusters", stats.Clusters),
+		slog.Int("virtual_nodes", stats.VirtualNodes))
+
+	if stats.Clusters == 0 || !l.logger.Enabled(ctx, l.levels.Overflow) {
+		return
+	}
+
+	if stats.Positions > stats.Clusters {
+		l.logger.Log(ctx, l.levels.Overflow, "rendezvous: branch positions mapped by the overflow policy",
+			slog.Uint64("epoch", event.Epoch),
+			slog.Int("positions", stats.Positions),
+			slog.Int("overflowing", stats.Positions-stats.Clusters))
+	}
+}
@@ -0,0 +1,101 @@
+package rendezvous
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestLogger(buffer *bytes.Buffer) *slog.Logger {
+	return slog.New(slog.NewTextHandler(buffer, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))
+}
+
+func TestLogger(t *testing.T) {
+	t.Run("should log topology changes and overflow", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+
+		sr, err := NewSkeletonRendezvous(Logger(newTestLogger(buffer)))
+		assert.NoError(t, err)
+
+		// 4 clusters take 2 levels of 3 branches, 5 positions overflow
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4", "jg5", "jg6", "jg7", "jg8"})
+
+		lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+
+		assert.Len(t, lines, 2)
+		assert.Contains(t, lines[0], `level=INFO msg="rendezvous: topology changed" epoch=1`)
+		assert.Contains(t, lines[0], "nodes=8 clusters=4 virtual_nodes=2")
+		assert.Contains(t, lines[1], "level=DEBUG")
+		assert.Contains(t, lines[1], "positions=9 overflowing=5")
+	})
+
+	t.Run("should log option warnings and failed lookups at the given levels", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+
+		levels := DefaultLogLevels
+		levels.Warning = slog.LevelError
+		levels.Lookup = slog.LevelWarn
+
+		sr, err := NewSkeletonRendezvous(LookupCache(16), BoundedLoad(0.25), LoggerLevels(newTestLogger(buffer), levels))
+		assert.NoError(t, err)
+
+		_, err = sr.FindNode("key-1")
+		assert.ErrorIs(t, err, ErrNoNodes)
+
+		lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+
+		assert.Len(t, lines, 2)
+		assert.Contains(t, lines[0], `level=ERROR msg="rendezvous: lookup cache is bypassed with bounded load"`)
+		assert.Contains(t, lines[1], `level=WARN msg="rendezvous: lookup failed" key=key-1`)
+	})
+
+	t.Run("should report the positions of each skeleton sharing the observer", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+
+		observer := &logObserver{logger: newTestLogger(buffer), levels: DefaultLogLevels}
+
+		binary, err := NewSkeletonRendezvous(FanOut(2), Observe(observer))
+		assert.NoError(t, err)
+
+		_, err = NewSkeletonRendezvous(FanOut(3), Observe(observer))
+		assert.NoError(t, err)
+
+		// 4 clusters take 2 levels of 2 branches, no position overflows
+		binary.SetNodes([]string{"jg1", "jg2", "jg3", "jg4", "jg5", "jg6", "jg7", "jg8"})
+
+		lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+
+		assert.Len(t, lines, 1)
+		assert.Contains(t, lines[0], "nodes=8 clusters=4 virtual_nodes=2")
+	})
+
+	t.Run("should not observe lookups when failed lookups are not logged", func(t *testing.T) {
+		logger := slog.New(slog.NewTextHandler(&bytes.Buffer{}, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+		sr, err := NewSkeletonRendezvous(Logger(logger))
+		assert.NoError(t, err)
+		assert.Empty(t, sr.lookupObservers)
+
+		sr, err = NewSkeletonRendezvous(Logger(newTestLogger(&bytes.Buffer{})))
+		assert.NoError(t, err)
+		assert.Len(t, sr.lookupObservers, 1)
+	})
+
+	t.Run("should reject nil logger", func(t *testing.T) {
+		_, err := NewSkeletonRendezvous(Logger(nil))
+
+		assert.ErrorIs(t, err, ErrInvalidOption)
+	})
+}
@@ -0,0 +1,209 @@
+package rendezvous
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Manager holds independent skeletons by name, such as one per tenant,
+// table or topic, created with shared default options.
+type Manager struct {
+	defaults []Option
+
+	mu       sync.RWMutex
+	rings    map[string]*SkeletonRendezvous
+	watchers []*eventQueue[NamespaceEvent]
+}
+
+// NamespaceEvent is a topology event of the skeleton with the name.
+type NamespaceEvent struct {
+	Name string
+	TopologyEvent
+}
+
+// NewManager creates a manager whose skeletons are created with the default
+// options. Options publishing global state such as Expvar must not be given
+// as defaults, since they are applied to every skeleton.
+func NewManager(defaults ...Option) *Manager {
+	return &Manager{
+		defaults: append([]Option(nil), defaults...),
+		rings:    make(map[string]*SkeletonRendezvous),
+	}
+}
+
+// Ring returns the skeleton with the name, creating it with the default
+// options followed by the given options when it does not exist. The given
+// options are ignored for an existing skeleton.
+func (m *Manager) Ring(name string, options ...Option) (*SkeletonRendezvous, error) {
+	if name == "" {
+		return nil, fmt.Errorf("%w: namespace is empty", ErrInvalidOption)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if sr, ok := m.rings[name]; ok {
+		return sr, nil
+	}
+
+	observer := &namespaceObserver{manager: m, name: name}
+
+	opts := append(append(append(make([]Option, 0, len(m.defaults)+len(options)+1), m.defaults...), options...), Observe(observer))
+
+	sr, err := NewSkeletonRendezvous(opts...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	observer.ring = sr
+	m.rings[name] = sr
+
+	return sr, nil
+}
+
+// Get returns the skeleton with the name, false when it does not exist.
+func (m *Manager) Get(name string) (*SkeletonRendezvous, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	sr, ok := m.rings[name]
+
+	return sr, ok
+}
+
+// Remove removes the skeleton with the name, its changes are no longer
+// delivered to the watchers. It returns false when it does not exist.
+func (m *Manager) Remove(name string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, ok := m.rings[name]
+	delete(m.rings, name)
+
+	return ok
+}
+
+// Names returns the names of the skeletons in order.
+func (m *Manager) Names() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	names := make([]string, 0, len(m.rings))
+
+	for name := range m.rings {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// MarshalJSON encodes the snapshots of every skeleton by name.
+func (m *Manager) MarshalJSON() ([]byte, error) {
+	m.mu.RLock()
+	rings := make(map[string]*SkeletonRendezvous, len(m.rings))
+
+	for name, sr := range m.rings {
+		rings[name] = sr
+	}
+
+	m.mu.RUnlock()
+
+	return json.Marshal(rings)
+}
+
+// UnmarshalJSON restores the skeletons encoded by MarshalJSON, missing
+// skeletons are created with the default options. Skeletons which are not
+// in the snapshots are kept.
+func (m *Manager) UnmarshalJSON(data []byte) error {
+	var snapshots map[string]json.RawMessage
+
+	if err := json.Unmarshal(data, &snapshots); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(snapshots))
+
+	for name := range snapshots {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		sr, err := m.Ring(name)
+
+		if err != nil {
+			return err
+		}
+
+		if err := sr.UnmarshalJSON(snapshots[name]); err != nil {
+			return fmt.Errorf("namespace %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// Watch returns a channel receiving the topology events of every skeleton
+// along with its name, like WatchTopology. The channel is closed once the
+// context is done.
+func (m *Manager) Watch(ctx context.Context) <-chan NamespaceEvent {
+	watcher := newEventQueue[NamespaceEvent]()
+
+	m.mu.Lock()
+	m.watchers = append(m.watchers, watcher)
+	m.mu.Unlock()
+
+	go watcher.run(ctx, func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		for i, w := range m.watchers {
+			if w == watcher {
+				m.watchers = append(m.watchers[:i:i], m.watchers[i+1:]...)
+
+				break
+			}
+		}
+	})
+
+	return watcher.events
+}
+
+// namespaceObserver forwards the topology events of a skeleton to the
+// watchers of its manager, as long as the skeleton is not removed.
+type namespaceObserver struct {
+	manager *Manager
+	name    string
+	ring    *SkeletonRendezvous
+}
+
+func (o *namespaceObserver) ObserveLookup(event LookupEvent) {}
+
+func (o *namespaceObserver) observesLookups() bool {
+	return false
+}
+
+func (o *namespaceObserver) ObserveTopology(event TopologyEvent, stats RingStats) {
+	o.manager.mu.RLock()
+
+	if o.manager.rings[o.name] != o.ring {
+		o.manager.mu.RUnlock()
+
+		return
+	}
+
+	watchers := append([]*eventQueue[NamespaceEvent](nil), o.manager.watchers...)
+
+	o.manager.mu.RUnlock()
+
+	for _, watcher := range watchers {
+		watcher.send(NamespaceEvent{Name: o.name, TopologyEvent: event})
+	}
+}
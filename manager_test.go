@@ -0,0 +1,131 @@
+package rendezvous
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManager(t *testing.T) {
+	t.Run("should create rings with the default options", func(t *testing.T) {
+		m := NewManager(ClusterSize(3), MinClusterSize(3))
+
+		tenant, err := m.Ring("tenant")
+		assert.NoError(t, err)
+
+		again, err := m.Ring("tenant")
+		assert.NoError(t, err)
+		assert.Same(t, tenant, again)
+
+		topic, err := m.Ring("topic", ClusterSize(1), MinClusterSize(1))
+		assert.NoError(t, err)
+
+		tenant.SetNodes([]string{"jg1", "jg2", "jg3"})
+		topic.SetNodes([]string{"jg1", "jg2", "jg3"})
+
+		assert.Len(t, tenant.Clusters, 1)
+		assert.Len(t, topic.Clusters, 3)
+		assert.Equal(t, []string{"tenant", "topic"}, m.Names())
+
+		sr, ok := m.Get("topic")
+
+		assert.True(t, ok)
+		assert.Same(t, topic, sr)
+		assert.True(t, m.Remove("topic"))
+		assert.False(t, m.Remove("topic"))
+
+		_, ok = m.Get("topic")
+
+		assert.False(t, ok)
+	})
+
+	t.Run("should stream the topology events of every ring", func(t *testing.T) {
+		m := NewManager()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		events := m.Watch(ctx)
+
+		tenant, err := m.Ring("tenant")
+		assert.NoError(t, err)
+
+		topic, err := m.Ring("topic")
+		assert.NoError(t, err)
+
+		tenant.SetNodes([]string{"jg1", "jg2"})
+		topic.SetNodes([]string{"jg3"})
+
+		event := <-events
+
+		assert.Equal(t, "tenant", event.Name)
+		assert.Equal(t, []string{"jg1", "jg2"}, event.NodesAdded)
+
+		event = <-events
+
+		assert.Equal(t, "topic", event.Name)
+		assert.Equal(t, []string{"jg3"}, event.NodesAdded)
+
+		m.Remove("topic")
+		topic.SetNodes([]string{"jg4"})
+		tenant.RemoveNodes([]string{"jg2"})
+
+		event = <-events
+
+		assert.Equal(t, "tenant", event.Name)
+		assert.Equal(t, []string{"jg2"}, event.NodesRemoved)
+
+		cancel()
+
+		for range events {
+		}
+	})
+
+	t.Run("should snapshot and restore every ring", func(t *testing.T) {
+		m := NewManager()
+
+		tenant, err := m.Ring("tenant")
+		assert.NoError(t, err)
+
+		topic, err := m.Ring("topic", FanOut(4))
+		assert.NoError(t, err)
+
+		tenant.SetNodes([]string{"jg1", "jg2"})
+		topic.SetNodes([]string{"jg3", "jg4", "jg5"})
+
+		data, err := m.MarshalJSON()
+		assert.NoError(t, err)
+
+		restored := NewManager()
+
+		assert.NoError(t, restored.UnmarshalJSON(data))
+		assert.Equal(t, m.Names(), restored.Names())
+
+		for _, name := range m.Names() {
+			sr, _ := m.Get(name)
+			other, _ := restored.Get(name)
+
+			assert.True(t, sr.Equal(other))
+		}
+	})
+
+	t.Run("should not time lookups for the manager", func(t *testing.T) {
+		m := NewManager()
+
+		sr, err := m.Ring("tenant")
+		assert.NoError(t, err)
+
+		assert.Len(t, sr.observers, 1)
+		assert.Empty(t, sr.lookupObservers)
+	})
+
+	t.Run("should reject empty name and invalid options", func(t *testing.T) {
+		m := NewManager(FanOut(1))
+
+		_, err := m.Ring("")
+		assert.ErrorIs(t, err, ErrInvalidOption)
+
+		_, err = m.Ring("tenant")
+		assert.ErrorIs(t, err, ErrInvalidOption)
+		assert.Empty(t, m.Names())
+	})
+}
@@ -0,0 +1,65 @@
+//go:build memberlist
+
+package membership
+
+import (
+	"github.com/hashicorp/memberlist"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+)
+
+// Join creates the memberlist with the config and joins the existing
+// members, the nodes of the skeleton are then kept in sync with the names of
+// the members. An event delegate already set in the config keeps being
+// notified, the config itself is not modified.
+func Join(sr *rendezvous.SkeletonRendezvous, config *memberlist.Config, existing []string) (*memberlist.Memberlist, error) {
+	delegate := NewEventDelegate(sr, func(node *memberlist.Node) string {
+		return node.Name
+	})
+
+	joined := *config
+	joined.Events = delegate
+
+	if config.Events != nil {
+		joined.Events = events{delegate, config.Events}
+	}
+
+	list, err := memberlist.Create(&joined)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(existing) > 0 {
+		if _, err := list.Join(existing); err != nil {
+			list.Shutdown()
+
+			return nil, err
+		}
+	}
+
+	delegate.Sync(list.Members())
+
+	return list, nil
+}
+
+// events notifies every delegate in order.
+type events []memberlist.EventDelegate
+
+func (e events) NotifyJoin(node *memberlist.Node) {
+	for _, delegate := range e {
+		delegate.NotifyJoin(node)
+	}
+}
+
+func (e events) NotifyLeave(node *memberlist.Node) {
+	for _, delegate := range e {
+		delegate.NotifyLeave(node)
+	}
+}
+
+func (e events) NotifyUpdate(node *memberlist.Node) {
+	for _, delegate := range e {
+		delegate.NotifyUpdate(node)
+	}
+}
@@ -0,0 +1,70 @@
+//go:build memberlist
+
+package membership
+
+import (
+	"testing"
+	"time"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+	"github.com/hashicorp/memberlist"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJoin(t *testing.T) {
+	config := func(name string) *memberlist.Config {
+		config := memberlist.DefaultLocalConfig()
+		config.Name = name
+		config.BindAddr = "127.0.0.1"
+		config.BindPort = 0
+
+		return config
+	}
+
+	t.Run("should keep the nodes in sync with the members", func(t *testing.T) {
+		first, err := rendezvous.NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		firstList, err := Join(first, config("jg1"), nil)
+		assert.NoError(t, err)
+
+		defer firstList.Shutdown()
+
+		second, err := rendezvous.NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		secondList, err := Join(second, config("jg2"), []string{firstList.LocalNode().Address()})
+		assert.NoError(t, err)
+
+		assert.Equal(t, 2, second.Stats().Nodes)
+
+		assert.Eventually(t, func() bool {
+			return first.Stats().Nodes == 2
+		}, 5*time.Second, 10*time.Millisecond)
+
+		assert.NoError(t, secondList.Leave(time.Second))
+		assert.NoError(t, secondList.Shutdown())
+
+		assert.Eventually(t, func() bool {
+			return first.Stats().Nodes == 1
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("should notify the event delegate of the config", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		events := make(chan memberlist.NodeEvent, 1)
+
+		joined := config("jg1")
+		joined.Events = &memberlist.ChannelEventDelegate{Ch: events}
+
+		list, err := Join(sr, joined, nil)
+		assert.NoError(t, err)
+
+		defer list.Shutdown()
+
+		assert.Equal(t, "jg1", (<-events).Node.Name)
+		assert.Equal(t, 1, sr.Stats().Nodes)
+	})
+}
@@ -0,0 +1,66 @@
+// Package membership keeps the nodes of a skeleton rendezvous in sync with
+// a gossip membership, such as hashicorp/memberlist. The delegate is
+// instantiated with the node type of the membership:
+//
+//	delegate := membership.NewEventDelegate(sr, func(node *memberlist.Node) string {
+//		return node.Name
+//	})
+//
+//	config := memberlist.DefaultLANConfig()
+//	config.Events = delegate
+//
+//	list, err := memberlist.Create(config)
+//	...
+//	delegate.Sync(list.Members())
+//
+// Built with the memberlist tag in a module requiring
+// github.com/hashicorp/memberlist, Join does the same and joins the
+// existing members:
+//
+//	list, err := membership.Join(sr, memberlist.DefaultLANConfig(), []string{"10.0.0.1:7946"})
+package membership
+
+import rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+
+// EventDelegate adds the nodes which join the membership to the skeleton
+// and removes the nodes which leave or are declared dead. Its methods match
+// the memberlist EventDelegate interface when N is *memberlist.Node.
+type EventDelegate[N any] struct {
+	skeleton *rendezvous.SkeletonRendezvous
+	name     func(N) string
+}
+
+// NewEventDelegate creates the delegate updating the skeleton, name returns
+// the ID of a member used as node in the skeleton.
+func NewEventDelegate[N any](sr *rendezvous.SkeletonRendezvous, name func(N) string) *EventDelegate[N] {
+	return &EventDelegate[N]{
+		skeleton: sr,
+		name:     name,
+	}
+}
+
+// NotifyJoin adds the member which joined to the skeleton.
+func (d *EventDelegate[N]) NotifyJoin(node N) {
+	d.skeleton.AddNodes([]string{d.name(node)})
+}
+
+// NotifyLeave removes the member which left or is declared dead.
+func (d *EventDelegate[N]) NotifyLeave(node N) {
+	d.skeleton.RemoveNodes([]string{d.name(node)})
+}
+
+// NotifyUpdate is called when the metadata of a member changes, it does not
+// change the skeleton.
+func (d *EventDelegate[N]) NotifyUpdate(node N) {}
+
+// Sync makes the nodes of the skeleton match the members, it is used once
+// joined since members known before the delegate is set are not notified.
+func (d *EventDelegate[N]) Sync(members []N) {
+	nodes := make([]string, 0, len(members))
+
+	for _, member := range members {
+		nodes = append(nodes, d.name(member))
+	}
+
+	d.skeleton.SyncNodes(nodes)
+}
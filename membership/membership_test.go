@@ -0,0 +1,57 @@
+package membership
+
+import (
+	"testing"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+	"github.com/stretchr/testify/assert"
+)
+
+// member mirrors the shape of a gossip member.
+type member struct {
+	Name string
+}
+
+// eventDelegate is the memberlist EventDelegate interface.
+type eventDelegate interface {
+	NotifyJoin(*member)
+	NotifyLeave(*member)
+	NotifyUpdate(*member)
+}
+
+func TestEventDelegate(t *testing.T) {
+	t.Run("should track members joining and leaving", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		var delegate eventDelegate = NewEventDelegate(sr, func(m *member) string {
+			return m.Name
+		})
+
+		delegate.NotifyJoin(&member{Name: "jg1"})
+		delegate.NotifyJoin(&member{Name: "jg2"})
+		delegate.NotifyJoin(&member{Name: "jg3"})
+		delegate.NotifyUpdate(&member{Name: "jg3"})
+		delegate.NotifyLeave(&member{Name: "jg2"})
+
+		assert.Equal(t, []string{"jg1", "jg3"}, sr.Nodes)
+		assert.NoError(t, sr.Validate())
+	})
+
+	t.Run("should sync nodes with current members", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		delegate := NewEventDelegate(sr, func(m *member) string {
+			return m.Name
+		})
+
+		sr.SetNodes([]string{"jg1", "jg2"})
+
+		delegate.Sync([]*member{{Name: "jg2"}, {Name: "jg3"}})
+
+		assert.ElementsMatch(t, []string{"jg2", "jg3"}, sr.Nodes)
+	})
+}
@@ -0,0 +1,105 @@
+// Package memcache selects the memcached server of a key with the skeleton
+// rendezvous. ServerSelector satisfies the ServerSelector interface of
+// gomemcache, which only uses net.Addr:
+//
+//	selector := memcache.NewServerSelector(sr)
+//	err := selector.SetServers("10.0.0.1:11211", "10.0.0.2:11211")
+//	...
+//	client := gomemcache.NewFromSelector(selector)
+package memcache
+
+import (
+	"net"
+	"strings"
+	"sync"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+)
+
+// ServerSelector picks the server owning a key among the nodes of the
+// skeleton, the servers marked down or draining in the skeleton are not
+// picked.
+type ServerSelector struct {
+	skeleton *rendezvous.SkeletonRendezvous
+
+	mu      sync.RWMutex
+	servers []string
+	addrs   map[string]net.Addr
+}
+
+// NewServerSelector creates the selector over the skeleton.
+func NewServerSelector(sr *rendezvous.SkeletonRendezvous) *ServerSelector {
+	return &ServerSelector{
+		skeleton: sr,
+		addrs:    make(map[string]net.Addr),
+	}
+}
+
+// SetServers replaces the servers, a server is a host:port or the path of a
+// unix socket when it contains a slash. Only the servers joining and
+// leaving move in the skeleton.
+func (s *ServerSelector) SetServers(servers ...string) error {
+	addrs := make(map[string]net.Addr, len(servers))
+
+	for _, server := range servers {
+		addr, err := resolve(server)
+
+		if err != nil {
+			return err
+		}
+
+		addrs[server] = addr
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.servers = append([]string(nil), servers...)
+	s.addrs = addrs
+	s.skeleton.SyncNodes(servers)
+
+	return nil
+}
+
+// PickServer returns the address of the server owning the key.
+func (s *ServerSelector) PickServer(key string) (net.Addr, error) {
+	node, err := s.skeleton.FindNode(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	addr, ok := s.addrs[node]
+
+	if !ok {
+		return nil, &net.AddrError{Err: "unknown memcached server", Addr: node}
+	}
+
+	return addr, nil
+}
+
+// Each calls f with the address of each server in the order they were set,
+// it stops at the first error.
+func (s *ServerSelector) Each(f func(net.Addr) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, server := range s.servers {
+		if err := f(s.addrs[server]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resolve(server string) (net.Addr, error) {
+	if strings.Contains(server, "/") {
+		return net.ResolveUnixAddr("unix", server)
+	}
+
+	return net.ResolveTCPAddr("tcp", server)
+}
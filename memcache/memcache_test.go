@@ -0,0 +1,94 @@
+package memcache
+
+import (
+	"net"
+	"testing"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+	"github.com/stretchr/testify/assert"
+)
+
+// serverSelector is the gomemcache ServerSelector interface.
+type serverSelector interface {
+	PickServer(key string) (net.Addr, error)
+	Each(func(net.Addr) error) error
+}
+
+func TestServerSelector(t *testing.T) {
+	servers := []string{"127.0.0.1:11211", "127.0.0.2:11211", "/tmp/memcached.sock"}
+
+	t.Run("should pick the server owning the key", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		selector := NewServerSelector(sr)
+
+		var _ serverSelector = selector
+
+		assert.NoError(t, selector.SetServers(servers...))
+
+		for _, key := range []string{"a", "b", "c", "d"} {
+			node, err := sr.FindNode(key)
+
+			assert.NoError(t, err)
+
+			addr, err := selector.PickServer(key)
+
+			assert.NoError(t, err)
+			assert.Equal(t, node, addr.String())
+		}
+	})
+
+	t.Run("should not pick servers marked down", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		selector := NewServerSelector(sr)
+
+		assert.NoError(t, selector.SetServers(servers...))
+
+		sr.MarkDown(servers[0], servers[2])
+
+		for _, key := range []string{"a", "b", "c", "d"} {
+			addr, err := selector.PickServer(key)
+
+			assert.NoError(t, err)
+			assert.Equal(t, servers[1], addr.String())
+		}
+	})
+
+	t.Run("should iterate servers in order", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		selector := NewServerSelector(sr)
+
+		assert.NoError(t, selector.SetServers(servers...))
+
+		var addrs []string
+
+		err = selector.Each(func(addr net.Addr) error {
+			addrs = append(addrs, addr.Network()+":"+addr.String())
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"tcp:127.0.0.1:11211", "tcp:127.0.0.2:11211", "unix:/tmp/memcached.sock"}, addrs)
+	})
+
+	t.Run("should return error without servers or for invalid server", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		selector := NewServerSelector(sr)
+
+		_, err = selector.PickServer("a")
+
+		assert.ErrorIs(t, err, rendezvous.ErrNoNodes)
+		assert.Error(t, selector.SetServers("127.0.0.1:port"))
+	})
+}
@@ -0,0 +1,139 @@
+package rendezvous
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Migration is a move of keys from one node to another.
+type Migration struct {
+	Move
+
+	// Keys are the moving keys among the planned keys, in their order
+	Keys []string
+
+	// EstimatedKeys is the estimated number of moving keys in the whole
+	// keyspace, the number of keys when the planned keys are the keyspace
+	EstimatedKeys int
+}
+
+// MigrationProgress is the progress of a migration plan.
+type MigrationProgress struct {
+	// Migrations is the number of migrations of the plan
+	Migrations int
+
+	// Acknowledged is the number of acknowledged migrations
+	Acknowledged int
+
+	// EstimatedKeys is the estimated number of moving keys of the plan
+	EstimatedKeys int
+
+	// AcknowledgedKeys is the estimated number of keys of the acknowledged
+	// migrations
+	AcknowledgedKeys int
+}
+
+// Done reports whether every migration is acknowledged.
+func (p MigrationProgress) Done() bool {
+	return p.Acknowledged == p.Migrations
+}
+
+// MigrationPlan is the ordered migrations between two views, tracking which
+// of them are acknowledged. It is safe for concurrent use.
+type MigrationPlan struct {
+	migrations []Migration
+
+	mu           sync.Mutex
+	acknowledged map[Move]bool
+}
+
+// NewMigrationPlan plans the migrations of the keys from the old view to the
+// new one, the largest migration first. The keys may be a sample of a
+// keyspace of the given size, the number of moving keys is then scaled to
+// the keyspace, a keyspace not larger than the keys is the keys themselves.
+func NewMigrationPlan(oldView *View, newView *View, keys []string, keyspace int) *MigrationPlan {
+	plan := &MigrationPlan{acknowledged: make(map[Move]bool)}
+
+	for move, moved := range Diff(oldView.sr, newView.sr, keys) {
+		estimated := len(moved)
+
+		if keyspace > len(keys) {
+			estimated = int(float64(len(moved))*float64(keyspace)/float64(len(keys)) + 0.5)
+		}
+
+		plan.migrations = append(plan.migrations, Migration{Move: move, Keys: moved, EstimatedKeys: estimated})
+	}
+
+	sort.Slice(plan.migrations, func(i, j int) bool {
+		a, b := plan.migrations[i], plan.migrations[j]
+
+		if len(a.Keys) != len(b.Keys) {
+			return len(a.Keys) > len(b.Keys)
+		}
+
+		if a.From != b.From {
+			return a.From < b.From
+		}
+
+		return a.To < b.To
+	})
+
+	return plan
+}
+
+// Migrations returns the migrations of the plan in order.
+func (p *MigrationPlan) Migrations() []Migration {
+	return append(make([]Migration, 0, len(p.migrations)), p.migrations...)
+}
+
+// Pending returns the migrations which are not acknowledged yet, in order.
+func (p *MigrationPlan) Pending() []Migration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pending := make([]Migration, 0, len(p.migrations)-len(p.acknowledged))
+
+	for _, migration := range p.migrations {
+		if !p.acknowledged[migration.Move] {
+			pending = append(pending, migration)
+		}
+	}
+
+	return pending
+}
+
+// Acknowledge marks the migration of the move as done, acknowledging it
+// again has no effect. It returns ErrUnknownMigration when the plan has no
+// such move.
+func (p *MigrationPlan) Acknowledge(move Move) error {
+	for _, migration := range p.migrations {
+		if migration.Move == move {
+			p.mu.Lock()
+			p.acknowledged[move] = true
+			p.mu.Unlock()
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: from %q to %q", ErrUnknownMigration, move.From, move.To)
+}
+
+// Progress returns the progress of the plan.
+func (p *MigrationPlan) Progress() MigrationProgress {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	progress := MigrationProgress{Migrations: len(p.migrations), Acknowledged: len(p.acknowledged)}
+
+	for _, migration := range p.migrations {
+		progress.EstimatedKeys += migration.EstimatedKeys
+
+		if p.acknowledged[migration.Move] {
+			progress.AcknowledgedKeys += migration.EstimatedKeys
+		}
+	}
+
+	return progress
+}
@@ -0,0 +1,98 @@
+package rendezvous
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrationPlan(t *testing.T) {
+	keys := make([]string, 1000)
+
+	for i := range keys {
+		keys[i] = "key-" + strconv.Itoa(i)
+	}
+
+	sr, err := NewSkeletonRendezvous()
+	assert.NoError(t, err)
+
+	sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4", "jg5", "jg6"})
+
+	oldView := sr.Snapshot()
+
+	sr.RemoveNodes([]string{"jg3"})
+
+	newView := sr.Snapshot()
+
+	t.Run("should plan the moves of the keys largest first", func(t *testing.T) {
+		plan := NewMigrationPlan(oldView, newView, keys, 0)
+		migrations := plan.Migrations()
+		moves := Diff(oldView.sr, newView.sr, keys)
+
+		assert.Len(t, migrations, len(moves))
+
+		for i, migration := range migrations {
+			assert.Equal(t, moves[migration.Move], migration.Keys)
+			assert.Equal(t, len(migration.Keys), migration.EstimatedKeys)
+
+			if i > 0 {
+				assert.LessOrEqual(t, len(migration.Keys), len(migrations[i-1].Keys))
+			}
+
+			for _, key := range migration.Keys {
+				oldNode, _ := oldView.FindNode(key)
+				newNode, _ := newView.FindNode(key)
+
+				assert.Equal(t, Move{From: oldNode, To: newNode}, migration.Move)
+			}
+		}
+	})
+
+	t.Run("should scale a sample to the keyspace", func(t *testing.T) {
+		plan := NewMigrationPlan(oldView, newView, keys, 100000)
+
+		for _, migration := range plan.Migrations() {
+			assert.Equal(t, len(migration.Keys)*100, migration.EstimatedKeys)
+		}
+	})
+
+	t.Run("should track the acknowledged migrations", func(t *testing.T) {
+		plan := NewMigrationPlan(oldView, newView, keys, 0)
+		migrations := plan.Migrations()
+
+		assert.NotEmpty(t, migrations)
+		assert.False(t, plan.Progress().Done())
+
+		assert.NoError(t, plan.Acknowledge(migrations[0].Move))
+		assert.NoError(t, plan.Acknowledge(migrations[0].Move))
+
+		progress := plan.Progress()
+
+		assert.Equal(t, len(migrations), progress.Migrations)
+		assert.Equal(t, 1, progress.Acknowledged)
+		assert.Equal(t, migrations[0].EstimatedKeys, progress.AcknowledgedKeys)
+		assert.Equal(t, migrations[1:], plan.Pending())
+
+		for _, migration := range migrations[1:] {
+			assert.NoError(t, plan.Acknowledge(migration.Move))
+		}
+
+		assert.True(t, plan.Progress().Done())
+		assert.Empty(t, plan.Pending())
+	})
+
+	t.Run("should return an error for an unknown move", func(t *testing.T) {
+		plan := NewMigrationPlan(oldView, newView, keys, 0)
+
+		assert.ErrorIs(t, plan.Acknowledge(Move{From: "jg1", To: "jg9"}), ErrUnknownMigration)
+		assert.Equal(t, 0, plan.Progress().Acknowledged)
+	})
+
+	t.Run("should plan nothing between equal views", func(t *testing.T) {
+		plan := NewMigrationPlan(newView, newView, keys, 0)
+
+		assert.Empty(t, plan.Migrations())
+		assert.True(t, plan.Progress().Done())
+	})
+}
@@ -0,0 +1,134 @@
+package rendezvous
+
+// Node is a member of the skeleton along with its metadata, nodes are
+// identified by ID which is the value used in Clusters and Nodes.
+type Node struct {
+	// ID identifies the node
+	ID string `json:"id"`
+
+	// Addr is the address to reach the node
+	Addr string `json:"addr,omitempty"`
+
+	// Weight is the capacity of the node relative to other nodes,
+	// zero means the default weight 1
+	Weight float64 `json:"weight,omitempty"`
+
+	// Labels are arbitrary attributes of the node, such as zone or tags
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// SetNodeList set new nodes along with their metadata into cluster, like
+// SetNodes which is the same as SetNodeList with nodes carrying only ID.
+func (sr *SkeletonRendezvous) SetNodeList(nodes []Node) {
+	sr.updateTopology(func() {
+		sr.setNodeList(nodes)
+	})
+}
+
+func (sr *SkeletonRendezvous) setNodeList(nodes []Node) {
+	ids := make([]string, 0, len(nodes))
+
+	if sr.nodeInfo == nil {
+		sr.nodeInfo = make(map[string]Node, len(nodes))
+	}
+
+	for _, node := range nodes {
+		sr.nodeInfo[node.ID] = node.clone()
+
+		if node.Weight > 0 {
+			if sr.nodeWeights == nil {
+				sr.nodeWeights = make(map[string]float64)
+			}
+
+			sr.nodeWeights[node.ID] = node.Weight
+		}
+
+		ids = append(ids, node.ID)
+	}
+
+	sr.setNodes(ids)
+}
+
+// NodeInfo returns the node with its metadata by ID, a node set without
+// metadata has only its ID. It returns false when the node does not exist.
+func (sr *SkeletonRendezvous) NodeInfo(id string) (Node, bool) {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+
+	return sr.nodeInfoOf(id)
+}
+
+// FindNodeInfo find selected node for the key like FindNode, returning the
+// node with its metadata. It returns false when no node is selected.
+func (sr *SkeletonRendezvous) FindNodeInfo(key string) (Node, bool) {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+
+	node, err := sr.findNode(key)
+
+	if err != nil {
+		return Node{}, false
+	}
+
+	return sr.nodeInfoOf(node)
+}
+
+// ClusterNodes returns the clusters with the metadata of every node.
+func (sr *SkeletonRendezvous) ClusterNodes() [][]Node {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+
+	clusters := make([][]Node, 0, len(sr.Clusters))
+
+	for _, cluster := range sr.Clusters {
+		nodes := make([]Node, 0, len(cluster))
+
+		for _, id := range cluster {
+			node, _ := sr.nodeInfoOf(id)
+			nodes = append(nodes, node)
+		}
+
+		clusters = append(clusters, nodes)
+	}
+
+	return clusters
+}
+
+func (sr *SkeletonRendezvous) nodeInfoOf(id string) (Node, bool) {
+	if node, ok := sr.nodeInfo[id]; ok {
+		return node.clone(), true
+	}
+
+	if sr.hasNode(id) {
+		return Node{ID: id}, true
+	}
+
+	return Node{}, false
+}
+
+// hasNode reports whether the node exists in the skeleton.
+func (sr *SkeletonRendezvous) hasNode(id string) bool {
+	for _, node := range sr.Nodes {
+		if node == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (n Node) clone() Node {
+	if n.Labels == nil {
+		return n
+	}
+
+	labels := make(map[string]string, len(n.Labels))
+
+	for key, value := range n.Labels {
+		labels[key] = value
+	}
+
+	n.Labels = labels
+
+	return n
+}
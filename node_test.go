@@ -0,0 +1,92 @@
+package rendezvous
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeList(t *testing.T) {
+	nodes := []Node{
+		{ID: "jg1", Addr: "10.0.0.1:7000", Labels: map[string]string{"zone": "a"}},
+		{ID: "jg2", Addr: "10.0.0.2:7000", Labels: map[string]string{"zone": "b"}},
+		{ID: "jg3", Addr: "10.0.0.3:7000", Weight: 2},
+		{ID: "jg4", Addr: "10.0.0.4:7000"},
+	}
+
+	t.Run("should set nodes with metadata", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(2), MinClusterSize(2))
+
+		assert.NoError(t, err)
+
+		sr.SetNodeList(nodes)
+		sr.SetNodes([]string{"jg5"})
+
+		assert.Equal(t, []string{"jg1", "jg2", "jg3", "jg4", "jg5"}, sr.Nodes)
+
+		node, ok := sr.NodeInfo("jg1")
+		assert.True(t, ok)
+		assert.Equal(t, nodes[0], node)
+
+		node, ok = sr.NodeInfo("jg5")
+		assert.True(t, ok)
+		assert.Equal(t, Node{ID: "jg5"}, node)
+
+		_, ok = sr.NodeInfo("unknown")
+		assert.False(t, ok)
+
+		assert.Equal(t, 2.0, sr.nodeWeight("jg3"))
+		assert.Equal(t, 1.0, sr.nodeWeight("jg4"))
+
+		clusters := sr.ClusterNodes()
+
+		assert.Equal(t, len(sr.Clusters), len(clusters))
+
+		for i, cluster := range clusters {
+			for j, node := range cluster {
+				assert.Equal(t, sr.Clusters[i][j], node.ID)
+			}
+		}
+	})
+
+	t.Run("should find node with metadata", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(2), MinClusterSize(2))
+
+		assert.NoError(t, err)
+
+		_, ok := sr.FindNodeInfo("key")
+		assert.False(t, ok)
+
+		sr.SetNodeList(nodes)
+
+		for i := 0; i < 100; i++ {
+			key := "key-" + strconv.Itoa(i)
+
+			node, ok := sr.FindNodeInfo(key)
+
+			assert.True(t, ok)
+			assert.Equal(t, mustFindNode(t, sr, key), node.ID)
+			assert.NotEmpty(t, node.Addr)
+		}
+	})
+
+	t.Run("should not share labels with caller", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		labels := map[string]string{"zone": "a"}
+
+		sr.SetNodeList([]Node{{ID: "jg1", Labels: labels}})
+		labels["zone"] = "b"
+
+		node, _ := sr.NodeInfo("jg1")
+		assert.Equal(t, "a", node.Labels["zone"])
+
+		sr.RemoveNodes([]string{"jg1"})
+
+		_, ok := sr.NodeInfo("jg1")
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,120 @@
+package rendezvous
+
+import (
+	"fmt"
+	"time"
+)
+
+// Observer is notified of the lookups and the topology changes of a
+// skeleton, such as to export metrics. Its methods are called from the
+// goroutines looking up and changing the skeleton, without holding its
+// lock, so they must be safe for concurrent use and return quickly.
+type Observer interface {
+	// ObserveLookup is called after every lookup of a key by FindNode,
+	// FindNodeContext, FindN and Iter, and after every key looked up by
+	// FindNodes, GroupByNode, AssignAll and AssignStream. A batch notifies
+	// its lookups once it has released the lock.
+	ObserveLookup(event LookupEvent)
+
+	// ObserveTopology is called after every change of the nodes or the
+	// clusters, in the order of the changes, with the resulting statistics
+	ObserveTopology(event TopologyEvent, stats RingStats)
+}
+
+// optionsObserver is an observer which is told the options of the skeleton
+// when it is created, along with the warnings about them.
+type optionsObserver interface {
+	observeOptions(o Options, warnings []string)
+}
+
+// publishingObserver is an observer which publishes itself globally, it is
+// published once the skeleton is created so a failing constructor leaves
+// nothing published.
+type publishingObserver interface {
+	publish() error
+}
+
+// topologyObserver is an observer which may ignore lookups, so lookups are
+// not timed for it.
+type topologyObserver interface {
+	observesLookups() bool
+}
+
+// LookupEvent describes a lookup.
+type LookupEvent struct {
+	// Key is the looked up key
+	Key string
+
+	// Node is the selected node, empty when the lookup failed
+	Node string
+
+	// Err is the error of the lookup
+	Err error
+
+	// Start is when the lookup started
+	Start time.Time
+
+	// Duration is how long the lookup took, including waiting for the lock
+	// for a single key, while a key of a batch is only timed itself
+	Duration time.Duration
+
+	// Epoch is the epoch of the topology the key was looked up in
+	Epoch uint64
+
+	// Depth is the number of branch levels walked
+	Depth int
+}
+
+// Observe adds an observer of the lookups and the topology changes, every
+// observer added by the options is notified.
+func Observe(observer Observer) Option {
+	return func(o *Options) error {
+		if observer == nil {
+			return fmt.Errorf("%w: observer is nil", ErrInvalidOption)
+		}
+
+		o.observers = append(o.observers[:len(o.observers):len(o.observers)], observer)
+
+		return nil
+	}
+}
+
+// lookupStart returns when a lookup starts, the zero time without lookup
+// observers so an unobserved lookup is not timed.
+func (sr *SkeletonRendezvous) lookupStart() time.Time {
+	if len(sr.lookupObservers) == 0 {
+		return time.Time{}
+	}
+
+	return time.Now()
+}
+
+// recordLookup appends the lookup started at start to the events for the
+// lookup observers, it records nothing without lookup observers. The
+// caller must hold the read lock, and notify the observers of the events
+// once it is released.
+func (sr *SkeletonRendezvous) recordLookup(events *[]LookupEvent, key string, node string, err error, start time.Time) {
+	if len(sr.lookupObservers) == 0 {
+		return
+	}
+
+	*events = append(*events, LookupEvent{
+		Key:      key,
+		Node:     node,
+		Err:      err,
+		Start:    start,
+		Duration: time.Since(start),
+		Epoch:    sr.epoch,
+		Depth:    sr.VirtualNodes,
+	})
+}
+
+// notifyLookups notifies the lookup observers of the events, it must be
+// called without holding the lock.
+func (sr *SkeletonRendezvous) notifyLookups(events []LookupEvent) {
+	for _, event := range events {
+		for _, observer := range sr.lookupObservers {
+			observer.ObserveLookup(event)
+		}
+	}
+}
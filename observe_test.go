@@ -0,0 +1,140 @@
+package rendezvous
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingObserver records the events it observes.
+type recordingObserver struct {
+	mu      sync.Mutex
+	lookups []LookupEvent
+	changes []TopologyEvent
+	stats   []RingStats
+}
+
+func (r *recordingObserver) ObserveLookup(event LookupEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lookups = append(r.lookups, event)
+}
+
+func (r *recordingObserver) ObserveTopology(event TopologyEvent, stats RingStats) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.changes = append(r.changes, event)
+	r.stats = append(r.stats, stats)
+}
+
+func TestObserve(t *testing.T) {
+	t.Run("should observe lookups", func(t *testing.T) {
+		observer := &recordingObserver{}
+
+		sr, err := NewSkeletonRendezvous(Observe(observer))
+		assert.NoError(t, err)
+
+		_, err = sr.FindNode("key-1")
+		assert.ErrorIs(t, err, ErrNoNodes)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		node := mustFindNode(t, sr, "key-1")
+
+		assert.Len(t, observer.lookups, 2)
+		assert.ErrorIs(t, observer.lookups[0].Err, ErrNoNodes)
+
+		lookup := observer.lookups[1]
+
+		assert.Equal(t, "key-1", lookup.Key)
+		assert.Equal(t, node, lookup.Node)
+		assert.NoError(t, lookup.Err)
+		assert.Equal(t, sr.Epoch(), lookup.Epoch)
+		assert.Equal(t, sr.VirtualNodes, lookup.Depth)
+		assert.False(t, lookup.Start.IsZero())
+	})
+
+	t.Run("should observe batch and ranked lookups", func(t *testing.T) {
+		observer := &recordingObserver{}
+
+		sr, err := NewSkeletonRendezvous(Observe(observer))
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		keys := []string{"key-1", "key-2", "key-3"}
+
+		_, err = sr.FindNodes(keys)
+		assert.NoError(t, err)
+
+		_, err = sr.GroupByNode(keys)
+		assert.NoError(t, err)
+
+		_, err = sr.AssignAll(keys, 2)
+		assert.NoError(t, err)
+
+		stream := make(chan string, len(keys))
+
+		for _, key := range keys {
+			stream <- key
+		}
+
+		close(stream)
+
+		assignments, err := sr.AssignStream(context.Background(), stream)
+		assert.NoError(t, err)
+
+		for range assignments {
+		}
+
+		nodes, err := sr.FindN("key-1", 2)
+		assert.NoError(t, err)
+
+		sr.Iter("key-1")
+
+		_, err = sr.FindNodeContext(context.Background(), "key-1")
+		assert.NoError(t, err)
+
+		observer.mu.Lock()
+		lookups := append([]LookupEvent(nil), observer.lookups...)
+		observer.mu.Unlock()
+
+		assert.Len(t, lookups, 4*len(keys)+3)
+
+		for _, lookup := range lookups {
+			assert.Equal(t, mustFindNode(t, sr, lookup.Key), lookup.Node)
+			assert.False(t, lookup.Start.IsZero())
+		}
+
+		assert.Equal(t, nodes[0], lookups[4*len(keys)].Node)
+	})
+
+	t.Run("should observe topology changes with every observer", func(t *testing.T) {
+		first := &recordingObserver{}
+		second := &recordingObserver{}
+
+		sr, err := NewSkeletonRendezvous(Observe(first), Observe(second))
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+		sr.RemoveNodes([]string{"jg4"})
+		sr.MarkDown("jg1")
+
+		for _, observer := range []*recordingObserver{first, second} {
+			assert.Len(t, observer.changes, 2)
+			assert.Equal(t, []string{"jg1", "jg2", "jg3", "jg4"}, observer.changes[0].NodesAdded)
+			assert.Equal(t, []string{"jg4"}, observer.changes[1].NodesRemoved)
+			assert.Equal(t, 4, observer.stats[0].Nodes)
+			assert.Equal(t, sr.Stats(), observer.stats[1])
+		}
+	})
+
+	t.Run("should reject nil observer", func(t *testing.T) {
+		_, err := NewSkeletonRendezvous(Observe(nil))
+		assert.ErrorIs(t, err, ErrInvalidOption)
+	})
+}
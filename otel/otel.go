@@ -0,0 +1,129 @@
+// Package otel records the lookups and the topology changes of a skeleton
+// rendezvous as OpenTelemetry spans and measurements. The observer hands
+// every finished span and measurement to the functions of its Config, which
+// forward them to a tracer and a meter:
+//
+//	tracer := otel.Tracer("rendezvous")
+//	duration, _ := otel.Meter("rendezvous").Float64Histogram("rendezvous.lookup.duration")
+//
+//	observer := skeletonotel.NewObserver(skeletonotel.Config{
+//		Span: func(name string, start, end time.Time, attributes []skeletonotel.Attribute, err error) {
+//			_, span := tracer.Start(context.Background(), name, trace.WithTimestamp(start))
+//			for _, a := range attributes {
+//				span.SetAttributes(attribute.String(a.Key, fmt.Sprint(a.Value)))
+//			}
+//			if err != nil {
+//				span.RecordError(err)
+//				span.SetStatus(codes.Error, err.Error())
+//			}
+//			span.End(trace.WithTimestamp(end))
+//		},
+//		Measure: func(name string, value float64, attributes []skeletonotel.Attribute) {
+//			if name == skeletonotel.LookupDuration {
+//				duration.Record(context.Background(), value)
+//			}
+//		},
+//	})
+//
+//	sr, err := rendezvous.NewSkeletonRendezvous(rendezvous.Observe(observer))
+package otel
+
+import (
+	"time"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+)
+
+// Names of the spans and the measurements.
+const (
+	// FindNodeSpan is the span of a lookup
+	FindNodeSpan = "rendezvous.FindNode"
+
+	// TopologySpan is the span of a change of the nodes or the clusters
+	TopologySpan = "rendezvous.TopologyChange"
+
+	// LookupDuration is the duration of a lookup in seconds
+	LookupDuration = "rendezvous.lookup.duration"
+
+	// TopologyChanges counts the changes of the nodes or the clusters
+	TopologyChanges = "rendezvous.topology.changes"
+)
+
+// Attribute is a key value pair attached to a span or a measurement.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// SpanFunc records a finished span.
+type SpanFunc func(name string, start time.Time, end time.Time, attributes []Attribute, err error)
+
+// MeasureFunc records a measurement of an instrument.
+type MeasureFunc func(name string, value float64, attributes []Attribute)
+
+// Config selects what the observer records, nil functions are skipped.
+type Config struct {
+	Span    SpanFunc
+	Measure MeasureFunc
+
+	// RecordKeys adds the looked up key to the lookup spans, keys are left
+	// out by default since they may hold user data
+	RecordKeys bool
+}
+
+// Observer records the lookups and the topology changes of the skeletons
+// it observes.
+type Observer struct {
+	config Config
+}
+
+// NewObserver creates the observer, it is added to a skeleton with the
+// rendezvous.Observe option.
+func NewObserver(config Config) *Observer {
+	return &Observer{config: config}
+}
+
+// ObserveLookup records the span and the duration of the lookup.
+func (o *Observer) ObserveLookup(event rendezvous.LookupEvent) {
+	if o.config.Span != nil {
+		attributes := []Attribute{
+			{Key: "rendezvous.node", Value: event.Node},
+			{Key: "rendezvous.depth", Value: event.Depth},
+			{Key: "rendezvous.epoch", Value: event.Epoch},
+		}
+
+		if o.config.RecordKeys {
+			attributes = append(attributes, Attribute{Key: "rendezvous.key", Value: event.Key})
+		}
+
+		o.config.Span(FindNodeSpan, event.Start, event.Start.Add(event.Duration), attributes, event.Err)
+	}
+
+	if o.config.Measure != nil {
+		o.config.Measure(LookupDuration, event.Duration.Seconds(), []Attribute{
+			{Key: "rendezvous.node", Value: event.Node},
+			{Key: "rendezvous.error", Value: event.Err != nil},
+		})
+	}
+}
+
+// ObserveTopology records the span and the count of the change.
+func (o *Observer) ObserveTopology(event rendezvous.TopologyEvent, stats rendezvous.RingStats) {
+	if o.config.Span != nil {
+		now := time.Now()
+
+		o.config.Span(TopologySpan, now, now, []Attribute{
+			{Key: "rendezvous.epoch", Value: event.Epoch},
+			{Key: "rendezvous.nodes_added", Value: len(event.NodesAdded)},
+			{Key: "rendezvous.nodes_removed", Value: len(event.NodesRemoved)},
+			{Key: "rendezvous.clusters_rebuilt", Value: event.ClustersRebuilt},
+			{Key: "rendezvous.nodes", Value: stats.Nodes},
+			{Key: "rendezvous.clusters", Value: stats.Clusters},
+			{Key: "rendezvous.virtual_nodes", Value: stats.VirtualNodes},
+		}, nil)
+	}
+
+	if o.config.Measure != nil {
+		o.config.Measure(TopologyChanges, 1, nil)
+	}
+}
@@ -0,0 +1,107 @@
+package otel
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+	"github.com/stretchr/testify/assert"
+)
+
+type span struct {
+	name       string
+	start      time.Time
+	end        time.Time
+	attributes map[string]interface{}
+	err        error
+}
+
+type measurement struct {
+	name  string
+	value float64
+}
+
+// recorder records the spans and measurements like an exporter.
+type recorder struct {
+	mu           sync.Mutex
+	spans        []span
+	measurements []measurement
+}
+
+func (r *recorder) config(recordKeys bool) Config {
+	return Config{
+		Span: func(name string, start, end time.Time, attributes []Attribute, err error) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+
+			values := make(map[string]interface{})
+
+			for _, attribute := range attributes {
+				values[attribute.Key] = attribute.Value
+			}
+
+			r.spans = append(r.spans, span{name: name, start: start, end: end, attributes: values, err: err})
+		},
+		Measure: func(name string, value float64, attributes []Attribute) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+
+			r.measurements = append(r.measurements, measurement{name: name, value: value})
+		},
+		RecordKeys: recordKeys,
+	}
+}
+
+func TestObserver(t *testing.T) {
+	t.Run("should record lookups and topology changes", func(t *testing.T) {
+		r := &recorder{}
+
+		sr, err := rendezvous.NewSkeletonRendezvous(rendezvous.Observe(NewObserver(r.config(false))))
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		node, err := sr.FindNode("key-1")
+		assert.NoError(t, err)
+
+		assert.Len(t, r.spans, 2)
+		assert.Equal(t, TopologySpan, r.spans[0].name)
+		assert.Equal(t, 4, r.spans[0].attributes["rendezvous.nodes_added"])
+		assert.Equal(t, uint64(1), r.spans[0].attributes["rendezvous.epoch"])
+
+		lookup := r.spans[1]
+
+		assert.Equal(t, FindNodeSpan, lookup.name)
+		assert.Equal(t, node, lookup.attributes["rendezvous.node"])
+		assert.Equal(t, sr.VirtualNodes, lookup.attributes["rendezvous.depth"])
+		assert.NotContains(t, lookup.attributes, "rendezvous.key")
+		assert.False(t, lookup.end.Before(lookup.start))
+		assert.NoError(t, lookup.err)
+
+		assert.Equal(t, []string{TopologyChanges, LookupDuration}, []string{r.measurements[0].name, r.measurements[1].name})
+	})
+
+	t.Run("should record keys and errors", func(t *testing.T) {
+		r := &recorder{}
+
+		sr, err := rendezvous.NewSkeletonRendezvous(rendezvous.Observe(NewObserver(r.config(true))))
+		assert.NoError(t, err)
+
+		_, err = sr.FindNode("key-1")
+		assert.ErrorIs(t, err, rendezvous.ErrNoNodes)
+
+		assert.Equal(t, "key-1", r.spans[0].attributes["rendezvous.key"])
+		assert.ErrorIs(t, r.spans[0].err, rendezvous.ErrNoNodes)
+	})
+
+	t.Run("should skip nil functions", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous(rendezvous.Observe(NewObserver(Config{})))
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1"})
+
+		_, err = sr.FindNode("key-1")
+		assert.NoError(t, err)
+	})
+}
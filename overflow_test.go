@@ -0,0 +1,135 @@
+package rendezvous
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOverflowPolicy(t *testing.T) {
+	clusters := [][]string{{"jg1"}, {"jg2"}, {"jg3"}, {"jg4"}, {"jg5"}}
+
+	newSkeleton := func(t *testing.T, policy OverflowPolicy) *SkeletonRendezvous {
+		sr, err := NewSkeletonRendezvous(FanOut(3), Overflow(policy))
+
+		assert.NoError(t, err)
+
+		sr.SetClusters(clusters)
+
+		assert.Equal(t, 2, sr.VirtualNodes)
+		assert.NoError(t, sr.Validate())
+
+		return sr
+	}
+
+	t.Run("should wrap overflowing positions with modulo by default", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(3))
+
+		assert.NoError(t, err)
+
+		sr.SetClusters(clusters)
+
+		indices := make([]int, 0)
+
+		for position := 0; position < 9; position++ {
+			indices = append(indices, sr.clusterIndex(position))
+		}
+
+		assert.Equal(t, []int{0, 1, 2, 3, 4, 0, 1, 2, 3}, indices)
+	})
+
+	t.Run("should clamp overflowing positions into last cluster", func(t *testing.T) {
+		sr := newSkeleton(t, ClampLast)
+
+		indices := make([]int, 0)
+
+		for position := 0; position < 9; position++ {
+			indices = append(indices, sr.clusterIndex(position))
+		}
+
+		assert.Equal(t, []int{0, 1, 2, 3, 4, 4, 4, 4, 4}, indices)
+	})
+
+	t.Run("should route every key into a cluster", func(t *testing.T) {
+		for _, policy := range []OverflowPolicy{WrapModulo, ClampLast, BalanceOverflow} {
+			sr := newSkeleton(t, policy)
+
+			for i := 0; i < 300; i++ {
+				key := "key-" + strconv.Itoa(i)
+
+				explanation := sr.Explain(key)
+
+				position := 0
+
+				for _, branch := range explanation.Branches {
+					position = position*3 + branch.Branch
+				}
+
+				assert.Equal(t, sr.clusterIndex(position), explanation.Cluster)
+				assert.Equal(t, clusters[explanation.Cluster][0], mustFindNode(t, sr, key))
+			}
+		}
+	})
+
+	t.Run("should precompute the cluster of every position", func(t *testing.T) {
+		for _, policy := range []OverflowPolicy{WrapModulo, ClampLast} {
+			sr := newSkeleton(t, policy)
+
+			assert.Len(t, sr.clusterIndexes, 9)
+			assert.Equal(t, []int{3, 1}, sr.spans)
+
+			for position, clusterIndex := range sr.clusterIndexes {
+				assert.Equal(t, sr.clusterIndex(position), clusterIndex)
+			}
+
+			sr.RemoveNodes([]string{"jg5"})
+
+			for position, clusterIndex := range sr.clusterIndexes {
+				assert.Equal(t, sr.clusterIndex(position), clusterIndex)
+			}
+		}
+	})
+
+	t.Run("should balance keys across clusters", func(t *testing.T) {
+		counts := func(policy OverflowPolicy) []int {
+			sr, err := NewSkeletonRendezvous(FanOut(3), Overflow(policy))
+
+			assert.NoError(t, err)
+
+			sr.SetClusters(clusters)
+
+			// random keys, sequential keys share a prefix which fnv
+			// spreads poorly over the branches
+			random := rand.New(rand.NewSource(1))
+			counts := make([]int, len(clusters))
+
+			for i := 0; i < 10000; i++ {
+				clusterIndex, err := sr.findCluster(strconv.FormatUint(random.Uint64(), 36))
+
+				assert.NoError(t, err)
+
+				counts[clusterIndex]++
+			}
+
+			return counts
+		}
+
+		// positions 5 to 8 wrap onto clusters 0 to 3, cluster 4 has one
+		// position out of 9 against two for the others
+		wrapped := counts(WrapModulo)
+
+		assert.InDelta(t, 10000/9, wrapped[4], 150)
+
+		for _, count := range counts(BalanceOverflow) {
+			assert.InDelta(t, 10000/5, count, 150)
+		}
+	})
+
+	t.Run("should reject unknown policy", func(t *testing.T) {
+		_, err := NewSkeletonRendezvous(Overflow(OverflowPolicy(7)))
+
+		assert.ErrorIs(t, err, ErrInvalidOption)
+	})
+}
@@ -0,0 +1,91 @@
+package rendezvous
+
+import (
+	"fmt"
+)
+
+// PinKey forces the key onto the node, overriding the hash based selection,
+// such as to move a problematic tenant onto a dedicated node. The pin is
+// ignored while the node is draining or down, and dropped once the node is
+// removed. It returns ErrEmptyKey for an empty key and ErrNodeNotFound when
+// the node does not exist.
+func (sr *SkeletonRendezvous) PinKey(key string, node string) error {
+	if key == "" {
+		return ErrEmptyKey
+	}
+
+	var err error
+
+	sr.update(func() {
+		if !sr.hasNode(node) {
+			err = fmt.Errorf("%w: %s", ErrNodeNotFound, node)
+
+			return
+		}
+
+		if sr.pins == nil {
+			sr.pins = make(map[string]string)
+		}
+
+		sr.pins[key] = node
+	})
+
+	return err
+}
+
+// UnpinKey removes the pin of the key, the key is selected by its hash
+// again.
+func (sr *SkeletonRendezvous) UnpinKey(key string) {
+	sr.update(func() {
+		delete(sr.pins, key)
+	})
+}
+
+// Pins returns a copy of the pinned keys along with their node.
+func (sr *SkeletonRendezvous) Pins() map[string]string {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+
+	pins := make(map[string]string, len(sr.pins))
+
+	for key, node := range sr.pins {
+		pins[key] = node
+	}
+
+	return pins
+}
+
+// pinnedNode returns the node the key is pinned to, unless the node is
+// unavailable.
+func (sr *SkeletonRendezvous) pinnedNode(key string, unavailable map[string]struct{}) (string, bool) {
+	node, ok := sr.pins[key]
+
+	if !ok {
+		return "", false
+	}
+
+	if _, ok := unavailable[node]; ok {
+		return "", false
+	}
+
+	return node, true
+}
+
+// prunePins drops the pins of the nodes which no longer exist.
+func (sr *SkeletonRendezvous) prunePins() {
+	if len(sr.pins) == 0 {
+		return
+	}
+
+	nodes := make(map[string]bool, len(sr.Nodes))
+
+	for _, node := range sr.Nodes {
+		nodes[node] = true
+	}
+
+	for key, node := range sr.pins {
+		if !nodes[node] {
+			delete(sr.pins, key)
+		}
+	}
+}
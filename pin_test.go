@@ -0,0 +1,100 @@
+package rendezvous
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPinKey(t *testing.T) {
+	newSkeleton := func(t *testing.T) (*SkeletonRendezvous, string, string) {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		node := mustFindNode(t, sr, "tenant-1")
+		other := "jg1"
+
+		if node == other {
+			other = "jg4"
+		}
+
+		return sr, node, other
+	}
+
+	t.Run("should select the pinned node until unpinned", func(t *testing.T) {
+		sr, node, other := newSkeleton(t)
+
+		assert.NoError(t, sr.PinKey("tenant-1", other))
+		assert.Equal(t, other, mustFindNode(t, sr, "tenant-1"))
+		assert.Equal(t, map[string]string{"tenant-1": other}, sr.Pins())
+
+		sr.UnpinKey("tenant-1")
+
+		assert.Equal(t, node, mustFindNode(t, sr, "tenant-1"))
+		assert.Empty(t, sr.Pins())
+	})
+
+	t.Run("should bypass the lookup cache", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(LookupCache(16))
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		node := mustFindNode(t, sr, "tenant-1")
+		other := "jg1"
+
+		if node == other {
+			other = "jg4"
+		}
+
+		assert.NoError(t, sr.PinKey("tenant-1", other))
+		assert.Equal(t, other, mustFindNode(t, sr, "tenant-1"))
+	})
+
+	t.Run("should ignore the pin while the node is down and drop it once removed", func(t *testing.T) {
+		sr, _, other := newSkeleton(t)
+
+		assert.NoError(t, sr.PinKey("tenant-1", other))
+
+		sr.MarkDown(other)
+
+		assert.NotEqual(t, other, mustFindNode(t, sr, "tenant-1"))
+
+		sr.MarkUp(other)
+
+		assert.Equal(t, other, mustFindNode(t, sr, "tenant-1"))
+
+		sr.RemoveNodes([]string{other})
+
+		assert.Empty(t, sr.Pins())
+
+		sr.AddNodes([]string{other})
+
+		assert.Empty(t, sr.Pins())
+	})
+
+	t.Run("should persist pins in snapshots", func(t *testing.T) {
+		sr, _, other := newSkeleton(t)
+
+		assert.NoError(t, sr.PinKey("tenant-1", other))
+
+		data, err := sr.MarshalJSON()
+		assert.NoError(t, err)
+
+		restored, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		assert.NoError(t, restored.UnmarshalJSON(data))
+		assert.Equal(t, other, mustFindNode(t, restored, "tenant-1"))
+	})
+
+	t.Run("should return error for empty key or unknown node", func(t *testing.T) {
+		sr, _, _ := newSkeleton(t)
+
+		assert.ErrorIs(t, sr.PinKey("", "jg1"), ErrEmptyKey)
+		assert.ErrorIs(t, sr.PinKey("tenant-1", "jg9"), ErrNodeNotFound)
+		assert.Empty(t, sr.Pins())
+	})
+}
@@ -0,0 +1,74 @@
+//go:build prometheus
+
+package prometheus
+
+import (
+	"net/http"
+
+	client "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	lookupsDesc         = client.NewDesc("rendezvous_lookups_total", "Lookups by selected node.", []string{"node"}, nil)
+	lookupErrorsDesc    = client.NewDesc("rendezvous_lookup_errors_total", "Lookups which failed.", nil, nil)
+	lookupDurationDesc  = client.NewDesc("rendezvous_lookup_duration_seconds", "Duration of the lookups.", nil, nil)
+	epochDesc           = client.NewDesc("rendezvous_topology_epoch", "Epoch of the topology.", nil, nil)
+	nodesDesc           = client.NewDesc("rendezvous_nodes", "Number of nodes.", nil, nil)
+	clustersDesc        = client.NewDesc("rendezvous_clusters", "Number of clusters.", nil, nil)
+	changesDesc         = client.NewDesc("rendezvous_topology_changes_total", "Changes of the nodes or the clusters.", nil, nil)
+	nodesAddedDesc      = client.NewDesc("rendezvous_nodes_added_total", "Nodes which joined.", nil, nil)
+	nodesRemovedDesc    = client.NewDesc("rendezvous_nodes_removed_total", "Nodes which left.", nil, nil)
+	clusterRebuildsDesc = client.NewDesc("rendezvous_cluster_rebuilds_total", "Changes which moved kept nodes into another cluster.", nil, nil)
+)
+
+// Describe sends the descriptors of the metrics, with Collect the collector
+// satisfies the Collector interface of the Prometheus client.
+func (c *Collector) Describe(descs chan<- *client.Desc) {
+	for _, desc := range []*client.Desc{
+		lookupsDesc, lookupErrorsDesc, lookupDurationDesc, epochDesc, nodesDesc, clustersDesc,
+		changesDesc, nodesAddedDesc, nodesRemovedDesc, clusterRebuildsDesc,
+	} {
+		descs <- desc
+	}
+}
+
+// Collect sends the current value of the metrics.
+func (c *Collector) Collect(metrics chan<- client.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for node, lookups := range c.lookups {
+		metrics <- client.MustNewConstMetric(lookupsDesc, client.CounterValue, float64(lookups), node)
+	}
+
+	metrics <- client.MustNewConstMetric(lookupErrorsDesc, client.CounterValue, float64(c.lookupErrors))
+
+	buckets := make(map[float64]uint64, len(c.buckets))
+	cumulative := uint64(0)
+
+	for i, bound := range c.buckets {
+		cumulative += c.bucketCounts[i]
+		buckets[bound] = cumulative
+	}
+
+	metrics <- client.MustNewConstHistogram(lookupDurationDesc, c.durationCount, c.durationSum, buckets)
+
+	metrics <- client.MustNewConstMetric(epochDesc, client.GaugeValue, float64(c.epoch))
+	metrics <- client.MustNewConstMetric(nodesDesc, client.GaugeValue, float64(c.nodes))
+	metrics <- client.MustNewConstMetric(clustersDesc, client.GaugeValue, float64(c.clusters))
+	metrics <- client.MustNewConstMetric(changesDesc, client.CounterValue, float64(c.changes))
+	metrics <- client.MustNewConstMetric(nodesAddedDesc, client.CounterValue, float64(c.nodesAdded))
+	metrics <- client.MustNewConstMetric(nodesRemovedDesc, client.CounterValue, float64(c.nodesRemoved))
+	metrics <- client.MustNewConstMetric(clusterRebuildsDesc, client.CounterValue, float64(c.clusterRebuilds))
+}
+
+// Handler serves the metrics of the collector with promhttp, from a
+// registry of its own. A collector registered with the default registry is
+// served by promhttp.Handler instead.
+func (c *Collector) Handler() http.Handler {
+	registry := client.NewRegistry()
+	registry.MustRegister(c)
+
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
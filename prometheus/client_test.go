@@ -0,0 +1,65 @@
+//go:build prometheus
+
+package prometheus
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+	client "github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientCollector(t *testing.T) {
+	t.Run("should gather the metrics from a registry", func(t *testing.T) {
+		collector := NewCollector()
+
+		sr, err := rendezvous.NewSkeletonRendezvous(rendezvous.Observe(collector))
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3"})
+
+		_, err = sr.FindNode("key-1")
+		assert.NoError(t, err)
+
+		registry := client.NewRegistry()
+		assert.NoError(t, registry.Register(collector))
+
+		families, err := registry.Gather()
+		assert.NoError(t, err)
+
+		values := make(map[string]*float64)
+
+		for _, family := range families {
+			for _, metric := range family.GetMetric() {
+				switch {
+				case metric.GetCounter() != nil:
+					values[family.GetName()] = metric.GetCounter().Value
+				case metric.GetGauge() != nil:
+					values[family.GetName()] = metric.GetGauge().Value
+				}
+			}
+		}
+
+		assert.Equal(t, float64(1), *values["rendezvous_lookups_total"])
+		assert.Equal(t, float64(3), *values["rendezvous_nodes"])
+		assert.Equal(t, float64(1), *values["rendezvous_topology_changes_total"])
+	})
+
+	t.Run("should serve the metrics with promhttp", func(t *testing.T) {
+		collector := NewCollector()
+
+		sr, err := rendezvous.NewSkeletonRendezvous(rendezvous.Observe(collector))
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3"})
+
+		recorder := httptest.NewRecorder()
+		collector.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "rendezvous_nodes 3\n")
+	})
+}
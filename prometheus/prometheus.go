@@ -0,0 +1,187 @@
+// Package prometheus exposes the lookups and the topology of a skeleton
+// rendezvous as Prometheus metrics. The collector observes the skeleton and
+// serves the metrics in the text exposition format:
+//
+//	collector := prometheus.NewCollector()
+//
+//	sr, err := rendezvous.NewSkeletonRendezvous(rendezvous.Observe(collector))
+//	...
+//	http.Handle("/metrics/rendezvous", collector)
+//
+// Built with the prometheus tag in a module requiring
+// github.com/prometheus/client_golang, the collector also satisfies the
+// Collector interface of the client, so it is registered with a registry
+// and served by promhttp:
+//
+//	client.MustRegister(collector)
+//	http.Handle("/metrics", promhttp.Handler())
+package prometheus
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+)
+
+// DefaultBuckets are the upper bounds of the lookup duration histogram in
+// seconds, from 1µs to 10ms.
+var DefaultBuckets = []float64{0.000001, 0.0000025, 0.000005, 0.00001, 0.000025, 0.00005, 0.0001, 0.00025, 0.0005, 0.001, 0.0025, 0.005, 0.01}
+
+// Collector counts the lookups and the topology changes of the skeletons
+// it observes.
+type Collector struct {
+	mu sync.Mutex
+
+	lookups      map[string]uint64
+	lookupErrors uint64
+
+	buckets         []float64
+	bucketCounts    []uint64
+	durationSum     float64
+	durationCount   uint64
+	epoch           uint64
+	nodes           int
+	clusters        int
+	changes         uint64
+	nodesAdded      uint64
+	nodesRemoved    uint64
+	clusterRebuilds uint64
+}
+
+// NewCollector creates the collector with the default duration buckets.
+func NewCollector() *Collector {
+	return NewCollectorWithBuckets(DefaultBuckets)
+}
+
+// NewCollectorWithBuckets creates the collector with the upper bounds of
+// the lookup duration histogram in seconds, in increasing order.
+func NewCollectorWithBuckets(buckets []float64) *Collector {
+	return &Collector{
+		lookups:      make(map[string]uint64),
+		buckets:      append([]float64(nil), buckets...),
+		bucketCounts: make([]uint64, len(buckets)),
+	}
+}
+
+// ObserveLookup counts the lookup by node and its duration.
+func (c *Collector) ObserveLookup(event rendezvous.LookupEvent) {
+	seconds := event.Duration.Seconds()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if event.Err != nil {
+		c.lookupErrors++
+	} else {
+		c.lookups[event.Node]++
+	}
+
+	for i, bound := range c.buckets {
+		if seconds <= bound {
+			c.bucketCounts[i]++
+
+			break
+		}
+	}
+
+	c.durationSum += seconds
+	c.durationCount++
+}
+
+// ObserveTopology counts the change and records the resulting topology.
+func (c *Collector) ObserveTopology(event rendezvous.TopologyEvent, stats rendezvous.RingStats) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.epoch = event.Epoch
+	c.nodes = stats.Nodes
+	c.clusters = stats.Clusters
+	c.changes++
+	c.nodesAdded += uint64(len(event.NodesAdded))
+	c.nodesRemoved += uint64(len(event.NodesRemoved))
+
+	if event.ClustersRebuilt {
+		c.clusterRebuilds++
+	}
+}
+
+// ServeHTTP responds with the metrics.
+func (c *Collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+	c.Write(w)
+}
+
+// Write writes the metrics in the Prometheus text exposition format.
+func (c *Collector) Write(w io.Writer) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	buffered := bufio.NewWriter(w)
+
+	nodes := make([]string, 0, len(c.lookups))
+
+	for node := range c.lookups {
+		nodes = append(nodes, node)
+	}
+
+	sort.Strings(nodes)
+
+	header(buffered, "rendezvous_lookups_total", "counter", "Lookups by selected node.")
+
+	for _, node := range nodes {
+		fmt.Fprintf(buffered, "rendezvous_lookups_total{node=\"%s\"} %d\n", escapeLabel(node), c.lookups[node])
+	}
+
+	counter(buffered, "rendezvous_lookup_errors_total", "Lookups which failed.", c.lookupErrors)
+
+	header(buffered, "rendezvous_lookup_duration_seconds", "histogram", "Duration of the lookups.")
+
+	cumulative := uint64(0)
+
+	for i, bound := range c.buckets {
+		cumulative += c.bucketCounts[i]
+
+		fmt.Fprintf(buffered, "rendezvous_lookup_duration_seconds_bucket{le=\"%s\"} %d\n", strconv.FormatFloat(bound, 'g', -1, 64), cumulative)
+	}
+
+	fmt.Fprintf(buffered, "rendezvous_lookup_duration_seconds_bucket{le=\"+Inf\"} %d\n", c.durationCount)
+	fmt.Fprintf(buffered, "rendezvous_lookup_duration_seconds_sum %s\n", strconv.FormatFloat(c.durationSum, 'g', -1, 64))
+	fmt.Fprintf(buffered, "rendezvous_lookup_duration_seconds_count %d\n", c.durationCount)
+
+	gauge(buffered, "rendezvous_topology_epoch", "Epoch of the topology.", c.epoch)
+	gauge(buffered, "rendezvous_nodes", "Number of nodes.", uint64(c.nodes))
+	gauge(buffered, "rendezvous_clusters", "Number of clusters.", uint64(c.clusters))
+	counter(buffered, "rendezvous_topology_changes_total", "Changes of the nodes or the clusters.", c.changes)
+	counter(buffered, "rendezvous_nodes_added_total", "Nodes which joined.", c.nodesAdded)
+	counter(buffered, "rendezvous_nodes_removed_total", "Nodes which left.", c.nodesRemoved)
+	counter(buffered, "rendezvous_cluster_rebuilds_total", "Changes which moved kept nodes into another cluster.", c.clusterRebuilds)
+
+	return buffered.Flush()
+}
+
+func header(w io.Writer, name string, kind string, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func counter(w io.Writer, name string, help string, value uint64) {
+	header(w, name, "counter", help)
+	fmt.Fprintf(w, "%s %d\n", name, value)
+}
+
+func gauge(w io.Writer, name string, help string, value uint64) {
+	header(w, name, "gauge", help)
+	fmt.Fprintf(w, "%s %d\n", name, value)
+}
+
+// escapeLabel escapes a label value of the text exposition format.
+func escapeLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
@@ -0,0 +1,64 @@
+package prometheus
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollector(t *testing.T) {
+	t.Run("should expose lookups and topology", func(t *testing.T) {
+		collector := NewCollector()
+
+		sr, err := rendezvous.NewSkeletonRendezvous(rendezvous.Observe(collector))
+		assert.NoError(t, err)
+
+		_, err = sr.FindNode("key-1")
+		assert.ErrorIs(t, err, rendezvous.ErrNoNodes)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+		sr.RemoveNodes([]string{"jg4"})
+
+		node, err := sr.FindNode("key-1")
+		assert.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		collector.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+		body := recorder.Body.String()
+
+		assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", recorder.Header().Get("Content-Type"))
+		assert.Contains(t, body, "# TYPE rendezvous_lookups_total counter\nrendezvous_lookups_total{node=\""+node+"\"} 1\n")
+		assert.Contains(t, body, "rendezvous_lookup_errors_total 1\n")
+		assert.Contains(t, body, "rendezvous_lookup_duration_seconds_bucket{le=\"+Inf\"} 2\n")
+		assert.Contains(t, body, "rendezvous_lookup_duration_seconds_count 2\n")
+		assert.Contains(t, body, "rendezvous_topology_epoch 2\n")
+		assert.Contains(t, body, "rendezvous_nodes 3\n")
+		assert.Contains(t, body, "rendezvous_topology_changes_total 2\n")
+		assert.Contains(t, body, "rendezvous_nodes_added_total 4\n")
+		assert.Contains(t, body, "rendezvous_nodes_removed_total 1\n")
+	})
+
+	t.Run("should count durations into cumulative buckets", func(t *testing.T) {
+		collector := NewCollectorWithBuckets([]float64{0.001, 0.01})
+
+		collector.ObserveLookup(rendezvous.LookupEvent{Node: "jg1", Duration: 500 * time.Microsecond})
+		collector.ObserveLookup(rendezvous.LookupEvent{Node: "jg1", Duration: 5 * time.Millisecond})
+		collector.ObserveLookup(rendezvous.LookupEvent{Node: "jg\"2", Duration: time.Second})
+
+		var out strings.Builder
+
+		assert.NoError(t, collector.Write(&out))
+		assert.Contains(t, out.String(), `rendezvous_lookup_duration_seconds_bucket{le="0.001"} 1
+rendezvous_lookup_duration_seconds_bucket{le="0.01"} 2
+rendezvous_lookup_duration_seconds_bucket{le="+Inf"} 3
+rendezvous_lookup_duration_seconds_sum 1.0055
+`)
+		assert.Contains(t, out.String(), `rendezvous_lookups_total{node="jg\"2"} 1`)
+	})
+}
@@ -0,0 +1,78 @@
+package rendezvous
+
+// ReplicaSet is the primary node of a key followed by its secondaries, the
+// nodes a storage layer replicates the key to.
+type ReplicaSet struct {
+	// Key is the placed key
+	Key string
+
+	// Primary is the preferred node of the key, the node selected by
+	// FindNode
+	Primary string
+
+	// Secondaries are the other nodes of the key, ordered by preference
+	Secondaries []string
+}
+
+// FindReplicaSet finds the primary and up to replication-1 secondaries of
+// the key, in the order of FindN. Fewer secondaries are returned when there
+// are not enough nodes, the quorums are computed over the returned nodes.
+func (sr *SkeletonRendezvous) FindReplicaSet(key string, replication int) (ReplicaSet, error) {
+	nodes, err := sr.FindN(key, replication)
+
+	if err != nil {
+		return ReplicaSet{}, err
+	}
+
+	return ReplicaSet{Key: key, Primary: nodes[0], Secondaries: nodes[1:]}, nil
+}
+
+// Nodes returns the primary followed by the secondaries.
+func (rs ReplicaSet) Nodes() []string {
+	return append([]string{rs.Primary}, rs.Secondaries...)
+}
+
+// Size returns the number of nodes of the replica set.
+func (rs ReplicaSet) Size() int {
+	return 1 + len(rs.Secondaries)
+}
+
+// WriteQuorum returns the majority of the replica set, the number of nodes
+// that must acknowledge a write so any two writes share a node.
+func (rs ReplicaSet) WriteQuorum() int {
+	return rs.Size()/2 + 1
+}
+
+// ReadQuorum returns the number of nodes a read must reach to share a node
+// with every write acknowledged by the write quorum, that is the reads and
+// writes overlap when their quorums add up to more than the size.
+func (rs ReplicaSet) ReadQuorum(writeQuorum int) int {
+	readQuorum := rs.Size() - writeQuorum + 1
+
+	if readQuorum < 1 {
+		return 1
+	}
+
+	return readQuorum
+}
+
+// HasQuorum reports whether at least quorum distinct nodes of the replica
+// set are among the acknowledged nodes, other nodes are ignored.
+func (rs ReplicaSet) HasQuorum(acknowledged []string, quorum int) bool {
+	members := make(map[string]bool, rs.Size())
+
+	for _, node := range rs.Nodes() {
+		members[node] = true
+	}
+
+	count := 0
+
+	for _, node := range acknowledged {
+		if members[node] {
+			members[node] = false
+			count++
+		}
+	}
+
+	return count >= quorum
+}
@@ -0,0 +1,94 @@
+package rendezvous
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindReplicaSet(t *testing.T) {
+	t.Run("should return the primary followed by the secondaries", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4", "jg5", "jg6"})
+
+		nodes, err := sr.FindN("key-1", 3)
+		assert.NoError(t, err)
+
+		rs, err := sr.FindReplicaSet("key-1", 3)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "key-1", rs.Key)
+		assert.Equal(t, nodes[0], rs.Primary)
+		assert.Equal(t, nodes[1:], rs.Secondaries)
+		assert.Equal(t, nodes, rs.Nodes())
+		assert.Equal(t, 3, rs.Size())
+	})
+
+	t.Run("should return fewer secondaries than nodes", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2"})
+
+		rs, err := sr.FindReplicaSet("key-1", 3)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, rs.Size())
+	})
+
+	t.Run("should return an error without nodes", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		_, err = sr.FindReplicaSet("key-1", 3)
+
+		assert.ErrorIs(t, err, ErrNoNodes)
+	})
+
+	t.Run("should return an error when the replication is below 1", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2"})
+
+		_, err = sr.FindReplicaSet("key-1", 0)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestReplicaSetQuorum(t *testing.T) {
+	t.Run("should compute overlapping quorums", func(t *testing.T) {
+		for size, expected := range map[int][2]int{1: {1, 1}, 2: {2, 1}, 3: {2, 2}, 4: {3, 2}, 5: {3, 3}} {
+			rs := ReplicaSet{Primary: "jg0"}
+
+			for i := 1; i < size; i++ {
+				rs.Secondaries = append(rs.Secondaries, "jg"+string(rune('0'+i)))
+			}
+
+			write := rs.WriteQuorum()
+			read := rs.ReadQuorum(write)
+
+			assert.Equal(t, expected, [2]int{write, read}, "size %d", size)
+			assert.Greater(t, write+read, size)
+		}
+	})
+
+	t.Run("should read from one node when writing to all", func(t *testing.T) {
+		rs := ReplicaSet{Primary: "jg1", Secondaries: []string{"jg2", "jg3"}}
+
+		assert.Equal(t, 1, rs.ReadQuorum(3))
+		assert.Equal(t, 1, rs.ReadQuorum(4))
+	})
+
+	t.Run("should count distinct members only", func(t *testing.T) {
+		rs := ReplicaSet{Primary: "jg1", Secondaries: []string{"jg2", "jg3"}}
+
+		assert.True(t, rs.HasQuorum([]string{"jg1", "jg3"}, 2))
+		assert.False(t, rs.HasQuorum([]string{"jg1", "jg1"}, 2))
+		assert.False(t, rs.HasQuorum([]string{"jg1", "jg4"}, 2))
+		assert.True(t, rs.HasQuorum(nil, 0))
+	})
+}
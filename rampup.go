@@ -0,0 +1,190 @@
+package rendezvous
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// nodeJoin is when a node joined the skeleton.
+type nodeJoin struct {
+	at    time.Time
+	epoch uint64
+
+	// ramped is set once the node reached its full weight
+	ramped bool
+}
+
+// RampUp sets how long the weight of a node added to the skeleton ramps up
+// from zero to its full weight, so a new node with a cold cache takes its
+// share of the keys progressively. Lookups use the ramps of the last
+// refresh, RefreshRampUp or RunRampUp advance them over time. Nodes set into
+// a skeleton without nodes take their full weight at once.
+func RampUp(duration time.Duration) Option {
+	return func(o *Options) error {
+		if duration <= 0 {
+			return fmt.Errorf("%w: ramp up must be positive, got %v", ErrInvalidOption, duration)
+		}
+
+		o.rampUp = duration
+
+		return nil
+	}
+}
+
+// RampUpEpochs sets over how many epochs the weight of a node added to the
+// skeleton ramps up from zero to its full weight, the ramps advance with
+// every change of the topology. Combined with RampUp, a node takes its full
+// weight once both ramps are complete.
+func RampUpEpochs(epochs int) Option {
+	return func(o *Options) error {
+		if epochs < 1 {
+			return fmt.Errorf("%w: ramp up epochs must be at least 1, got %d", ErrInvalidOption, epochs)
+		}
+
+		o.rampUpEpochs = epochs
+
+		return nil
+	}
+}
+
+// Ramps returns the weight factor between 0 and 1 of the nodes still ramping
+// up, nodes at their full weight are omitted.
+func (sr *SkeletonRendezvous) Ramps() map[string]float64 {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+
+	ramps := make(map[string]float64, len(sr.ramps))
+
+	for node, factor := range sr.ramps {
+		ramps[node] = factor
+	}
+
+	return ramps
+}
+
+// RefreshRampUp advances the ramps of the nodes to the current time. Keys
+// moving to the ramping nodes notify the watchers like SetHealth.
+func (sr *SkeletonRendezvous) RefreshRampUp() {
+	sr.update(func() {
+		sr.refreshRamps()
+		sr.refreshBranchWeights()
+	})
+}
+
+// RunRampUp refreshes the ramps at every interval until the context is done,
+// it blocks so it is usually run in its own goroutine. It returns
+// ErrInvalidOption when the interval is not positive, otherwise the error of
+// the context once it is done.
+func (sr *SkeletonRendezvous) RunRampUp(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("%w: ramp up interval must be positive, got %v", ErrInvalidOption, interval)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			sr.RefreshRampUp()
+		}
+	}
+}
+
+// rampsUp reports whether the weight of new nodes ramps up.
+func (o Options) rampsUp() bool {
+	return o.rampUp > 0 || o.rampUpEpochs > 0
+}
+
+// refreshRamps records the nodes which joined and computes the weight factor
+// of the nodes ramping up, the caller must hold the lock.
+func (sr *SkeletonRendezvous) refreshRamps() {
+	if !sr.options.rampsUp() {
+		return
+	}
+
+	present := make(map[string]bool, len(sr.Nodes))
+
+	for _, node := range sr.Nodes {
+		present[node] = true
+	}
+
+	for node := range sr.joined {
+		if !present[node] {
+			delete(sr.joined, node)
+		}
+	}
+
+	// without a node left to take over the keys, joining nodes take their
+	// full weight at once.
+	established := len(sr.joined) > 0
+	now := sr.now()
+
+	if sr.joined == nil {
+		sr.joined = make(map[string]nodeJoin, len(sr.Nodes))
+	}
+
+	for _, node := range sr.Nodes {
+		if _, ok := sr.joined[node]; !ok {
+			sr.joined[node] = nodeJoin{at: now, epoch: sr.epoch, ramped: !established}
+		}
+	}
+
+	sr.ramps = nil
+
+	for node, join := range sr.joined {
+		if join.ramped {
+			continue
+		}
+
+		factor := sr.rampFactor(join, now)
+
+		if factor >= 1 {
+			join.ramped = true
+			sr.joined[node] = join
+
+			continue
+		}
+
+		if sr.ramps == nil {
+			sr.ramps = make(map[string]float64)
+		}
+
+		sr.ramps[node] = factor
+	}
+}
+
+// rampFactor returns the share of its weight a node has at the given time.
+func (sr *SkeletonRendezvous) rampFactor(join nodeJoin, now time.Time) float64 {
+	factor := 1.0
+
+	if sr.options.rampUp > 0 {
+		if elapsed := float64(now.Sub(join.at)) / float64(sr.options.rampUp); elapsed < factor {
+			factor = elapsed
+		}
+	}
+
+	if sr.options.rampUpEpochs > 0 {
+		if epochs := float64(sr.epoch-join.epoch) / float64(sr.options.rampUpEpochs); epochs < factor {
+			factor = epochs
+		}
+	}
+
+	if factor < 0 {
+		return 0
+	}
+
+	return factor
+}
+
+// nodeRamp returns the weight factor of the node, 1 once it ramped up.
+func (sr *SkeletonRendezvous) nodeRamp(node string) float64 {
+	if factor, ok := sr.ramps[node]; ok {
+		return factor
+	}
+
+	return 1
+}
@@ -0,0 +1,170 @@
+package rendezvous
+
+import (
+	"context"
+	"math/rand"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRampUp(t *testing.T) {
+	// random keys, sequential keys share a prefix which fnv spreads poorly
+	// over the nodes
+	random := rand.New(rand.NewSource(1))
+	keys := make([]string, 10000)
+
+	for i := range keys {
+		keys[i] = strconv.FormatUint(random.Uint64(), 36)
+	}
+
+	share := func(t *testing.T, sr *SkeletonRendezvous, node string) float64 {
+		nodes, err := sr.FindNodes(keys)
+		assert.NoError(t, err)
+
+		count := 0
+
+		for _, owner := range nodes {
+			if owner == node {
+				count++
+			}
+		}
+
+		return float64(count) / float64(len(keys))
+	}
+
+	newSkeleton := func(t *testing.T, now *time.Time, options ...Option) *SkeletonRendezvous {
+		sr, err := NewSkeletonRendezvous(options...)
+		assert.NoError(t, err)
+
+		sr.clock = func() time.Time {
+			return *now
+		}
+
+		return sr
+	}
+
+	t.Run("should reject invalid ramps", func(t *testing.T) {
+		_, err := NewSkeletonRendezvous(RampUp(0))
+		assert.ErrorIs(t, err, ErrInvalidOption)
+
+		_, err = NewSkeletonRendezvous(RampUpEpochs(0))
+		assert.ErrorIs(t, err, ErrInvalidOption)
+	})
+
+	t.Run("should give the first nodes their full weight", func(t *testing.T) {
+		now := time.Unix(0, 0)
+		sr := newSkeleton(t, &now, RampUp(time.Minute))
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		assert.Empty(t, sr.Ramps())
+	})
+
+	t.Run("should ramp up a new node over the duration", func(t *testing.T) {
+		now := time.Unix(0, 0)
+		sr := newSkeleton(t, &now, RampUp(time.Minute), ClusterSize(8))
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+		sr.AddNodes([]string{"jg5"})
+
+		assert.Equal(t, map[string]float64{"jg5": 0}, sr.Ramps())
+		assert.Zero(t, share(t, sr, "jg5"))
+
+		now = now.Add(30 * time.Second)
+
+		// the ramps only advance on a refresh.
+		assert.Zero(t, share(t, sr, "jg5"))
+
+		sr.RefreshRampUp()
+
+		half := share(t, sr, "jg5")
+
+		assert.Equal(t, map[string]float64{"jg5": 0.5}, sr.Ramps())
+		assert.Greater(t, half, 0.05)
+		assert.Less(t, half, 0.15)
+
+		now = now.Add(30 * time.Second)
+		sr.RefreshRampUp()
+
+		full := share(t, sr, "jg5")
+
+		assert.Empty(t, sr.Ramps())
+		assert.InDelta(t, 0.2, full, 0.03)
+	})
+
+	t.Run("should ramp up a new node over the epochs", func(t *testing.T) {
+		now := time.Unix(0, 0)
+		sr := newSkeleton(t, &now, RampUpEpochs(2), ClusterSize(8))
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+		sr.AddNodes([]string{"jg5"})
+
+		assert.Equal(t, map[string]float64{"jg5": 0}, sr.Ramps())
+
+		sr.AddNodes([]string{"jg6"})
+
+		assert.Equal(t, map[string]float64{"jg5": 0.5, "jg6": 0}, sr.Ramps())
+
+		sr.RemoveNodes([]string{"jg6"})
+
+		assert.Empty(t, sr.Ramps())
+		assert.InDelta(t, 0.2, share(t, sr, "jg5"), 0.03)
+	})
+
+	t.Run("should keep keys off a new cluster of ramping nodes", func(t *testing.T) {
+		now := time.Unix(0, 0)
+		sr := newSkeleton(t, &now, RampUp(time.Minute))
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+		sr.AddNodes([]string{"jg5", "jg6"})
+
+		assert.Len(t, sr.Clusters, 3)
+		assert.Zero(t, share(t, sr, "jg5"))
+		assert.Zero(t, share(t, sr, "jg6"))
+
+		now = now.Add(time.Minute)
+		sr.RefreshRampUp()
+
+		assert.Greater(t, share(t, sr, "jg5"), 0.05)
+		assert.Greater(t, share(t, sr, "jg6"), 0.05)
+	})
+
+	t.Run("should restore the ramp up of a snapshot", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(RampUp(time.Minute), RampUpEpochs(3))
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+		sr.AddNodes([]string{"jg5"})
+
+		data, err := sr.MarshalJSON()
+		assert.NoError(t, err)
+
+		restored, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		assert.NoError(t, restored.UnmarshalJSON(data))
+		assert.True(t, sr.options.equal(restored.options))
+		assert.Empty(t, restored.Ramps())
+	})
+
+	t.Run("should refresh in background until context is done", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(RampUp(time.Minute))
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		assert.ErrorIs(t, sr.RunRampUp(ctx, time.Millisecond), context.Canceled)
+	})
+
+	t.Run("should reject non positive refresh interval", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(RampUp(time.Minute))
+		assert.NoError(t, err)
+
+		assert.ErrorIs(t, sr.RunRampUp(context.Background(), 0), ErrInvalidOption)
+		assert.ErrorIs(t, sr.RunRampUp(context.Background(), -time.Second), ErrInvalidOption)
+	})
+}
@@ -0,0 +1,299 @@
+package rendezvous
+
+import (
+	"container/heap"
+	"fmt"
+	"sort"
+)
+
+// rankedNode is a node along with its rendezvous score for a key
+type rankedNode struct {
+	node  string
+	score float64
+}
+
+// FindNodeAt find the node with the k-th highest score for the key in the
+// selected cluster, k = 0 is the node selected by FindNode.
+func (sr *SkeletonRendezvous) FindNodeAt(key string, k int) (string, error) {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+
+	nodes, err := sr.findClusterNodes(key)
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(nodes) == 0 {
+		return "", ErrClusterEmpty
+	}
+
+	if k < 0 || k >= len(nodes) {
+		return "", fmt.Errorf("%w: rank %d is out of range for cluster of %d nodes", ErrNodeNotFound, k, len(nodes))
+	}
+
+	ranked := sr.topNodes(key, nodes, k+1)
+
+	return ranked[k].node, nil
+}
+
+// FindN find up to n nodes for the key ordered by preference, such as a
+// primary followed by its backups. The first node is the node selected by
+// FindNode, unless it is moved by bounded load. The nodes of the selected
+// cluster come first ordered by score, followed by the nodes of the sibling
+// clusters in the order the branch walk prefers them. A pinned key starts
+// with its node, and the nodes which are draining or down are skipped.
+//
+// The order of two nodes only depends on their scores for the key, so it
+// holds while the key keeps its cluster and both nodes keep theirs: a node
+// joining or leaving only takes or frees its own rank. AddNodes keeps the
+// clusters until a new cluster is created, RemoveNodes keeps them with
+// StableClusterCount until a cluster is emptied. Otherwise the clusters are
+// generated again and the order may change.
+func (sr *SkeletonRendezvous) FindN(key string, n int) ([]string, error) {
+	var events []LookupEvent
+
+	// deferred first, the observers are notified once the lock is released.
+	defer func() { sr.notifyLookups(events) }()
+
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+
+	if n < 1 {
+		return nil, fmt.Errorf("%w: n must be at least 1, got %d", ErrInvalidOption, n)
+	}
+
+	start := sr.lookupStart()
+	nodes, err := sr.preferredNodes(key, n, sr.unavailableNodes())
+	sr.recordLookup(&events, key, firstNode(nodes), err, start)
+
+	return nodes, err
+}
+
+// firstNode returns the first of the nodes, empty when there is none.
+func firstNode(nodes []string) string {
+	if len(nodes) == 0 {
+		return ""
+	}
+
+	return nodes[0]
+}
+
+// preferredNodes returns up to n nodes for the key in the order of FindN,
+// ignoring the unavailable nodes.
+func (sr *SkeletonRendezvous) preferredNodes(key string, n int, unavailable map[string]struct{}) ([]string, error) {
+	order, err := sr.clusterOrder(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]string, 0, n)
+
+	pinned, ok := sr.pinnedNode(key, unavailable)
+
+	if ok {
+		nodes = append(nodes, pinned)
+	}
+
+	for _, clusterIndex := range order {
+		if len(nodes) == n {
+			break
+		}
+
+		candidates := make([]string, 0, len(sr.Clusters[clusterIndex]))
+
+		for _, node := range sr.Clusters[clusterIndex] {
+			if _, ok := unavailable[node]; !ok && node != pinned {
+				candidates = append(candidates, node)
+			}
+		}
+
+		for _, ranked := range sr.topNodes(key, candidates, n-len(nodes)) {
+			nodes = append(nodes, ranked.node)
+		}
+	}
+
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("%w: every node is unavailable", ErrNoNodes)
+	}
+
+	return nodes, nil
+}
+
+// clusterOrder returns the index of every cluster in the order the branch
+// walk prefers them for the key, starting with the cluster selected by
+// findCluster. The walk descends into the branches of each level from the
+// highest score, a cluster reached through several branch positions keeps
+// the first one.
+func (sr *SkeletonRendezvous) clusterOrder(key string) ([]int, error) {
+	clusterIndex, err := sr.findCluster(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	order := append(make([]int, 0, len(sr.Clusters)), clusterIndex)
+	seen := make([]bool, len(sr.Clusters))
+	seen[clusterIndex] = true
+
+	var walk func(level int, position int)
+
+	walk = func(level int, position int) {
+		if len(order) == len(sr.Clusters) {
+			return
+		}
+
+		if level == sr.VirtualNodes {
+			index := sr.lookupClusterIndex(position)
+
+			if index >= 0 && index < len(sr.Clusters) && !seen[index] {
+				order = append(order, index)
+				seen[index] = true
+			}
+
+			return
+		}
+
+		for _, branch := range sr.branchOrder(key, level, position) {
+			walk(level+1, position*sr.options.fanOut+branch)
+		}
+	}
+
+	walk(0, 0)
+
+	return order, nil
+}
+
+// branchOrder returns the branches of the given level ordered from the
+// branch selectBranch chooses, position is the branch position chosen so
+// far.
+func (sr *SkeletonRendezvous) branchOrder(key string, level int, position int) []int {
+	type scoredBranch struct {
+		branch int
+		rank   uint64
+		score  float64
+		tie    uint64
+	}
+
+	weighted := sr.branchWeights != nil
+	span := 0
+
+	if weighted {
+		span = sr.subtreeSpan(level)
+	}
+
+	scored := make([]scoredBranch, sr.options.fanOut)
+
+	for j := range scored {
+		hashScore := sr.rank(sr.hashBranch(level, j, key))
+		scored[j] = scoredBranch{branch: j, rank: hashScore, tie: branchTieBreak(hashScore, j)}
+
+		if weighted {
+			scored[j].score = weightedScore(hashScore, sr.subtreeWeight(span, position, j))
+		}
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		if weighted && scored[i].score != scored[j].score {
+			return scored[i].score > scored[j].score
+		}
+
+		if !weighted && scored[i].rank != scored[j].rank {
+			return scored[i].rank > scored[j].rank
+		}
+
+		return scored[i].tie > scored[j].tie
+	})
+
+	branches := make([]int, len(scored))
+
+	for i, branch := range scored {
+		branches[i] = branch.branch
+	}
+
+	return branches
+}
+
+// rankNodes returns the nodes ordered from the highest score for the key,
+// nodes with equal score are ordered by id.
+func (sr *SkeletonRendezvous) rankNodes(key string, nodes []string) []rankedNode {
+	ranked := make([]rankedNode, 0, len(nodes))
+
+	for _, node := range nodes {
+		ranked = append(ranked, rankedNode{node: node, score: sr.scoreNode(node, key)})
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		return outranks(ranked[i].node, ranked[i].score, ranked[j].node, ranked[j].score)
+	})
+
+	return ranked
+}
+
+// topNodes returns the k nodes with the highest score for the key ordered
+// like rankNodes, keeping only k nodes in a heap instead of sorting every
+// node.
+func (sr *SkeletonRendezvous) topNodes(key string, nodes []string, k int) []rankedNode {
+	if k >= len(nodes) {
+		return sr.rankNodes(key, nodes)
+	}
+
+	top := make(lowestRanked, 0, k+1)
+
+	for _, node := range nodes {
+		ranked := rankedNode{node: node, score: sr.scoreNode(node, key)}
+
+		if len(top) < k {
+			heap.Push(&top, ranked)
+
+			continue
+		}
+
+		if outranks(ranked.node, ranked.score, top[0].node, top[0].score) {
+			top[0] = ranked
+			heap.Fix(&top, 0)
+		}
+	}
+
+	// popping yields the lowest ranked node first
+	ranked := make([]rankedNode, len(top))
+
+	for i := len(ranked) - 1; i >= 0; i-- {
+		ranked[i] = heap.Pop(&top).(rankedNode)
+	}
+
+	return ranked
+}
+
+// lowestRanked is a heap of ranked nodes with the lowest ranked node on top.
+type lowestRanked []rankedNode
+
+func (h lowestRanked) Len() int { return len(h) }
+
+func (h lowestRanked) Less(i, j int) bool {
+	return outranks(h[j].node, h[j].score, h[i].node, h[i].score)
+}
+
+func (h lowestRanked) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *lowestRanked) Push(x any) { *h = append(*h, x.(rankedNode)) }
+
+func (h *lowestRanked) Pop() any {
+	old := *h
+	last := old[len(old)-1]
+	*h = old[:len(old)-1]
+
+	return last
+}
+
+// outranks reports whether the node is preferred over the other node, ties
+// are broken by id so the order of two nodes never depends on the order or
+// the clusters they are given in.
+func outranks(node string, score float64, other string, otherScore float64) bool {
+	if score != otherScore {
+		return score > otherScore
+	}
+
+	return node < other
+}
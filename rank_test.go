@@ -0,0 +1,343 @@
+package rendezvous
+
+import (
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindNodeAt(t *testing.T) {
+	sr, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(3), MinClusterSize(2))
+
+	assert.NoError(t, err)
+
+	sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4", "jg5", "jg6"})
+
+	t.Run("should return primary node at rank 0", func(t *testing.T) {
+		for i := 0; i < 100; i++ {
+			key := "key-" + strconv.Itoa(i)
+
+			node, err := sr.FindNodeAt(key, 0)
+
+			assert.NoError(t, err)
+			assert.Equal(t, mustFindNode(t, sr, key), node)
+		}
+	})
+
+	t.Run("should return every node of the cluster once", func(t *testing.T) {
+		for i := 0; i < 100; i++ {
+			key := "key-" + strconv.Itoa(i)
+			cluster := sr.Clusters[clusterOf(sr, mustFindNode(t, sr, key))]
+
+			seen := make(map[string]bool)
+
+			for k := 0; k < len(cluster); k++ {
+				node, err := sr.FindNodeAt(key, k)
+
+				assert.NoError(t, err)
+				assert.Contains(t, cluster, node)
+				assert.False(t, seen[node])
+
+				seen[node] = true
+			}
+		}
+	})
+
+	t.Run("should match next best node when primary is excluded", func(t *testing.T) {
+		for i := 0; i < 100; i++ {
+			key := "key-" + strconv.Itoa(i)
+
+			second, err := sr.FindNodeAt(key, 1)
+			assert.NoError(t, err)
+
+			node, err := sr.FindNodeExcluding(key, map[string]struct{}{mustFindNode(t, sr, key): {}})
+			assert.NoError(t, err)
+
+			assert.Equal(t, second, node)
+		}
+	})
+
+	t.Run("should return error when rank is out of range", func(t *testing.T) {
+		_, err := sr.FindNodeAt("key", 3)
+		assert.Error(t, err)
+
+		_, err = sr.FindNodeAt("key", -1)
+		assert.Error(t, err)
+	})
+}
+
+func TestTopNodes(t *testing.T) {
+	t.Run("should equal the highest ranked nodes of the full ranking", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		nodes := make([]string, 0, 50)
+
+		for i := 0; i < 50; i++ {
+			nodes = append(nodes, "jg"+strconv.Itoa(i))
+		}
+
+		for i := 0; i < 20; i++ {
+			key := "key-" + strconv.Itoa(i)
+			ranked := sr.rankNodes(key, nodes)
+
+			for _, k := range []int{1, 2, 7, 49, 50, 60} {
+				expected := ranked
+
+				if k < len(ranked) {
+					expected = ranked[:k]
+				}
+
+				assert.Equal(t, expected, sr.topNodes(key, nodes, k), "key %s top %d", key, k)
+			}
+		}
+	})
+}
+
+func TestFindN(t *testing.T) {
+	sr, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(2), MinClusterSize(2))
+
+	assert.NoError(t, err)
+
+	sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4", "jg5", "jg6"})
+
+	t.Run("should return primary followed by distinct backups", func(t *testing.T) {
+		for i := 0; i < 100; i++ {
+			key := "key-" + strconv.Itoa(i)
+
+			nodes, err := sr.FindN(key, 4)
+
+			assert.NoError(t, err)
+			assert.Equal(t, 4, len(nodes))
+			assert.Equal(t, mustFindNode(t, sr, key), nodes[0])
+
+			second, err := sr.FindNodeAt(key, 1)
+			assert.NoError(t, err)
+			assert.Equal(t, second, nodes[1])
+
+			seen := make(map[string]bool)
+
+			for _, node := range nodes {
+				assert.False(t, seen[node])
+				seen[node] = true
+			}
+
+			for _, node := range nodes[2:] {
+				assert.NotEqual(t, clusterOf(sr, nodes[0]), clusterOf(sr, node))
+			}
+		}
+	})
+
+	t.Run("should order the sibling clusters like the branch walk", func(t *testing.T) {
+		for i := 0; i < 100; i++ {
+			key := "key-" + strconv.Itoa(i)
+
+			order, err := sr.clusterOrder(key)
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, []int{0, 1, 2}, order)
+
+			nodes, err := sr.FindN(key, 6)
+			assert.NoError(t, err)
+
+			for j, node := range nodes {
+				assert.Equal(t, order[j/2], clusterOf(sr, node), "key %s rank %d", key, j)
+			}
+		}
+	})
+
+	t.Run("should return every node when n exceeds node count", func(t *testing.T) {
+		nodes, err := sr.FindN("key", 10)
+
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, sr.Nodes, nodes)
+	})
+
+	t.Run("should return error for invalid n or empty skeleton", func(t *testing.T) {
+		_, err := sr.FindN("key", 0)
+		assert.ErrorIs(t, err, ErrInvalidOption)
+
+		empty, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		_, err = empty.FindN("key", 1)
+		assert.ErrorIs(t, err, ErrNoNodes)
+	})
+}
+
+func TestFindNPrimary(t *testing.T) {
+	nodes := []string{"n0", "n1", "n2", "n3", "n4", "n5", "n6", "n7"}
+
+	// assertPrimary asserts the first node of FindN and Iter is the node
+	// selected by FindNode.
+	assertPrimary := func(t *testing.T, sr *SkeletonRendezvous) {
+		for i := 0; i < 1000; i++ {
+			key := "key-" + strconv.Itoa(i)
+			node := mustFindNode(t, sr, key)
+
+			ranked, err := sr.FindN(key, 3)
+			assert.NoError(t, err)
+			assert.Equal(t, node, ranked[0], "key %s", key)
+
+			first, ok := sr.Iter(key).Next()
+			assert.True(t, ok)
+			assert.Equal(t, node, first, "key %s", key)
+		}
+	}
+
+	t.Run("should start with the node selected by FindNode", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(ClusterSize(2))
+		assert.NoError(t, err)
+
+		sr.SetNodes(nodes)
+
+		assertPrimary(t, sr)
+	})
+
+	t.Run("should skip the nodes which are down", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(ClusterSize(2))
+		assert.NoError(t, err)
+
+		sr.SetNodes(nodes)
+		sr.MarkDown("n0", "n1", "n2", "n3")
+
+		assertPrimary(t, sr)
+
+		for i := 0; i < 100; i++ {
+			ranked, err := sr.FindN("key-"+strconv.Itoa(i), len(nodes))
+
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, nodes[4:], ranked)
+		}
+	})
+
+	t.Run("should start with the pinned node", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(ClusterSize(2))
+		assert.NoError(t, err)
+
+		sr.SetNodes(nodes)
+
+		for i := 0; i < 10; i++ {
+			assert.NoError(t, sr.PinKey("key-"+strconv.Itoa(i), "n7"))
+		}
+
+		assertPrimary(t, sr)
+
+		for i := 0; i < 10; i++ {
+			ranked, err := sr.FindN("key-"+strconv.Itoa(i), len(nodes))
+
+			assert.NoError(t, err)
+			assert.Equal(t, "n7", ranked[0])
+			assert.ElementsMatch(t, nodes, ranked)
+		}
+	})
+
+	t.Run("should follow the cluster weights", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(ClusterSize(2))
+		assert.NoError(t, err)
+
+		sr.SetNodes(nodes)
+		sr.SetClusterWeights([]float64{4, 1, 0, 2})
+
+		assertPrimary(t, sr)
+	})
+
+	t.Run("should return error when every node is down", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(ClusterSize(2))
+		assert.NoError(t, err)
+
+		sr.SetNodes(nodes[:2])
+		sr.MarkDown(nodes[:2]...)
+
+		_, err = sr.FindN("key", 1)
+		assert.ErrorIs(t, err, ErrNoNodes)
+	})
+}
+
+func TestFindNStableOrder(t *testing.T) {
+	// without removes the node from the nodes, keeping the order of the
+	// others.
+	without := func(nodes []string, node string) []string {
+		others := make([]string, 0, len(nodes))
+
+		for _, other := range nodes {
+			if other != node {
+				others = append(others, other)
+			}
+		}
+
+		return others
+	}
+
+	nodes := []string{"jg1", "jg2", "jg3", "jg4", "jg5", "jg6", "jg7", "jg8", "jg9"}
+
+	t.Run("should keep the order of the other nodes when a node leaves", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(ClusterSize(3), MinClusterSize(2), StableClusterCount(true))
+		assert.NoError(t, err)
+
+		sr.SetNodes(nodes)
+
+		for _, removed := range nodes {
+			staged := sr.Clone()
+			staged.RemoveNodes([]string{removed})
+
+			for i := 0; i < 100; i++ {
+				key := "key-" + strconv.Itoa(i)
+
+				before, err := sr.FindN(key, len(nodes))
+				assert.NoError(t, err)
+
+				after, err := staged.FindN(key, len(nodes))
+				assert.NoError(t, err)
+
+				assert.Equal(t, without(before, removed), after, "key %s without %s", key, removed)
+			}
+		}
+	})
+
+	t.Run("should keep the order of the other nodes when a node joins", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(ClusterSize(3), MinClusterSize(2))
+		assert.NoError(t, err)
+
+		sr.SetNodes(nodes[:8])
+
+		staged := sr.Clone()
+		staged.AddNodes([]string{"jg9"})
+
+		assert.Equal(t, len(sr.Clusters), len(staged.Clusters))
+
+		for i := 0; i < 100; i++ {
+			key := "key-" + strconv.Itoa(i)
+
+			before, err := sr.FindN(key, len(nodes))
+			assert.NoError(t, err)
+
+			after, err := staged.FindN(key, len(nodes))
+			assert.NoError(t, err)
+
+			assert.Equal(t, before, without(after, "jg9"), "key %s", key)
+		}
+	})
+
+	t.Run("should order nodes with equal scores by id", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(ScoreFunc(func(node, key string) uint64 {
+			return 1 << 60
+		}))
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg4", "jg3", "jg2", "jg1"})
+
+		clusterIndex, err := sr.findCluster("key")
+		assert.NoError(t, err)
+
+		cluster := append([]string(nil), sr.Clusters[clusterIndex]...)
+		sort.Strings(cluster)
+
+		ranked, err := sr.FindN("key", len(cluster))
+
+		assert.NoError(t, err)
+		assert.Equal(t, cluster, ranked)
+		assert.Equal(t, cluster[0], mustFindNode(t, sr, "key"))
+	})
+}
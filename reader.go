@@ -0,0 +1,35 @@
+package rendezvous
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// SetNodesFromReader reads newline delimited nodes from the reader and set
+// them into cluster like SetNodes. Whitespace around each line is trimmed,
+// blank lines and lines starting with # are skipped. It returns the number
+// of nodes read, the nodes are not set when reading fails.
+func (sr *SkeletonRendezvous) SetNodesFromReader(r io.Reader) (int, error) {
+	nodes := make([]string, 0)
+
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		nodes = append(nodes, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+
+	sr.SetNodes(nodes)
+
+	return len(nodes), nil
+}
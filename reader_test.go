@@ -0,0 +1,41 @@
+package rendezvous
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetNodesFromReader(t *testing.T) {
+	t.Run("should set nodes skipping blanks and comments", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(2), MinClusterSize(2))
+
+		assert.NoError(t, err)
+
+		input := "# inventory\njg1\n  jg2  \n\n\tjg3\n# jg9\njg4\n"
+
+		count, err := sr.SetNodesFromReader(strings.NewReader(input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 4, count)
+		assert.Equal(t, []string{"jg1", "jg2", "jg3", "jg4"}, sr.Nodes)
+		assert.Equal(t, 2, len(sr.Clusters))
+	})
+
+	t.Run("should return error when reading fails", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		readErr := errors.New("connection reset")
+
+		count, err := sr.SetNodesFromReader(iotest.ErrReader(readErr))
+
+		assert.ErrorIs(t, err, readErr)
+		assert.Equal(t, 0, count)
+		assert.Empty(t, sr.Nodes)
+	})
+}
@@ -0,0 +1,171 @@
+// Package redisroute shards keys across standalone Redis servers on the
+// client side with the skeleton rendezvous. The router is instantiated with
+// the client type, such as *redis.Client of go-redis:
+//
+//	router := redisroute.NewRouter(sr, map[string]*redis.Client{
+//		"10.0.0.1:6379": redis.NewClient(&redis.Options{Addr: "10.0.0.1:6379"}),
+//		"10.0.0.2:6379": redis.NewClient(&redis.Options{Addr: "10.0.0.2:6379"}),
+//	})
+//
+//	values, err := redisroute.FanOut(ctx, router, keys, func(ctx context.Context, client *redis.Client, keys []string) ([]interface{}, error) {
+//		return client.MGet(ctx, keys...).Result()
+//	})
+//
+// MSET and pipelines are split with Split, each batch holds the indexes of
+// the keys, or of the commands by key, sent to the client of a node.
+package redisroute
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+)
+
+// Router routes keys to the client of the node selected by the skeleton.
+type Router[C any] struct {
+	skeleton *rendezvous.SkeletonRendezvous
+	clients  map[string]C
+}
+
+// Batch is the keys routed to the client of a node.
+type Batch[C any] struct {
+	Node   string
+	Client C
+
+	// Indexes are the positions of the keys, in ascending order
+	Indexes []int
+}
+
+// NewRouter creates the router over the clients by node, the nodes of the
+// skeleton are synced with the nodes of the clients.
+func NewRouter[C any](sr *rendezvous.SkeletonRendezvous, clients map[string]C) *Router[C] {
+	nodes := make([]string, 0, len(clients))
+
+	for node := range clients {
+		nodes = append(nodes, node)
+	}
+
+	sort.Strings(nodes)
+	sr.SyncNodes(nodes)
+
+	return &Router[C]{
+		skeleton: sr,
+		clients:  clients,
+	}
+}
+
+// Client returns the client of the node owning the key.
+func (r *Router[C]) Client(key string) (C, error) {
+	var client C
+
+	node, err := r.skeleton.FindNode(key)
+
+	if err != nil {
+		return client, err
+	}
+
+	return r.client(node)
+}
+
+// Split groups the keys by the node owning them, the batches are ordered
+// by node.
+func (r *Router[C]) Split(keys []string) ([]Batch[C], error) {
+	indexes := make(map[string][]int)
+
+	for i, key := range keys {
+		node, err := r.skeleton.FindNode(key)
+
+		if err != nil {
+			return nil, err
+		}
+
+		indexes[node] = append(indexes[node], i)
+	}
+
+	batches := make([]Batch[C], 0, len(indexes))
+
+	for node, nodeIndexes := range indexes {
+		client, err := r.client(node)
+
+		if err != nil {
+			return nil, err
+		}
+
+		batches = append(batches, Batch[C]{Node: node, Client: client, Indexes: nodeIndexes})
+	}
+
+	sort.Slice(batches, func(i, j int) bool {
+		return batches[i].Node < batches[j].Node
+	})
+
+	return batches, nil
+}
+
+func (r *Router[C]) client(node string) (C, error) {
+	client, ok := r.clients[node]
+
+	if !ok {
+		return client, fmt.Errorf("%w: no client for node %s", rendezvous.ErrNodeNotFound, node)
+	}
+
+	return client, nil
+}
+
+// FanOut splits the keys by node and calls fn concurrently with the client
+// and keys of each node, such as MGET. fn returns a value per key and the
+// values are returned in the order of the keys. The first error is
+// returned.
+func FanOut[C any, V any](ctx context.Context, r *Router[C], keys []string, fn func(ctx context.Context, client C, keys []string) ([]V, error)) ([]V, error) {
+	batches, err := r.Split(keys)
+
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]V, len(keys))
+	errs := make([]error, len(batches))
+
+	var wg sync.WaitGroup
+
+	for i, batch := range batches {
+		wg.Add(1)
+
+		go func(i int, batch Batch[C]) {
+			defer wg.Done()
+
+			batchKeys := make([]string, len(batch.Indexes))
+
+			for j, index := range batch.Indexes {
+				batchKeys[j] = keys[index]
+			}
+
+			batchValues, err := fn(ctx, batch.Client, batchKeys)
+
+			if err == nil && len(batchValues) != len(batchKeys) {
+				err = fmt.Errorf("node %s returned %d values for %d keys", batch.Node, len(batchValues), len(batchKeys))
+			}
+
+			if err != nil {
+				errs[i] = err
+				return
+			}
+
+			for j, index := range batch.Indexes {
+				values[index] = batchValues[j]
+			}
+		}(i, batch)
+	}
+
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
@@ -0,0 +1,178 @@
+package redisroute
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+	"github.com/stretchr/testify/assert"
+)
+
+// client mirrors a standalone Redis client holding its own keys.
+type client struct {
+	mu     sync.Mutex
+	node   string
+	values map[string]string
+}
+
+func (c *client) mget(keys []string) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	values := make([]string, len(keys))
+
+	for i, key := range keys {
+		values[i] = c.values[key]
+	}
+
+	return values
+}
+
+func TestRouter(t *testing.T) {
+	nodes := []string{"10.0.0.1:6379", "10.0.0.2:6379", "10.0.0.3:6379"}
+	keys := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+
+	t.Run("should route keys to the client of their node", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		clients := make(map[string]*client)
+
+		for _, node := range nodes {
+			clients[node] = &client{node: node, values: make(map[string]string)}
+		}
+
+		router := NewRouter(sr, clients)
+
+		assert.Equal(t, 3, sr.Stats().Nodes)
+
+		for _, key := range keys {
+			node, err := sr.FindNode(key)
+
+			assert.NoError(t, err)
+
+			c, err := router.Client(key)
+
+			assert.NoError(t, err)
+			assert.Equal(t, node, c.node)
+		}
+	})
+
+	t.Run("should split keys by node", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		clients := make(map[string]*client)
+
+		for _, node := range nodes {
+			clients[node] = &client{node: node, values: make(map[string]string)}
+		}
+
+		router := NewRouter(sr, clients)
+
+		batches, err := router.Split(keys)
+
+		assert.NoError(t, err)
+
+		count := 0
+
+		for i, batch := range batches {
+			if i > 0 {
+				assert.Less(t, batches[i-1].Node, batch.Node)
+			}
+
+			assert.Equal(t, batch.Node, batch.Client.node)
+
+			for _, index := range batch.Indexes {
+				node, err := sr.FindNode(keys[index])
+
+				assert.NoError(t, err)
+				assert.Equal(t, batch.Node, node)
+			}
+
+			count += len(batch.Indexes)
+		}
+
+		assert.Equal(t, len(keys), count)
+	})
+
+	t.Run("should fan out and keep the order of keys", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		clients := make(map[string]*client)
+
+		for _, node := range nodes {
+			clients[node] = &client{node: node, values: make(map[string]string)}
+		}
+
+		router := NewRouter(sr, clients)
+
+		batches, err := router.Split(keys)
+
+		assert.NoError(t, err)
+
+		for _, batch := range batches {
+			for _, index := range batch.Indexes {
+				batch.Client.values[keys[index]] = "value-" + keys[index]
+			}
+		}
+
+		values, err := FanOut(context.Background(), router, keys, func(ctx context.Context, c *client, keys []string) ([]string, error) {
+			return c.mget(keys), nil
+		})
+
+		assert.NoError(t, err)
+
+		for i, key := range keys {
+			assert.Equal(t, "value-"+key, values[i])
+		}
+	})
+
+	t.Run("should return the error of a node", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		clients := make(map[string]*client)
+
+		for _, node := range nodes {
+			clients[node] = &client{node: node, values: make(map[string]string)}
+		}
+
+		router := NewRouter(sr, clients)
+
+		errDown := errors.New("down")
+
+		_, err = FanOut(context.Background(), router, keys, func(ctx context.Context, c *client, keys []string) ([]string, error) {
+			return nil, errDown
+		})
+
+		assert.ErrorIs(t, err, errDown)
+	})
+
+	t.Run("should return error for node without client", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		clients := make(map[string]*client)
+
+		for _, node := range nodes {
+			clients[node] = &client{node: node, values: make(map[string]string)}
+		}
+
+		router := NewRouter(sr, clients)
+
+		sr.SyncNodes([]string{"10.0.0.4:6379"})
+
+		_, err = router.Client("a")
+
+		assert.ErrorIs(t, err, rendezvous.ErrNodeNotFound)
+	})
+}
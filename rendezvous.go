@@ -1,14 +1,37 @@
 package rendezvous
 
 import (
+	"fmt"
 	"hash"
 	"hash/fnv"
-	"math"
-	"strconv"
+	"sort"
+	"sync"
+	"time"
 )
 
 type Option func(*Options) error
 
+// OverflowPolicy decides which cluster a branch position beyond the last
+// cluster is mapped to, since fanOut^VirtualNodes is usually bigger than
+// the number of clusters.
+type OverflowPolicy int
+
+const (
+	// WrapModulo maps the branch position into cluster position % clusters,
+	// spreading overflowing positions evenly from the first cluster
+	WrapModulo OverflowPolicy = iota
+
+	// ClampLast maps every overflowing branch position into the last cluster
+	ClampLast
+
+	// BalanceOverflow maps the branch position like WrapModulo, but weighs
+	// the branches so every cluster receives keys in proportion to its
+	// nodes, no matter how many positions wrap onto it. Without weights
+	// WrapModulo gives the first clusters twice the keys of the others
+	// when only some positions wrap.
+	BalanceOverflow
+)
+
 // Options can be used to create a customized configuration
 // rendezvous skeleton based.
 type Options struct {
@@ -19,11 +42,88 @@ type Options struct {
 	// Hash is algorithm that will be used for hashing key
 	hash hash.Hash64
 
+	// HashFunc is the stateless hash function, preferred over Hash
+	hashFunc func([]byte) uint64
+
+	// ScoreFunc replaces the hash score of a node for a key
+	scoreFunc func(node string, key string) uint64
+
+	// NewHash creates instances of Hash pooled for concurrent lookups,
+	// preferred over the single Hash
+	newHash func() hash.Hash64
+
+	// Seed is mixed into every hash, skeletons with different seeds place
+	// the same keys independently
+	seed uint64
+
+	// HashName is the registered name of the hash algorithm
+	hashName string
+
 	// ClusterSize is number of nodes to be filled in a cluster
 	clusterSize int
 
 	// MinClusterSize the minimum number of nodes that must exist in a cluster
 	minClusterSize int
+
+	// Replicas is number of virtual copies of each node scored in a cluster
+	replicas int
+
+	// SelectMin selects the lowest hash score instead of the highest
+	selectMin bool
+
+	// StableClusterCount keeps the number of clusters when removing nodes
+	stableClusterCount bool
+
+	// OverflowPolicy maps branch positions beyond the last cluster
+	overflowPolicy OverflowPolicy
+
+	// BoundedLoad caps the number of keys assigned to a node
+	boundedLoad bool
+
+	// LoadEpsilon is how much a node may exceed the average load
+	loadEpsilon float64
+
+	// HashedAssignment places nodes into clusters by the hash of their ID
+	// instead of the order they are given
+	hashedAssignment bool
+
+	// Placement is the policy to place nodes into clusters
+	placement PlacementPolicy
+
+	// ZoneLabel is the node label holding the zone of a node
+	zoneLabel string
+
+	// NodeTTL is how long a node stays registered without a heartbeat
+	nodeTTL time.Duration
+
+	// LookupCacheSize is how many lookup results are memoized
+	lookupCacheSize int
+
+	// Observers are notified of lookups and topology changes
+	observers []Observer
+
+	// DisableRedistribution keeps an undersized last cluster instead of
+	// spreading its nodes into the other clusters
+	disableRedistribution bool
+
+	// Strategy assigns the nodes into clusters instead of the placement
+	// policy
+	strategy Strategy
+
+	// Depth is the pinned depth of the branch tree, zero derives the depth
+	// from the number of clusters
+	depth int
+
+	// RampUp is how long the weight of a new node ramps up to its full
+	// weight
+	rampUp time.Duration
+
+	// RampUpEpochs is over how many epochs the weight of a new node ramps
+	// up to its full weight
+	rampUpEpochs int
+
+	// EpochHistory is how many past epochs are kept for PreviousOwner
+	epochHistory int
 }
 
 // GetDefaultOptions returns default configuration options
@@ -32,14 +132,25 @@ func GetDefaultOptions() Options {
 	return Options{
 		fanOut:         3,
 		hash:           fnv.New64(),
+		newHash:        fnv.New64,
+		hashName:       "fnv64",
 		clusterSize:    2,
 		minClusterSize: 2,
+		replicas:       1,
+		overflowPolicy: WrapModulo,
+		zoneLabel:      DefaultZoneLabel,
 	}
 }
 
 // FanOut sets the number of fan out for spread data into virtual node.
+// The fan out must be at least 2, a single branch can not spread keys
+// across clusters.
 func FanOut(fanOut int) Option {
 	return func(o *Options) error {
+		if fanOut < 2 {
+			return fmt.Errorf("%w: fan out must be at least 2, got %d", ErrInvalidOption, fanOut)
+		}
+
 		o.fanOut = fanOut
 
 		return nil
@@ -47,19 +158,108 @@ func FanOut(fanOut int) Option {
 }
 
 // HashAlgorithm sets the algorithm type that will be used to hash the score.
+// The hash is owned by the skeleton and its clones, it must not be given to
+// another skeleton or used elsewhere, share HashFactory or HashFunc instead.
+//
+// Deprecated: a hash.Hash64 is stateful and every lookup has to lock it, use
+// HashFunc instead.
 func HashAlgorithm(hash hash.Hash64) Option {
 	return func(o *Options) error {
+		if hash == nil {
+			return fmt.Errorf("%w: hash algorithm is nil", ErrInvalidOption)
+		}
+
 		o.hash = hash
+		o.hashFunc = nil
+		o.newHash = nil
+		o.hashName = hashAlgorithmName(hash)
 
 		return nil
 	}
 }
 
-// ClusterSize sets the amount of cluster each fan out.
+// HashFactory sets the constructor of the hash algorithm, each concurrent
+// lookup hashes with its own instance taken from a pool instead of locking
+// a single instance.
+func HashFactory(newHash func() hash.Hash64) Option {
+	return func(o *Options) error {
+		if newHash == nil {
+			return fmt.Errorf("%w: hash factory is nil", ErrInvalidOption)
+		}
+
+		o.hash = newHash()
+		o.hashFunc = nil
+		o.newHash = newHash
+		o.hashName = hashAlgorithmName(o.hash)
+
+		return nil
+	}
+}
+
+// HashFunc sets the stateless function that will be used to hash the score,
+// it must be safe to call from multiple goroutines. Lookups through a hash
+// function neither lock nor allocate.
+func HashFunc(hash func([]byte) uint64) Option {
+	return func(o *Options) error {
+		if hash == nil {
+			return fmt.Errorf("%w: hash function is nil", ErrInvalidOption)
+		}
+
+		o.hashFunc = hash
+		o.hash = nil
+		o.newHash = nil
+		o.hashName = ""
+
+		return nil
+	}
+}
+
+// ScoreFunc sets the function scoring a node for a key within the cluster
+// selected by the branch walk, replacing the hash of the node and its
+// replicas, such as to blend in locality or an existing scoring scheme. The
+// score is still scaled by the node weight and health, and the lowest score
+// wins with SelectMin. Scores are compared by their top 53 bits, so they
+// should span the range of a hash. It must be deterministic and safe to
+// call from multiple goroutines.
+func ScoreFunc(score func(node string, key string) uint64) Option {
+	return func(o *Options) error {
+		if score == nil {
+			return fmt.Errorf("%w: score function is nil", ErrInvalidOption)
+		}
+
+		o.scoreFunc = score
+
+		return nil
+	}
+}
+
+// Seed sets the seed mixed into every hash. Skeletons over the same nodes
+// with the same seed place keys identically, while different seeds give
+// independent placements. The zero seed is the unseeded placement.
+func Seed(seed uint64) Option {
+	return func(o *Options) error {
+		o.seed = seed
+
+		return nil
+	}
+}
+
+// ClusterSize sets the amount of cluster each fan out. A minimum cluster
+// size bigger than the cluster size, such as the default of 2 for a cluster
+// size of 1, is lowered to it, MinClusterSize given afterwards must not
+// exceed it.
 func ClusterSize(size int) Option {
 	return func(o *Options) error {
+		if size < 1 {
+			return fmt.Errorf("%w: cluster size must be at least 1, got %d", ErrInvalidOption, size)
+		}
+
 		o.clusterSize = size
 
+		if o.minClusterSize > size {
+			o.minClusterSize = size
+		}
+
 		return nil
 	}
 }
@@ -67,20 +267,217 @@ func ClusterSize(size int) Option {
 // MinClusterSize sets the minimun data in the cluster.
 func MinClusterSize(size int) Option {
 	return func(o *Options) error {
+		if size < 1 {
+			return fmt.Errorf("%w: minimum cluster size must be at least 1, got %d", ErrInvalidOption, size)
+		}
+
 		o.minClusterSize = size
 
 		return nil
 	}
 }
 
+// DisableRedistribution sets whether the last cluster which has less nodes
+// than the minimum cluster size is kept as is instead of being spread.
+func DisableRedistribution(disable bool) Option {
+	return func(o *Options) error {
+		o.disableRedistribution = disable
+
+		return nil
+	}
+}
+
+// maxPinnedPositions bounds the branch positions of a pinned depth, the
+// positions are precomputed into tables when the topology changes.
+const maxPinnedPositions = 1 << 16
+
+// Depth pins the depth of the branch tree, instead of the smallest depth
+// able to address every cluster. A deeper tree spends more hashes per lookup
+// to spread keys over more branch positions, and keeps the depth stable while
+// the number of clusters changes. The depth only grows beyond the pinned one
+// when there are more clusters than its branches can address.
+func Depth(depth int) Option {
+	return func(o *Options) error {
+		if depth < 1 {
+			return fmt.Errorf("%w: depth must be at least 1, got %d", ErrInvalidOption, depth)
+		}
+
+		o.depth = depth
+
+		return nil
+	}
+}
+
+// Replicas sets the number of virtual copies of each node, a node is scored
+// as node#0, node#1, ... within its cluster and wins with its best copy.
+func Replicas(replicas int) Option {
+	return func(o *Options) error {
+		if replicas < 1 {
+			return fmt.Errorf("%w: replicas must be at least 1, got %d", ErrInvalidOption, replicas)
+		}
+
+		o.replicas = replicas
+
+		return nil
+	}
+}
+
+// SelectMin sets whether the branch and node with lowest hash score
+// are selected instead of the highest.
+func SelectMin(selectMin bool) Option {
+	return func(o *Options) error {
+		o.selectMin = selectMin
+
+		return nil
+	}
+}
+
+// StableClusterCount sets whether removing nodes keeps the existing clusters
+// and only removes the nodes from them, without backfilling clusters that
+// fall below the minimum cluster size. A cluster is dropped once all of its
+// nodes are removed.
+func StableClusterCount(stable bool) Option {
+	return func(o *Options) error {
+		o.stableClusterCount = stable
+
+		return nil
+	}
+}
+
+// HashedAssignment sets whether nodes are placed into clusters by the hash
+// of their ID instead of the order they are given, so identical node sets
+// always produce identical clusters regardless of their order.
+func HashedAssignment(enabled bool) Option {
+	return func(o *Options) error {
+		o.hashedAssignment = enabled
+
+		return nil
+	}
+}
+
+// Overflow sets the policy to map branch positions beyond the last cluster.
+func Overflow(policy OverflowPolicy) Option {
+	return func(o *Options) error {
+		if policy != WrapModulo && policy != ClampLast && policy != BalanceOverflow {
+			return fmt.Errorf("%w: unknown overflow policy %d", ErrInvalidOption, policy)
+		}
+
+		o.overflowPolicy = policy
+
+		return nil
+	}
+}
+
 // a SkeletonRendezvous represents list of cluster
-// that already process using rendezvous
+// that already process using rendezvous.
+//
+// A SkeletonRendezvous is safe for concurrent use by multiple goroutines,
+// the exported fields must only be read directly while no other goroutine
+// mutates the skeleton.
 type SkeletonRendezvous struct {
 	options Options
 
 	Clusters     [][]string
 	Nodes        []string
 	VirtualNodes int
+
+	clusterWeights []float64
+	branchWeights  []float64
+
+	health map[string]float64
+
+	nodeWeights map[string]float64
+
+	nodeInfo map[string]Node
+
+	loads map[string]int
+
+	watchers []*keyWatcher
+
+	topologyWatchers []*eventQueue[TopologyEvent]
+
+	states map[string]NodeState
+
+	// clusterIndexes maps every branch position of the walk into its
+	// cluster and spans holds how many positions are below a branch of each
+	// level, both are rebuilt with the topology
+	clusterIndexes []int
+	spans          []int
+
+	// hashedNodes caches the hashed form of every node, it is rebuilt with
+	// the topology and whenever the hash changes
+	hashedNodes map[string]hashedNode
+
+	// unavailable caches the nodes which are draining or down, so lookups
+	// do not build the set on every call
+	unavailable map[string]struct{}
+
+	deadlines map[string]time.Time
+
+	// pins are the keys forced onto a node
+	pins map[string]string
+
+	// joined records when each node joined while a ramp up is set, and
+	// ramps holds the weight factor of the nodes still ramping up
+	joined map[string]nodeJoin
+	ramps  map[string]float64
+
+	// history holds the frozen topologies of the past epochs, oldest first
+	history []*SkeletonRendezvous
+
+	clock func() time.Time
+
+	epoch uint64
+
+	hasher keyHasher
+
+	cache *lookupCache
+
+	// observers are copied from the options once, they never change
+	observers []Observer
+
+	// lookupObservers are the observers which observe lookups
+	lookupObservers []Observer
+
+	// notifications are the changes not delivered yet, delivering is set
+	// while a caller delivers them
+	notifications []notification
+	delivering    bool
+
+	mu     sync.RWMutex
+	loadMu sync.Mutex
+}
+
+// validate checks the combination of options, each option only checks its
+// own value.
+func (o Options) validate() error {
+	if o.minClusterSize > o.clusterSize {
+		return fmt.Errorf("%w: minimum cluster size %d is bigger than cluster size %d",
+			ErrInvalidOption, o.minClusterSize, o.clusterSize)
+	}
+
+	positions := 1
+
+	for i := 0; i < o.depth; i++ {
+		if positions *= o.fanOut; positions > maxPinnedPositions {
+			return fmt.Errorf("%w: depth %d with fan out %d addresses more than %d branch positions",
+				ErrInvalidOption, o.depth, o.fanOut, maxPinnedPositions)
+		}
+	}
+
+	return nil
+}
+
+// warnings reports the combinations of options which are valid but likely
+// not what was intended.
+func (o Options) warnings() []string {
+	var warnings []string
+
+	if o.lookupCacheSize > 0 && o.boundedLoad {
+		warnings = append(warnings, "lookup cache is bypassed with bounded load")
+	}
+
+	return warnings
 }
 
 func NewSkeletonRendezvous(options ...Option) (*SkeletonRendezvous, error) {
@@ -94,23 +491,148 @@ func NewSkeletonRendezvous(options ...Option) (*SkeletonRendezvous, error) {
 		}
 	}
 
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	for _, observer := range opts.observers {
+		if observer, ok := observer.(publishingObserver); ok {
+			if err := observer.publish(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	skeletonRendezvous := &SkeletonRendezvous{
 		options:      opts,
 		Clusters:     make([][]string, 0),
 		Nodes:        make([]string, 0),
 		VirtualNodes: 0,
+		hasher:       newHasher(opts),
+		cache:        newLookupCache(opts.lookupCacheSize),
+		observers:    opts.observers,
+	}
+
+	for _, observer := range opts.observers {
+		if observer, ok := observer.(optionsObserver); ok {
+			observer.observeOptions(opts, opts.warnings())
+		}
+
+		if topology, ok := observer.(topologyObserver); !ok || topology.observesLookups() {
+			skeletonRendezvous.lookupObservers = append(skeletonRendezvous.lookupObservers, observer)
+		}
 	}
 
 	return skeletonRendezvous, nil
 }
 
-// SetNodes set new nodes into cluster
+// SetNodes set new nodes into cluster, the clusters are generated
+// again from the existing nodes followed by the new nodes.
 func (sr *SkeletonRendezvous) SetNodes(nodes []string) {
-	sr.generateCluster(nodes)
+	sr.updateTopology(func() {
+		sr.setNodes(nodes)
+	})
+}
+
+func (sr *SkeletonRendezvous) setNodes(nodes []string) {
+	allNodes := make([]string, 0, len(sr.Nodes)+len(nodes))
+	allNodes = append(allNodes, sr.Nodes...)
+	allNodes = append(allNodes, nodes...)
+
+	sr.register(nodes)
+	sr.generateCluster(allNodes)
+}
+
+// SetClusters replace the skeleton topology with the given cluster layout
+// verbatim, bypassing cluster generation. Nodes is recomputed as the union
+// of all clusters, a node appearing more than once is only kept in the
+// first cluster it is found in. Clusters left without nodes are dropped.
+func (sr *SkeletonRendezvous) SetClusters(clusters [][]string) {
+	sr.updateTopology(func() {
+		sr.setClusters(clusters)
+	})
 }
 
-// RemoveNodes remove nodes from the cluster and generate new cluster
+func (sr *SkeletonRendezvous) setClusters(clusters [][]string) {
+	lookup := make(map[string]bool)
+
+	sr.Clusters = make([][]string, 0, len(clusters))
+	sr.Nodes = make([]string, 0)
+
+	for _, cluster := range clusters {
+		newCluster := make([]string, 0, len(cluster))
+
+		for _, node := range cluster {
+			if !lookup[node] {
+				newCluster = append(newCluster, node)
+				lookup[node] = true
+			}
+		}
+
+		if len(newCluster) == 0 {
+			continue
+		}
+
+		sr.Clusters = append(sr.Clusters, newCluster)
+		sr.Nodes = append(sr.Nodes, newCluster...)
+	}
+
+	sr.register(sr.Nodes)
+
+	sr.VirtualNodes = sr.countVirtualNodes(len(sr.Clusters), sr.options.fanOut)
+	sr.topologyChanged()
+}
+
+// SetHash replace the hash algorithm used for scoring, leaving Clusters and
+// Nodes untouched. Every key may be placed on a different node afterwards,
+// so any placement persisted with the previous algorithm is invalidated. The
+// hash is owned by the skeleton like with HashAlgorithm, a nil hash is
+// ignored.
+//
+// Deprecated: use SetHashFunc instead.
+func (sr *SkeletonRendezvous) SetHash(hash hash.Hash64) {
+	if hash == nil {
+		return
+	}
+
+	sr.update(func() {
+		sr.options.hash = hash
+		sr.options.hashFunc = nil
+		sr.options.newHash = nil
+		sr.options.hashName = hashAlgorithmName(hash)
+		sr.hasher = newLockedHasher(hash, sr.options.seed)
+		sr.refreshHashedNodes()
+	})
+}
+
+// SetHashFunc set the stateless hash function, the topology is kept but
+// keys may be placed on different nodes. A nil hash function is ignored.
+func (sr *SkeletonRendezvous) SetHashFunc(hash func([]byte) uint64) {
+	if hash == nil {
+		return
+	}
+
+	sr.update(func() {
+		sr.options.hash = nil
+		sr.options.hashFunc = hash
+		sr.options.newHash = nil
+		sr.options.hashName = ""
+		sr.hasher = newFuncHasher(hash, sr.options.seed)
+		sr.refreshHashedNodes()
+	})
+}
+
+// RemoveNodes remove nodes from their clusters. Only the clusters of removed
+// nodes are touched, a cluster that falls below the minimum cluster size is
+// backfilled with nodes of the last cluster, so key movement stays
+// proportional to the removed capacity.
 func (sr *SkeletonRendezvous) RemoveNodes(removedNodes []string) {
+	sr.updateTopology(func() {
+		sr.removeNodes(removedNodes)
+	})
+}
+
+func (sr *SkeletonRendezvous) removeNodes(removedNodes []string) {
 	deletedNodes := make(map[string]bool)
 
 	for _, removedNode := range removedNodes {
@@ -125,43 +647,433 @@ func (sr *SkeletonRendezvous) RemoveNodes(removedNodes []string) {
 		}
 	}
 
-	sr.Clusters = make([][]string, 0)
-	sr.generateCluster(newNodes)
+	for node := range deletedNodes {
+		delete(sr.nodeInfo, node)
+		delete(sr.nodeWeights, node)
+		delete(sr.states, node)
+		delete(sr.deadlines, node)
+	}
+
+	sr.refreshUnavailable()
+	sr.removeClusterNodes(deletedNodes, newNodes)
 }
 
-// FindNode given specific key, find selected nodes with highest hash score
-func (sr *SkeletonRendezvous) FindNode(key string) string {
-	var branch string
+// removeClusterNodes remove deleted nodes from their cluster in place,
+// a cluster is only dropped once it has no nodes left.
+func (sr *SkeletonRendezvous) removeClusterNodes(deletedNodes map[string]bool, newNodes []string) {
+	clusters := make([][]string, 0, len(sr.Clusters))
 
-	for i := 0; i < sr.VirtualNodes; i++ {
-		var highestNode uint64
-		var targetBranch string
+	for _, cluster := range sr.Clusters {
+		newCluster := make([]string, 0, len(cluster))
 
-		for j := 0; j < sr.options.fanOut; j++ {
-			branchStr := strconv.Itoa(i) + strconv.Itoa(j)
+		for _, node := range cluster {
+			if !deletedNodes[node] {
+				newCluster = append(newCluster, node)
+			}
+		}
 
-			hashScore := sr.hash(branchStr, key)
+		clusters = append(clusters, newCluster)
+	}
 
-			if hashScore > highestNode {
-				highestNode = hashScore
-				targetBranch = strconv.Itoa(j)
+	// backfilling could break the zone placement or the placement of a
+	// strategy, so such clusters only lose the removed nodes.
+	if !sr.options.stableClusterCount && sr.packedInOrder() {
+		clusters = sr.backfillClusters(clusters)
+	}
+
+	sr.Nodes = newNodes
+	sr.Clusters = make([][]string, 0, len(clusters))
+
+	for _, cluster := range clusters {
+		if len(cluster) > 0 {
+			sr.Clusters = append(sr.Clusters, cluster)
+		}
+	}
+
+	sr.VirtualNodes = sr.countVirtualNodes(len(sr.Clusters), sr.options.fanOut)
+	sr.topologyChanged()
+}
+
+// backfillClusters moves nodes from the last cluster into the clusters that
+// fell below the minimum cluster size. When the last cluster itself ends up
+// undersized it is spread over the other clusters, like generateCluster does.
+func (sr *SkeletonRendezvous) backfillClusters(clusters [][]string) [][]string {
+	for i := 0; i < len(clusters)-1; i++ {
+		for len(clusters[i]) < sr.options.minClusterSize && i < len(clusters)-1 {
+			last := clusters[len(clusters)-1]
+
+			if len(last) == 0 {
+				clusters = clusters[:len(clusters)-1]
+
+				continue
 			}
+
+			clusters[i] = append(clusters[i], last[len(last)-1])
+			clusters[len(clusters)-1] = last[:len(last)-1]
 		}
+	}
+
+	for len(clusters) > 1 && len(clusters[len(clusters)-1]) == 0 {
+		clusters = clusters[:len(clusters)-1]
+	}
+
+	if len(clusters) > 1 && !sr.options.disableRedistribution {
+		lastCluster := clusters[len(clusters)-1]
+
+		if len(lastCluster) < sr.options.minClusterSize {
+			clusters = clusters[:len(clusters)-1]
+
+			for i, node := range lastCluster {
+				clusters[i%len(clusters)] = append(clusters[i%len(clusters)], node)
+			}
+		}
+	}
+
+	return clusters
+}
+
+// FindNode given specific key, find selected nodes with highest hash score.
+// An empty key is a valid key and is placed deterministically like any other.
+// It returns ErrNoNodes when the skeleton has no nodes, ErrClusterEmpty when
+// the selected cluster has no nodes and ErrInvalidTopology when the branch
+// can not be mapped into a cluster.
+func (sr *SkeletonRendezvous) FindNode(key string) (string, error) {
+	if len(sr.lookupObservers) == 0 {
+		sr.mu.RLock()
+		defer sr.mu.RUnlock()
+
+		return sr.lookupNode(key)
+	}
+
+	start := time.Now()
+
+	sr.mu.RLock()
+
+	node, err := sr.lookupNode(key)
+	event := LookupEvent{
+		Key:   key,
+		Node:  node,
+		Err:   err,
+		Start: start,
+		Epoch: sr.epoch,
+		Depth: sr.VirtualNodes,
+	}
+
+	sr.mu.RUnlock()
+
+	event.Duration = time.Since(start)
+
+	for _, observer := range sr.lookupObservers {
+		observer.ObserveLookup(event)
+	}
+
+	return node, err
+}
+
+// lookupNode find selected node through the lookup cache, the caller must
+// hold the read lock.
+func (sr *SkeletonRendezvous) lookupNode(key string) (string, error) {
+	if sr.cache == nil || sr.options.boundedLoad {
+		return sr.findNode(key)
+	}
+
+	if node, ok := sr.cache.get(key); ok {
+		return node, nil
+	}
+
+	node, err := sr.findNode(key)
+
+	if err == nil {
+		sr.cache.add(key, node)
+	}
+
+	return node, err
+}
+
+func (sr *SkeletonRendezvous) findNode(key string) (string, error) {
+	return sr.findNodeIn(key, sr.unavailableNodes())
+}
+
+// peekNode find selected node like findNode without counting the assignment
+// with bounded load, for the lookups which only inspect the placement.
+func (sr *SkeletonRendezvous) peekNode(key string) (string, error) {
+	return sr.selectNode(key, sr.unavailableNodes(), false)
+}
+
+// findNodeIn find selected node ignoring the unavailable nodes and counts
+// the assignment with bounded load.
+func (sr *SkeletonRendezvous) findNodeIn(key string, unavailable map[string]struct{}) (string, error) {
+	return sr.selectNode(key, unavailable, true)
+}
 
-		branch = branch + targetBranch
+// selectNode find selected node ignoring the unavailable nodes, when every
+// node of the selected cluster is unavailable the other clusters are used.
+// A pinned key is selected on its node. The assignment is counted with
+// bounded load when assign is set.
+func (sr *SkeletonRendezvous) selectNode(key string, unavailable map[string]struct{}, assign bool) (string, error) {
+	if node, ok := sr.pinnedNode(key, unavailable); ok {
+		return node, nil
 	}
 
-	nodes, err := sr.selectClusterNodes(branch)
+	nodes, err := sr.findClusterNodes(key)
 
 	if err != nil {
-		return ""
+		return "", err
+	}
+
+	if len(nodes) == 0 {
+		return "", ErrClusterEmpty
 	}
 
-	selectedNode := sr.findHighestRandomWeight(key, nodes)
+	if sr.options.boundedLoad {
+		if len(unavailable) > 0 {
+			nodes = availableNodes(nodes, unavailable)
 
-	return selectedNode
+			if len(nodes) == 0 {
+				return sr.findNodeExcluding(key, unavailable)
+			}
+		}
+
+		return sr.findBoundedNode(key, nodes, assign), nil
+	}
+
+	// skipping the unavailable nodes in place avoids allocating the
+	// available nodes on every lookup.
+	if selectedNode, _, ok := sr.findHighestRandomWeightExcluding(key, nodes, unavailable); ok {
+		return selectedNode, nil
+	}
+
+	return sr.findNodeExcluding(key, unavailable)
+}
+
+// topologyChanged refreshes the state derived from clusters, it must be
+// called whenever the clusters are rebuilt.
+func (sr *SkeletonRendezvous) topologyChanged() {
+	sr.epoch++
+	sr.prunePins()
+	sr.refreshRamps()
+	sr.refreshClusterIndexes()
+	sr.refreshHashedNodes()
+	sr.refreshBranchWeights()
+
+	sr.loadMu.Lock()
+	sr.loads = nil
+	sr.loadMu.Unlock()
+}
+
+// update runs the mutation holding the write lock, then notifies the
+// watchers of moved keys once the lock is released. The mutation must keep
+// the nodes and the clusters, they are changed through updateTopology.
+func (sr *SkeletonRendezvous) update(mutate func()) {
+	sr.apply(mutate, false)
+}
+
+// updateTopology runs a mutation of the nodes or the clusters like update,
+// the topology before it is copied for the topology event and the epoch
+// history.
+func (sr *SkeletonRendezvous) updateTopology(mutate func()) {
+	sr.apply(mutate, true)
+}
+
+func (sr *SkeletonRendezvous) apply(mutate func(), topology bool) {
+	if sr.record(mutate, topology) {
+		sr.deliver()
+	}
+}
+
+// record runs the mutation holding the write lock and queues what it has
+// to deliver, it reports whether the caller has to deliver the queue.
+func (sr *SkeletonRendezvous) record(mutate func(), topology bool) bool {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+
+	epoch := sr.epoch
+	notify := len(sr.topologyWatchers) > 0 || len(sr.observers) > 0
+
+	// the mutation may change the nodes and clusters in place, so the
+	// topology before it is copied for the event.
+	var nodes []string
+	var clusters [][]string
+
+	if topology && notify {
+		nodes = append(make([]string, 0, len(sr.Nodes)), sr.Nodes...)
+		clusters = copyClusters(sr.Clusters)
+	}
+
+	var past *SkeletonRendezvous
+
+	if topology && sr.options.epochHistory > 0 {
+		past = sr.freeze()
+	}
+
+	mutate()
+
+	if past != nil && sr.epoch != epoch {
+		sr.remember(past)
+	}
+
+	sr.cache.purge()
+
+	change := notification{events: sr.collectWatchEvents()}
+
+	if notify && sr.epoch != epoch {
+		change.topologyWatchers = append(change.topologyWatchers, sr.topologyWatchers...)
+		change.topologyEvent = sr.topologyEvent(nodes, clusters)
+		change.stats = sr.stats()
+		change.changed = true
+	}
+
+	if len(change.events) == 0 && !change.changed {
+		return false
+	}
+
+	sr.notifications = append(sr.notifications, change)
+
+	// only one caller delivers at a time, the others leave their changes
+	// queued so they are delivered in the order of the changes.
+	deliver := !sr.delivering
+	sr.delivering = true
+
+	return deliver
+}
+
+// notification holds what a change has to deliver to the watchers and
+// the observers.
+type notification struct {
+	events           []watchEvent
+	topologyWatchers []*eventQueue[TopologyEvent]
+	topologyEvent    TopologyEvent
+	stats            RingStats
+	changed          bool
+}
+
+// deliver calls the watchers and the observers for the queued changes
+// until none are left, no lock is held while they are called, so they may
+// change the topology again.
+func (sr *SkeletonRendezvous) deliver() {
+	finished := false
+
+	// a panicking callback leaves the rest queued for the next change
+	defer func() {
+		if !finished {
+			sr.mu.Lock()
+			sr.delivering = false
+			sr.mu.Unlock()
+		}
+	}()
+
+	for {
+		sr.mu.Lock()
+
+		notifications := sr.notifications
+		sr.notifications = nil
+
+		if len(notifications) == 0 {
+			sr.delivering = false
+			sr.mu.Unlock()
+
+			finished = true
+
+			return
+		}
+
+		sr.mu.Unlock()
+
+		for _, change := range notifications {
+			for _, event := range change.events {
+				if !event.watcher.stopped.Load() {
+					event.watcher.callback(event.key, event.oldNode, event.newNode)
+				}
+			}
+
+			for _, watcher := range change.topologyWatchers {
+				watcher.send(change.topologyEvent)
+			}
+
+			if change.changed {
+				for _, observer := range sr.observers {
+					observer.ObserveTopology(change.topologyEvent, change.stats)
+				}
+			}
+		}
+	}
 }
 
+// findClusterNodes walks the skeleton branches for the given key
+// and returns the nodes of the selected cluster.
+func (sr *SkeletonRendezvous) findClusterNodes(key string) ([]string, error) {
+	clusterIndex, err := sr.findCluster(key)
+
+	if err != nil {
+		return []string{}, err
+	}
+
+	return sr.Clusters[clusterIndex], nil
+}
+
+// findCluster walks the skeleton branches for the given key
+// and returns the index of the selected cluster.
+func (sr *SkeletonRendezvous) findCluster(key string) (int, error) {
+	if len(sr.Clusters) == 0 {
+		return -1, ErrNoNodes
+	}
+
+	position := 0
+
+	for i := 0; i < sr.VirtualNodes; i++ {
+		position = position*sr.options.fanOut + sr.selectBranch(key, i, position)
+	}
+
+	clusterIndex := sr.lookupClusterIndex(position)
+
+	if clusterIndex < 0 || clusterIndex > len(sr.Clusters)-1 {
+		return -1, fmt.Errorf("%w: branch position %d is out of cluster range", ErrInvalidTopology, position)
+	}
+
+	return clusterIndex, nil
+}
+
+// selectBranch chooses the branch with highest hash score on the given
+// virtual node level, position is the branch position chosen so far.
+func (sr *SkeletonRendezvous) selectBranch(key string, level int, position int) int {
+	if sr.branchWeights != nil {
+		return sr.selectWeightedBranch(key, level, position)
+	}
+
+	var highestNode uint64
+	var highestTie uint64
+	var targetBranch int
+
+	for j := 0; j < sr.options.fanOut; j++ {
+		hashScore := sr.rank(sr.hashBranch(level, j, key))
+		tie := branchTieBreak(hashScore, j)
+
+		if j == 0 || hashScore > highestNode || (hashScore == highestNode && tie > highestTie) {
+			highestNode = hashScore
+			highestTie = tie
+			targetBranch = j
+		}
+	}
+
+	return targetBranch
+}
+
+// branchTieBreak derives a secondary score from the hash score and the
+// branch, so branches with identical hash scores, for instance from a
+// weak hash, are still spread depending on the key instead of always
+// selecting the first branch.
+func branchTieBreak(hashScore uint64, branch int) uint64 {
+	tie := hashScore ^ (uint64(branch+1) * 0x9e3779b97f4a7c15)
+	tie ^= tie >> 33
+	tie *= 0xff51afd7ed558ccd
+	tie ^= tie >> 33
+	tie *= 0xc4ceb9fe1a85ec53
+	tie ^= tie >> 33
+
+	return tie
+}
+
+// generateCluster rebuilds every cluster from the given nodes
 func (sr *SkeletonRendezvous) generateCluster(nodes []string) {
 	lookup := make(map[string]bool)
 
@@ -174,99 +1086,198 @@ func (sr *SkeletonRendezvous) generateCluster(nodes []string) {
 		}
 	}
 
-	sr.Nodes = append(sr.Nodes, newNodes...)
+	sr.orderNodes(newNodes)
+
+	sr.Nodes = newNodes
 
-	clusterCount := float64(len(nodes)) / float64(sr.options.clusterSize)
-	clusterAmount := int(math.Ceil(clusterCount))
+	if sr.options.placement != PlaceInOrder && sr.options.strategy == nil {
+		sr.Clusters = sr.zoneClusters(newNodes)
+		sr.VirtualNodes = sr.countVirtualNodes(len(sr.Clusters), sr.options.fanOut)
+		sr.topologyChanged()
 
-	for i := 0; i < clusterAmount; i++ {
-		sr.Clusters = append(sr.Clusters, make([]string, 0))
+		return
 	}
 
-	clusterIndex := 0
+	sr.Clusters = sr.assignClusters(newNodes)
+	sr.VirtualNodes = sr.countVirtualNodes(len(sr.Clusters), sr.options.fanOut)
+	sr.topologyChanged()
+}
+
+// orderNodes sorts the nodes by the hash of their ID when hashed assignment
+// is enabled, ties are broken by the ID.
+func (sr *SkeletonRendezvous) orderNodes(nodes []string) {
+	if !sr.options.hashedAssignment {
+		return
+	}
 
-	for _, node := range newNodes {
-		sr.Clusters[clusterIndex] = append(sr.Clusters[clusterIndex], node)
+	sums := make(map[string]uint64, len(nodes))
 
-		if len(sr.Clusters[clusterIndex]) >= sr.options.clusterSize {
-			clusterIndex++
-		}
+	for _, node := range nodes {
+		sums[node] = sr.hash("", node)
 	}
 
-	if clusterAmount > 1 {
-		lastCluster := sr.Clusters[len(sr.Clusters)-1]
+	sort.Slice(nodes, func(i, j int) bool {
+		if sums[nodes[i]] != sums[nodes[j]] {
+			return sums[nodes[i]] < sums[nodes[j]]
+		}
 
-		if len(lastCluster) < sr.options.minClusterSize {
-			sr.Clusters = sr.Clusters[:len(sr.Clusters)-1]
-			clusterAmount--
+		return nodes[i] < nodes[j]
+	})
+}
 
-			spreadClusterIndex := 0
+// countVirtualNodes returns the smallest depth which branches are able
+// to address every cluster, that is fanOut^depth >= clusterAmount. The
+// depth is at least 1 as long as there is a cluster, and at least the
+// pinned depth.
+func (sr *SkeletonRendezvous) countVirtualNodes(clusterAmount int, fanOut int) int {
+	return virtualNodesFor(clusterAmount, fanOut, sr.options.depth)
+}
 
-			for _, node := range lastCluster {
-				sr.Clusters[spreadClusterIndex] = append(sr.Clusters[spreadClusterIndex], node)
+// virtualNodesFor is countVirtualNodes for the given pinned depth.
+func virtualNodesFor(clusterAmount int, fanOut int, depth int) int {
+	if clusterAmount == 0 {
+		return 0
+	}
 
-				spreadClusterIndex = (spreadClusterIndex + 1) % clusterAmount
-			}
-		}
+	virtualNodes := 1
+
+	for branches := fanOut; branches < clusterAmount; branches *= fanOut {
+		virtualNodes++
 	}
 
-	sr.VirtualNodes = sr.countVirtualNodes(clusterAmount, sr.options.fanOut)
+	if virtualNodes < depth {
+		return depth
+	}
+
+	return virtualNodes
 }
 
-func (sr *SkeletonRendezvous) countVirtualNodes(clusterAmount int, fanOut int) int {
-	return int(math.Ceil(math.Log(float64(clusterAmount)) / math.Log(float64(fanOut))))
+// clusterIndex maps a branch position into cluster index, positions
+// beyond the last cluster are mapped according to the overflow policy.
+func (sr *SkeletonRendezvous) clusterIndex(position int) int {
+	if len(sr.Clusters) == 0 {
+		return -1
+	}
+
+	if position < len(sr.Clusters) {
+		return position
+	}
+
+	if sr.options.overflowPolicy == ClampLast {
+		return len(sr.Clusters) - 1
+	}
+
+	return position % len(sr.Clusters)
 }
 
-func (sr *SkeletonRendezvous) selectClusterNodes(branch string) ([]string, error) {
-	if len(branch) == 1 {
-		branchCluster, err := strconv.Atoi(branch)
+// lookupClusterIndex maps a branch position into cluster index from the
+// precomputed table, positions outside of the table are mapped directly.
+func (sr *SkeletonRendezvous) lookupClusterIndex(position int) int {
+	if position < len(sr.clusterIndexes) {
+		return sr.clusterIndexes[position]
+	}
 
-		if err != nil {
-			return []string{}, err
-		}
+	return sr.clusterIndex(position)
+}
 
-		if branchCluster > len(sr.Clusters)-1 {
-			return sr.Clusters[branchCluster-1], nil
-		}
+// refreshClusterIndexes precomputes the cluster of every branch position
+// and the span of every level, so the branch walk neither maps positions
+// nor computes powers of the fan out on each lookup.
+func (sr *SkeletonRendezvous) refreshClusterIndexes() {
+	sr.clusterIndexes = nil
+	sr.spans = nil
 
-		return sr.Clusters[branchCluster], nil
+	if len(sr.Clusters) == 0 {
+		return
 	}
 
-	currentBrannchIndex := 0
-	branchSize := len(branch) - 1
+	sr.spans = make([]int, sr.VirtualNodes)
+	positions := 1
 
-	for _, v := range branch {
-		currentVal, _ := strconv.Atoi(string(v))
-		currentBrannchIndex = currentBrannchIndex + (int(math.Pow(float64(sr.options.fanOut), float64(branchSize))) * currentVal)
-		branchSize--
+	for level := sr.VirtualNodes - 1; level >= 0; level-- {
+		sr.spans[level] = positions
+		positions *= sr.options.fanOut
 	}
 
-	if currentBrannchIndex > len(sr.Clusters)-1 {
-		return sr.Clusters[currentBrannchIndex-len(sr.Clusters)-1], nil
+	sr.clusterIndexes = make([]int, positions)
+
+	for position := range sr.clusterIndexes {
+		sr.clusterIndexes[position] = sr.clusterIndex(position)
 	}
+}
 
-	return sr.Clusters[currentBrannchIndex], nil
+// refreshHashedNodes hashes every node once, so scoring a node only hashes
+// the key. The map is replaced rather than updated, clones share it.
+func (sr *SkeletonRendezvous) refreshHashedNodes() {
+	sr.hashedNodes = make(map[string]hashedNode, len(sr.Nodes))
+
+	for _, node := range sr.Nodes {
+		sr.hashedNodes[node] = sr.hasher.hashNode(node)
+	}
 }
 
 func (sr *SkeletonRendezvous) findHighestRandomWeight(key string, nodes []string) string {
-	var highestNode uint64
-	var selectedNode string
+	selectedNode, _, _ := sr.findHighestRandomWeightExcluding(key, nodes, nil)
 
-	for _, node := range nodes {
-		nodeScore := sr.hash(node, key)
+	return selectedNode
+}
+
+// scoreNode returns the rendezvous score of a node for the given key,
+// scaled by the node weight, health and ramp up.
+func (sr *SkeletonRendezvous) scoreNode(node string, key string) float64 {
+	weight := sr.nodeWeight(node) * sr.nodeHealth(node) * sr.nodeRamp(node)
+
+	if sr.options.scoreFunc != nil {
+		return weightedScore(sr.rank(sr.options.scoreFunc(node, key)), weight)
+	}
+
+	if sr.options.replicas == 1 {
+		return weightedScore(sr.rank(sr.hashNode(node, key)), weight)
+	}
 
-		if nodeScore > highestNode {
-			highestNode = nodeScore
-			selectedNode = node
+	var highestReplica uint64
+
+	for replica := 0; replica < sr.options.replicas; replica++ {
+		replicaScore := sr.rank(sr.hashReplica(node, replica, key))
+
+		if replica == 0 || replicaScore > highestReplica {
+			highestReplica = replicaScore
 		}
 	}
 
-	return selectedNode
+	return weightedScore(highestReplica, weight)
+}
+
+// rank turns a hash score into a comparable rank where the highest rank
+// wins, flipping the order when the lowest hash score must be selected.
+func (sr *SkeletonRendezvous) rank(hashScore uint64) uint64 {
+	if sr.options.selectMin {
+		return ^hashScore
+	}
+
+	return hashScore
 }
 
 func (sr *SkeletonRendezvous) hash(target string, key string) uint64 {
-	sr.options.hash.Reset()
-	sr.options.hash.Write([]byte(target))
-	sr.options.hash.Write([]byte(key))
-	return sr.options.hash.Sum64()
+	return sr.hasher.sum(target, key)
+}
+
+// hashNode hash the key with the cached form of the node, falling back to
+// hashing both when the node is not cached.
+func (sr *SkeletonRendezvous) hashNode(node string, key string) uint64 {
+	if hashed, ok := sr.hashedNodes[node]; ok {
+		return sr.hasher.sumNode(hashed, key)
+	}
+
+	return sr.hash(node, key)
+}
+
+// hashBranch hash the key with the branch of a level, <level><branch>
+func (sr *SkeletonRendezvous) hashBranch(level int, branch int, key string) uint64 {
+	return sr.hasher.sumBranch(level, branch, key)
+}
+
+// hashReplica hash the key with the virtual copy of a node, node#replica
+func (sr *SkeletonRendezvous) hashReplica(node string, replica int, key string) uint64 {
+	return sr.hasher.sumReplica(node, replica, key)
 }
@@ -1,6 +1,11 @@
 package rendezvous
 
 import (
+	"bytes"
+	"hash"
+	"hash/fnv"
+	"strconv"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -49,4 +54,876 @@ func TestSekSkeletonRendezvous(t *testing.T) {
 
 		assert.Equal(t, 1, len(sr.Clusters))
 	})
+
+	t.Run("should backfill undersized cluster from the last cluster", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(2), MinClusterSize(2))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4", "jg5", "jg6"})
+		sr.RemoveNodes([]string{"jg1"})
+
+		assert.Equal(t, [][]string{{"jg2", "jg6", "jg5"}, {"jg3", "jg4"}}, sr.Clusters)
+		assert.Equal(t, []string{"jg2", "jg3", "jg4", "jg5", "jg6"}, sr.Nodes)
+		assert.NoError(t, sr.Validate())
+	})
+
+	t.Run("should not touch unrelated clusters when removing a node", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(3), MinClusterSize(2))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4", "jg5", "jg6", "jg7", "jg8", "jg9"})
+		sr.RemoveNodes([]string{"jg5"})
+
+		assert.Equal(t, [][]string{{"jg1", "jg2", "jg3"}, {"jg4", "jg6"}, {"jg7", "jg8", "jg9"}}, sr.Clusters)
+	})
+
+	t.Run("should keep cluster count when removing nodes with stable cluster count", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(2), MinClusterSize(2), StableClusterCount(true))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+		sr.RemoveNodes([]string{"jg3"})
+
+		assert.Equal(t, [][]string{{"jg1", "jg2"}, {"jg4"}}, sr.Clusters)
+		assert.Equal(t, []string{"jg1", "jg2", "jg4"}, sr.Nodes)
+		assert.NoError(t, sr.Validate())
+
+		sr.RemoveNodes([]string{"jg4"})
+
+		assert.Equal(t, [][]string{{"jg1", "jg2"}}, sr.Clusters)
+		assert.NoError(t, sr.Validate())
+	})
+
+	t.Run("should spread undersized last cluster by default", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(2), MinClusterSize(2))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4", "jg5"})
+
+		assert.Equal(t, [][]string{{"jg1", "jg2", "jg5"}, {"jg3", "jg4"}}, sr.Clusters)
+	})
+
+	t.Run("should keep undersized last cluster when redistribution disabled", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(2), MinClusterSize(2), DisableRedistribution(true))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4", "jg5"})
+
+		assert.Equal(t, [][]string{{"jg1", "jg2"}, {"jg3", "jg4"}, {"jg5"}}, sr.Clusters)
+	})
+
+	t.Run("should place keys differently when lowest score wins", func(t *testing.T) {
+		nodes := []string{"jg1", "jg2", "jg3", "jg4", "jg5", "jg6"}
+
+		highest, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(2), MinClusterSize(2), HashAlgorithm(newMixedHash()))
+		assert.NoError(t, err)
+
+		lowest, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(2), MinClusterSize(2), HashAlgorithm(newMixedHash()), SelectMin(true))
+		assert.NoError(t, err)
+
+		highest.SetNodes(nodes)
+		lowest.SetNodes(nodes)
+
+		different := 0
+
+		for i := 0; i < 100; i++ {
+			key := "key-" + strconv.Itoa(i)
+
+			assert.Contains(t, nodes, mustFindNode(t, highest, key))
+			assert.Contains(t, nodes, mustFindNode(t, lowest, key))
+
+			if mustFindNode(t, highest, key) != mustFindNode(t, lowest, key) {
+				different++
+			}
+		}
+
+		assert.Greater(t, different, 50)
+	})
+
+	t.Run("should spread keys when branch hash scores collide", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(3), HashAlgorithm(&keyOnlyHash{Hash64: fnv.New64a()}))
+
+		assert.NoError(t, err)
+
+		sr.SetClusters([][]string{{"jg1"}, {"jg2"}, {"jg3"}})
+
+		counts := make(map[string]int)
+
+		for i := 0; i < 3000; i++ {
+			counts[mustFindNode(t, sr, "key-"+strconv.Itoa(i))]++
+		}
+
+		assert.Equal(t, 3, len(counts))
+
+		for _, count := range counts {
+			assert.InDelta(t, 1000, count, 200)
+		}
+	})
+
+	t.Run("should hold every node in one cluster when cluster size exceeds nodes", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(10), MinClusterSize(2), HashAlgorithm(newMixedHash()))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3"})
+
+		assert.Equal(t, [][]string{{"jg1", "jg2", "jg3"}}, sr.Clusters)
+		assert.Equal(t, 1, sr.VirtualNodes)
+		assert.NoError(t, sr.Validate())
+
+		counts := make(map[string]int)
+
+		for i := 0; i < 3000; i++ {
+			counts[mustFindNode(t, sr, "key-"+strconv.Itoa(i))]++
+		}
+
+		assert.Equal(t, 3, len(counts))
+
+		for _, count := range counts {
+			assert.InDelta(t, 1000, count, 150)
+		}
+	})
+
+	t.Run("should place empty key deterministically", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(2), MinClusterSize(2))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4", "jg5", "jg6"})
+
+		node := mustFindNode(t, sr, "")
+
+		assert.Contains(t, sr.Nodes, node)
+		assert.Equal(t, node, mustFindNode(t, sr, ""))
+	})
+
+	t.Run("should reject fan out lower than 2", func(t *testing.T) {
+		for _, fanOut := range []int{-1, 0, 1} {
+			sr, err := NewSkeletonRendezvous(FanOut(fanOut))
+
+			assert.Error(t, err)
+			assert.Nil(t, sr)
+		}
+	})
+
+	t.Run("should reject invalid cluster sizes", func(t *testing.T) {
+		for _, options := range [][]Option{
+			{ClusterSize(0)},
+			{ClusterSize(-1)},
+			{MinClusterSize(0)},
+			{ClusterSize(2), MinClusterSize(3)},
+			{ClusterSize(1), MinClusterSize(2)},
+			{HashAlgorithm(nil)},
+		} {
+			sr, err := NewSkeletonRendezvous(options...)
+
+			assert.ErrorIs(t, err, ErrInvalidOption)
+			assert.Nil(t, sr)
+		}
+	})
+
+	t.Run("should accept minimum cluster size equal to cluster size", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(ClusterSize(1), MinClusterSize(1))
+
+		assert.NoError(t, err)
+		assert.NotNil(t, sr)
+	})
+
+	t.Run("should lower the default minimum cluster size to cluster size 1", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(ClusterSize(1))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3"})
+
+		assert.Equal(t, [][]string{{"jg1"}, {"jg2"}, {"jg3"}}, sr.Clusters)
+		assert.NoError(t, sr.Validate())
+	})
+}
+
+func TestSetClusters(t *testing.T) {
+	t.Run("should use given clusters verbatim", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(2), MinClusterSize(2))
+
+		assert.NoError(t, err)
+
+		sr.SetClusters([][]string{{"jg1", "jg2", "jg3"}, {"jg4"}, {"jg5", "jg1"}})
+
+		assert.Equal(t, [][]string{{"jg1", "jg2", "jg3"}, {"jg4"}, {"jg5"}}, sr.Clusters)
+		assert.Equal(t, []string{"jg1", "jg2", "jg3", "jg4", "jg5"}, sr.Nodes)
+		assert.Equal(t, 1, sr.VirtualNodes)
+	})
+
+	t.Run("should drop clusters without nodes", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(2), MinClusterSize(1))
+
+		assert.NoError(t, err)
+
+		sr.SetClusters([][]string{{"jg1", "jg2"}, {}, {"jg3"}, {"jg1", "jg3"}})
+
+		assert.Equal(t, [][]string{{"jg1", "jg2"}, {"jg3"}}, sr.Clusters)
+		assert.Empty(t, sr.Verify())
+
+		for i := 0; i < 100; i++ {
+			assert.Contains(t, sr.Nodes, mustFindNode(t, sr, "key-"+strconv.Itoa(i)))
+		}
+	})
+
+	t.Run("should route only into given clusters", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(3))
+
+		assert.NoError(t, err)
+
+		sr.SetClusters([][]string{{"jg1"}, {"jg2"}, {"jg3"}})
+
+		for i := 0; i < 100; i++ {
+			assert.Contains(t, sr.Nodes, mustFindNode(t, sr, "key-"+strconv.Itoa(i)))
+		}
+	})
+}
+
+func TestSetHash(t *testing.T) {
+	t.Run("should change placement without touching clusters", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(2), MinClusterSize(2))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4", "jg5", "jg6"})
+
+		clusters := sr.Clusters
+		nodes := sr.Nodes
+
+		before := make([]string, 0)
+
+		for i := 0; i < 100; i++ {
+			before = append(before, mustFindNode(t, sr, "key-"+strconv.Itoa(i)))
+		}
+
+		sr.SetHash(fnv.New64a())
+
+		after := make([]string, 0)
+
+		for i := 0; i < 100; i++ {
+			after = append(after, mustFindNode(t, sr, "key-"+strconv.Itoa(i)))
+		}
+
+		assert.Equal(t, clusters, sr.Clusters)
+		assert.Equal(t, nodes, sr.Nodes)
+		assert.NotEqual(t, before, after)
+	})
+
+	t.Run("should ignore nil hash", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		expected := mustFindNode(t, sr, "key")
+
+		assert.NotPanics(t, func() { sr.SetHash(nil) })
+		assert.Equal(t, expected, mustFindNode(t, sr, "key"))
+		assert.Equal(t, "fnv64", sr.Algorithm())
+	})
+
+	t.Run("should release the lock when the mutation panics", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2"})
+
+		assert.Panics(t, func() {
+			sr.update(func() { panic("mutation") })
+		})
+
+		sr.AddNodes([]string{"jg3"})
+
+		assert.Contains(t, sr.Nodes, "jg3")
+	})
+}
+
+func TestFindNodeAllocs(t *testing.T) {
+	t.Run("should not allocate on lookup", func(t *testing.T) {
+		// a single locked hash, the race detector drops pooled hashes
+		sr, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(4), MinClusterSize(2), HashAlgorithm(fnv.New64()))
+
+		assert.NoError(t, err)
+
+		nodes := make([]string, 0)
+
+		for i := 0; i < 64; i++ {
+			nodes = append(nodes, "jg"+strconv.Itoa(i))
+		}
+
+		sr.SetNodes(nodes)
+		sr.SetClusterWeights([]float64{1, 2, 3, 4})
+		sr.MarkDown("jg1")
+		sr.MarkDraining("jg2")
+
+		allocs := testing.AllocsPerRun(100, func() {
+			sr.FindNode("some-key")
+		})
+
+		assert.Zero(t, allocs)
+	})
+}
+
+// mustFindNode find the node of the key, asserting the lookup succeeds.
+func mustFindNode(t assert.TestingT, sr *SkeletonRendezvous, key string) string {
+	node, err := sr.FindNode(key)
+
+	assert.NoError(t, err)
+
+	return node
+}
+
+func BenchmarkHash(b *testing.B) {
+	sr, err := NewSkeletonRendezvous()
+
+	assert.NoError(b, err)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		sr.hash("jg1", "some-benchmark-key")
+	}
+}
+
+func BenchmarkFindNode(b *testing.B) {
+	sr, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(4), MinClusterSize(2))
+
+	assert.NoError(b, err)
+
+	nodes := make([]string, 0)
+
+	for i := 0; i < 64; i++ {
+		nodes = append(nodes, "jg"+strconv.Itoa(i))
+	}
+
+	sr.SetNodes(nodes)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		sr.FindNode("some-benchmark-key")
+	}
+}
+
+func BenchmarkFindNodeParallel(b *testing.B) {
+	for name, option := range map[string]Option{
+		"hash":         HashAlgorithm(fnv.New64a()),
+		"hash factory": HashFactory(fnv.New64a),
+		"hash func":    HashFunc(fnv64aSum),
+	} {
+		b.Run(name, func(b *testing.B) {
+			sr, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(4), MinClusterSize(2), option)
+
+			assert.NoError(b, err)
+
+			nodes := make([]string, 0)
+
+			for i := 0; i < 64; i++ {
+				nodes = append(nodes, "jg"+strconv.Itoa(i))
+			}
+
+			sr.SetNodes(nodes)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					sr.FindNode("some-benchmark-key")
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkFindNodeLargeCluster(b *testing.B) {
+	sr, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(256), MinClusterSize(2))
+
+	assert.NoError(b, err)
+
+	nodes := make([]string, 0)
+
+	for i := 0; i < 1024; i++ {
+		nodes = append(nodes, "memcached-"+strconv.Itoa(i)+".cache.svc.cluster.local:11211")
+	}
+
+	sr.SetNodes(nodes)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		sr.FindNode("some-benchmark-key")
+	}
+}
+
+// keyOnlyHash ignores everything written before the "key-" prefix, so every
+// branch of the same key collides on an identical hash score.
+type keyOnlyHash struct {
+	hash.Hash64
+}
+
+func (k *keyOnlyHash) Write(p []byte) (int, error) {
+	if index := bytes.Index(p, []byte("key-")); index >= 0 {
+		k.Hash64.Write(p[index:])
+	}
+
+	return len(p), nil
+}
+
+// mixedHash wraps fnv64a with a murmur3 finalizer so that tests asserting
+// key distribution are not skewed by the weak avalanche of plain fnv.
+type mixedHash struct {
+	hash.Hash64
+}
+
+func newMixedHash() hash.Hash64 {
+	return &mixedHash{Hash64: fnv.New64a()}
+}
+
+func (m *mixedHash) Sum64() uint64 {
+	h := m.Hash64.Sum64()
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+
+	return h
+}
+
+func TestHashFunc(t *testing.T) {
+	nodes := []string{"jg1", "jg2", "jg3", "jg4", "jg5", "jg6"}
+
+	t.Run("should place keys like the equivalent stateful hash", func(t *testing.T) {
+		stateful, err := NewSkeletonRendezvous(HashAlgorithm(fnv.New64a()))
+
+		assert.NoError(t, err)
+
+		stateless, err := NewSkeletonRendezvous(HashFunc(fnv64aSum))
+
+		assert.NoError(t, err)
+
+		stateful.SetNodes(nodes)
+		stateless.SetNodes(nodes)
+
+		for i := 0; i < 100; i++ {
+			key := "key-" + strconv.Itoa(i)
+
+			assert.Equal(t, mustFindNode(t, stateful, key), mustFindNode(t, stateless, key))
+		}
+	})
+
+	t.Run("should reject nil hash function", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(HashFunc(nil))
+
+		assert.ErrorIs(t, err, ErrInvalidOption)
+		assert.Nil(t, sr)
+	})
+
+	t.Run("should replace stateful hash with hash function", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		sr.SetNodes(nodes)
+		sr.SetHashFunc(fnv64aSum)
+
+		expected, err := NewSkeletonRendezvous(HashFunc(fnv64aSum))
+
+		assert.NoError(t, err)
+
+		expected.SetNodes(nodes)
+
+		assert.True(t, sr.Equal(expected))
+		assert.Empty(t, sr.Algorithm())
+	})
+
+	t.Run("should ignore nil hash function", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(HashFunc(fnv64aSum))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes(nodes)
+
+		epoch := sr.Epoch()
+
+		sr.SetHashFunc(nil)
+
+		assert.Equal(t, epoch, sr.Epoch())
+
+		_, err = sr.FindNode("key")
+
+		assert.NoError(t, err)
+	})
+}
+
+// fnv64aSum is the stateless form of fnv.New64a.
+func fnv64aSum(data []byte) uint64 {
+	sum := uint64(14695981039346656037)
+
+	for _, c := range data {
+		sum ^= uint64(c)
+		sum *= 1099511628211
+	}
+
+	return sum
+}
+
+func TestHashFactory(t *testing.T) {
+	t.Run("should place keys like a single hash instance", func(t *testing.T) {
+		pooled, err := NewSkeletonRendezvous(HashFactory(fnv.New64a))
+		assert.NoError(t, err)
+
+		locked, err := NewSkeletonRendezvous(HashAlgorithm(fnv.New64a()))
+		assert.NoError(t, err)
+
+		nodes := []string{"jg1", "jg2", "jg3", "jg4", "jg5", "jg6"}
+
+		pooled.SetNodes(nodes)
+		locked.SetNodes(nodes)
+
+		for i := 0; i < 100; i++ {
+			key := "key-" + strconv.Itoa(i)
+
+			assert.Equal(t, mustFindNode(t, locked, key), mustFindNode(t, pooled, key))
+		}
+
+		assert.Equal(t, "fnv64a", pooled.Algorithm())
+		assert.True(t, pooled.Equal(locked))
+	})
+
+	t.Run("should look up concurrently", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(HashFactory(fnv.New64a))
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4", "jg5", "jg6"})
+
+		expected := mustFindNode(t, sr, "key")
+
+		var wg sync.WaitGroup
+
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				for j := 0; j < 100; j++ {
+					assert.Equal(t, expected, mustFindNode(t, sr, "key"))
+				}
+			}()
+		}
+
+		wg.Wait()
+	})
+
+	t.Run("should reject nil factory", func(t *testing.T) {
+		_, err := NewSkeletonRendezvous(HashFactory(nil))
+		assert.ErrorIs(t, err, ErrInvalidOption)
+	})
+}
+
+func TestScoreFunc(t *testing.T) {
+	priority := map[string]uint64{"jg1": 1, "jg2": 2, "jg3": 3, "jg4": 4}
+
+	score := func(node string, key string) uint64 {
+		// scores compare by their top 53 bits like hashes
+		return priority[node] << 60
+	}
+
+	newSkeleton := func(t *testing.T, options ...Option) *SkeletonRendezvous {
+		sr, err := NewSkeletonRendezvous(append([]Option{ScoreFunc(score)}, options...)...)
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		assert.Equal(t, [][]string{{"jg1", "jg2"}, {"jg3", "jg4"}}, sr.Clusters)
+
+		return sr
+	}
+
+	t.Run("should select the highest score within the cluster of the branch walk", func(t *testing.T) {
+		sr := newSkeleton(t)
+
+		for i := 0; i < 100; i++ {
+			key := "key-" + strconv.Itoa(i)
+
+			expected := map[int]string{0: "jg2", 1: "jg4"}[sr.Explain(key).Cluster]
+
+			assert.Equal(t, expected, mustFindNode(t, sr, key))
+		}
+	})
+
+	t.Run("should select the lowest score with select min", func(t *testing.T) {
+		sr := newSkeleton(t, SelectMin(true))
+
+		for i := 0; i < 100; i++ {
+			assert.Contains(t, []string{"jg1", "jg3"}, mustFindNode(t, sr, "key-"+strconv.Itoa(i)))
+		}
+	})
+
+	t.Run("should skip unavailable nodes", func(t *testing.T) {
+		sr := newSkeleton(t)
+
+		sr.MarkDown("jg2", "jg4")
+
+		for i := 0; i < 100; i++ {
+			assert.Contains(t, []string{"jg1", "jg3"}, mustFindNode(t, sr, "key-"+strconv.Itoa(i)))
+		}
+	})
+
+	t.Run("should not equal without the same score function", func(t *testing.T) {
+		sr := newSkeleton(t)
+
+		other, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		other.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		assert.False(t, sr.Equal(other))
+		assert.True(t, sr.Equal(newSkeleton(t)))
+	})
+
+	t.Run("should reject nil score function", func(t *testing.T) {
+		_, err := NewSkeletonRendezvous(ScoreFunc(nil))
+		assert.ErrorIs(t, err, ErrInvalidOption)
+	})
+}
+
+func TestSeed(t *testing.T) {
+	nodes := []string{"jg1", "jg2", "jg3", "jg4", "jg5", "jg6"}
+
+	placement := func(t *testing.T, options ...Option) []string {
+		sr, err := NewSkeletonRendezvous(options...)
+
+		assert.NoError(t, err)
+
+		sr.SetNodes(nodes)
+
+		placed := make([]string, 0, 200)
+
+		for i := 0; i < 200; i++ {
+			placed = append(placed, mustFindNode(t, sr, "key-"+strconv.Itoa(i)))
+		}
+
+		return placed
+	}
+
+	t.Run("should place keys identically with the same seed", func(t *testing.T) {
+		assert.Equal(t, placement(t, Seed(42)), placement(t, Seed(42)))
+		assert.Equal(t, placement(t, HashFunc(fnv64aSum), Seed(42)), placement(t, HashFunc(fnv64aSum), Seed(42)))
+	})
+
+	t.Run("should place keys differently with different seeds", func(t *testing.T) {
+		assert.NotEqual(t, placement(t, HashAlgorithm(newMixedHash()), Seed(1)), placement(t, HashAlgorithm(newMixedHash()), Seed(2)))
+	})
+
+	t.Run("should keep unseeded placement with zero seed", func(t *testing.T) {
+		assert.Equal(t, placement(t), placement(t, Seed(0)))
+	})
+
+	t.Run("should not equal when seeds differ", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(Seed(1))
+
+		assert.NoError(t, err)
+
+		other, err := NewSkeletonRendezvous(Seed(2))
+
+		assert.NoError(t, err)
+
+		assert.False(t, sr.Equal(other))
+	})
+}
+
+func TestHashedNodes(t *testing.T) {
+	nodes := []string{"jg1", "jg2", "node-with-a-rather-long-name-1.cluster.local", "node-with-a-rather-long-name-2.cluster.local"}
+
+	assertHashed := func(t *testing.T, sr *SkeletonRendezvous) {
+		assert.Len(t, sr.hashedNodes, len(sr.Nodes))
+
+		for _, node := range sr.Nodes {
+			for i := 0; i < 50; i++ {
+				key := "key-" + strconv.Itoa(i)
+
+				assert.Equal(t, sr.hash(node, key), sr.hashNode(node, key))
+			}
+		}
+	}
+
+	tests := []struct {
+		name    string
+		options []Option
+	}{
+		{name: "hash", options: nil},
+		{name: "seeded hash", options: []Option{Seed(42)}},
+		{name: "hash factory", options: []Option{HashFactory(fnv.New64a)}},
+		{name: "seeded hash factory", options: []Option{HashFactory(fnv.New64a), Seed(42)}},
+		{name: "hash func", options: []Option{HashFunc(fnv64aSum), Seed(42)}},
+	}
+
+	for _, test := range tests {
+		t.Run("should hash cached nodes like uncached nodes with "+test.name, func(t *testing.T) {
+			sr, err := NewSkeletonRendezvous(test.options...)
+
+			assert.NoError(t, err)
+
+			sr.SetNodes(nodes)
+
+			assertHashed(t, sr)
+		})
+	}
+
+	t.Run("should refresh the cache when nodes and hash change", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(Seed(7))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes(nodes)
+		sr.AddNodes([]string{"node-with-a-rather-long-name-3.cluster.local"})
+		sr.RemoveNodes([]string{"jg1"})
+
+		assertHashed(t, sr)
+		assert.NotContains(t, sr.hashedNodes, "jg1")
+
+		sr.SetHashFunc(fnv64aSum)
+
+		assertHashed(t, sr)
+
+		sr.SetHash(fnv.New64a())
+
+		assertHashed(t, sr)
+	})
+}
+
+func TestHashedAssignment(t *testing.T) {
+	t.Run("should produce identical clusters regardless of node order", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(HashedAssignment(true))
+
+		assert.NoError(t, err)
+
+		other, err := NewSkeletonRendezvous(HashedAssignment(true))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4", "jg5", "jg6", "jg7"})
+		other.SetNodes([]string{"jg7", "jg5", "jg3", "jg1", "jg6", "jg4", "jg2"})
+
+		assert.Equal(t, sr.Clusters, other.Clusters)
+		assert.True(t, sr.Equal(other))
+
+		for i := 0; i < 100; i++ {
+			key := "key-" + strconv.Itoa(i)
+
+			assert.Equal(t, mustFindNode(t, sr, key), mustFindNode(t, other, key))
+		}
+	})
+
+	t.Run("should keep given order when disabled", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg4", "jg3", "jg2", "jg1"})
+
+		assert.Equal(t, [][]string{{"jg4", "jg3"}, {"jg2", "jg1"}}, sr.Clusters)
+	})
+
+	t.Run("should add nodes regardless of their order", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(HashedAssignment(true))
+
+		assert.NoError(t, err)
+
+		other, err := NewSkeletonRendezvous(HashedAssignment(true))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+		other.SetNodes([]string{"jg4", "jg3", "jg2", "jg1"})
+
+		sr.AddNodes([]string{"jg5", "jg6", "jg7"})
+		other.AddNodes([]string{"jg7", "jg6", "jg5"})
+
+		assert.Equal(t, sr.Clusters, other.Clusters)
+	})
+}
+
+func TestDepth(t *testing.T) {
+	t.Run("should keep the pinned depth while clusters change", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(3), Depth(3))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2"})
+
+		assert.Equal(t, 3, sr.VirtualNodes)
+		assert.Empty(t, sr.Verify())
+
+		sr.AddNodes([]string{"jg3", "jg4", "jg5", "jg6"})
+
+		assert.Equal(t, 3, sr.VirtualNodes)
+		assert.Len(t, sr.clusterIndexes, 27)
+		assert.Empty(t, sr.Verify())
+
+		explanation := sr.Explain("key-1")
+
+		assert.Len(t, explanation.Branches, 3)
+		assert.Equal(t, explanation.Node, mustFindNode(t, sr, "key-1"))
+	})
+
+	t.Run("should grow beyond the pinned depth to address every cluster", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(2), ClusterSize(1), MinClusterSize(1), Depth(1))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3"})
+
+		assert.Equal(t, 2, sr.VirtualNodes)
+		assert.NoError(t, sr.Validate())
+	})
+
+	t.Run("should restore the pinned depth from a snapshot", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(Depth(2))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2"})
+
+		data, err := sr.MarshalJSON()
+
+		assert.NoError(t, err)
+
+		other, err := NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+		assert.NoError(t, other.UnmarshalJSON(data))
+		assert.True(t, sr.Equal(other))
+
+		other.SetNodes([]string{"jg3", "jg4"})
+
+		assert.Equal(t, 2, other.VirtualNodes)
+	})
+
+	t.Run("should reject invalid depth", func(t *testing.T) {
+		_, err := NewSkeletonRendezvous(Depth(0))
+
+		assert.ErrorIs(t, err, ErrInvalidOption)
+
+		_, err = NewSkeletonRendezvous(FanOut(4), Depth(9))
+
+		assert.ErrorIs(t, err, ErrInvalidOption)
+	})
 }
@@ -0,0 +1,52 @@
+package rendezvous
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplicas(t *testing.T) {
+	nodes := make([]string, 0)
+
+	for i := 0; i < 8; i++ {
+		nodes = append(nodes, "node-"+strconv.Itoa(i))
+	}
+
+	variance := func(t *testing.T, replicas int) float64 {
+		sr, err := NewSkeletonRendezvous(ClusterSize(8), Replicas(replicas))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes(nodes)
+
+		counts := make(map[string]int)
+
+		for i := 0; i < 8000; i++ {
+			node := mustFindNode(t, sr, "key-"+strconv.Itoa(i))
+
+			assert.Contains(t, nodes, node)
+			counts[node]++
+		}
+
+		total := 0.0
+
+		for _, node := range nodes {
+			diff := float64(counts[node] - 1000)
+			total += diff * diff
+		}
+
+		return total / float64(len(nodes))
+	}
+
+	t.Run("should reduce variance of key distribution", func(t *testing.T) {
+		assert.Less(t, variance(t, 10), variance(t, 1))
+	})
+
+	t.Run("should reject replicas lower than 1", func(t *testing.T) {
+		_, err := NewSkeletonRendezvous(Replicas(0))
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,218 @@
+// Package scheduler assigns tasks to workers with the skeleton rendezvous,
+// such as partitioned consumers or sharded cron jobs. Every worker runs a
+// scheduler over the same workers and tasks, and learns the tasks it owns
+// whenever the workers or the tasks change:
+//
+//	s := scheduler.New(sr, "worker-1", func(change scheduler.Change) {
+//		for _, task := range change.Released {
+//			stop(task)
+//		}
+//
+//		for _, task := range change.Acquired {
+//			start(task)
+//		}
+//	})
+//
+//	s.SetTasks([]string{"report-daily", "report-hourly", "cleanup"})
+//
+//	go s.Run(ctx)
+//
+// The workers are the nodes of the skeleton, they are set through
+// SetWorkers or by anything changing the skeleton, such as the consul or
+// dns packages.
+package scheduler
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+)
+
+// Change is a change of the tasks owned by the worker.
+type Change struct {
+	// Acquired are the tasks the worker started owning, in order
+	Acquired []string
+
+	// Released are the tasks the worker stopped owning, in order
+	Released []string
+
+	// Epoch is the epoch of the topology the tasks were assigned in
+	Epoch uint64
+}
+
+// Scheduler tracks the tasks owned by a worker.
+type Scheduler struct {
+	skeleton *rendezvous.SkeletonRendezvous
+	self     string
+	onChange func(Change)
+
+	mu    sync.Mutex
+	tasks []string
+	owned map[string]bool
+
+	// changes are the changes not delivered yet, delivering is set while
+	// they are delivered so they are delivered in the order they are
+	// computed, by a single caller
+	changes    []Change
+	delivering bool
+}
+
+// New creates the scheduler of the worker self, onChange is called with
+// every change of its tasks and may be nil.
+func New(sr *rendezvous.SkeletonRendezvous, self string, onChange func(Change)) *Scheduler {
+	return &Scheduler{
+		skeleton: sr,
+		self:     self,
+		onChange: onChange,
+		owned:    make(map[string]bool),
+	}
+}
+
+// SetWorkers makes the nodes of the skeleton match the workers, then assigns
+// the tasks again.
+func (s *Scheduler) SetWorkers(workers []string) {
+	s.skeleton.SyncNodes(workers)
+	s.Reassign()
+}
+
+// SetTasks replaces the tasks, then assigns them again.
+func (s *Scheduler) SetTasks(tasks []string) {
+	s.mu.Lock()
+	s.tasks = append(make([]string, 0, len(tasks)), tasks...)
+	s.mu.Unlock()
+
+	s.Reassign()
+}
+
+// Owned returns the tasks owned by the worker, in order.
+func (s *Scheduler) Owned() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return sortedTasks(s.owned)
+}
+
+// Owner returns the worker owning the task.
+func (s *Scheduler) Owner(task string) (string, error) {
+	return s.skeleton.FindNode(task)
+}
+
+// Reassign assigns the tasks with the current workers, calling onChange when
+// the tasks owned by the worker changed. Run calls it on every change of the
+// skeleton. A change computed while onChange runs is delivered once it
+// returns, by the caller already delivering.
+func (s *Scheduler) Reassign() {
+	s.mu.Lock()
+
+	epoch := s.skeleton.Epoch()
+	owned := make(map[string]bool)
+
+	for _, task := range s.tasks {
+		if owner, err := s.skeleton.FindNode(task); err == nil && owner == s.self {
+			owned[task] = true
+		}
+	}
+
+	change := Change{Epoch: epoch}
+
+	for task := range owned {
+		if !s.owned[task] {
+			change.Acquired = append(change.Acquired, task)
+		}
+	}
+
+	for task := range s.owned {
+		if !owned[task] {
+			change.Released = append(change.Released, task)
+		}
+	}
+
+	s.owned = owned
+
+	if len(change.Acquired) == 0 && len(change.Released) == 0 || s.onChange == nil {
+		s.mu.Unlock()
+
+		return
+	}
+
+	sort.Strings(change.Acquired)
+	sort.Strings(change.Released)
+
+	s.changes = append(s.changes, change)
+
+	deliver := !s.delivering
+	s.delivering = true
+
+	s.mu.Unlock()
+
+	if deliver {
+		s.deliver()
+	}
+}
+
+// deliver calls onChange with the queued changes until none are left, the
+// lock is not held while it is called, so it may change the tasks again.
+func (s *Scheduler) deliver() {
+	finished := false
+
+	// a panicking callback leaves the rest queued for the next change
+	defer func() {
+		if !finished {
+			s.mu.Lock()
+			s.delivering = false
+			s.mu.Unlock()
+		}
+	}()
+
+	for {
+		s.mu.Lock()
+
+		changes := s.changes
+		s.changes = nil
+
+		if len(changes) == 0 {
+			s.delivering = false
+			s.mu.Unlock()
+
+			finished = true
+
+			return
+		}
+
+		s.mu.Unlock()
+
+		for _, change := range changes {
+			s.onChange(change)
+		}
+	}
+}
+
+// Run assigns the tasks again on every change of the nodes or the clusters
+// of the skeleton until the context is done, it returns the error of the
+// context. Changes of the node states do not change the topology, Reassign
+// must be called after them.
+func (s *Scheduler) Run(ctx context.Context) error {
+	events := s.skeleton.WatchTopology(ctx)
+
+	s.Reassign()
+
+	for range events {
+		s.Reassign()
+	}
+
+	return ctx.Err()
+}
+
+func sortedTasks(tasks map[string]bool) []string {
+	sorted := make([]string, 0, len(tasks))
+
+	for task := range tasks {
+		sorted = append(sorted, task)
+	}
+
+	sort.Strings(sorted)
+
+	return sorted
+}
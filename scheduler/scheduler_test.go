@@ -0,0 +1,180 @@
+package scheduler
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+	"github.com/stretchr/testify/assert"
+)
+
+// recorder records the changes of a scheduler.
+type recorder struct {
+	mu      sync.Mutex
+	changes []Change
+}
+
+func (r *recorder) record(change Change) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.changes = append(r.changes, change)
+}
+
+func tasks(n int) []string {
+	tasks := make([]string, n)
+
+	for i := range tasks {
+		tasks[i] = "task-" + strconv.Itoa(i)
+	}
+
+	return tasks
+}
+
+func TestScheduler(t *testing.T) {
+	t.Run("should assign every task to exactly one worker", func(t *testing.T) {
+		workers := []string{"w1", "w2", "w3", "w4"}
+		owners := make(map[string]string)
+
+		for _, worker := range workers {
+			sr, err := rendezvous.NewSkeletonRendezvous(rendezvous.HashAlgorithmByName("fnv64a"))
+			assert.NoError(t, err)
+
+			s := New(sr, worker, nil)
+			s.SetWorkers(workers)
+			s.SetTasks(tasks(100))
+
+			owned := make(map[string]bool)
+
+			for _, task := range s.Owned() {
+				_, ok := owners[task]
+
+				assert.False(t, ok, "task %s owned twice", task)
+
+				owners[task] = worker
+				owned[task] = true
+			}
+
+			for _, task := range tasks(100) {
+				owner, err := s.Owner(task)
+
+				assert.NoError(t, err)
+				assert.Equal(t, owned[task], owner == worker)
+			}
+		}
+
+		assert.Len(t, owners, 100)
+	})
+
+	t.Run("should report acquired and released tasks", func(t *testing.T) {
+		r := &recorder{}
+
+		sr, err := rendezvous.NewSkeletonRendezvous(rendezvous.HashAlgorithmByName("fnv64a"))
+		assert.NoError(t, err)
+
+		s := New(sr, "w1", r.record)
+		s.SetWorkers([]string{"w1"})
+		s.SetTasks(tasks(20))
+
+		assert.Len(t, r.changes, 1)
+		assert.Equal(t, tasks(20)[:1], r.changes[0].Acquired[:1])
+		assert.Len(t, r.changes[0].Acquired, 20)
+
+		s.SetWorkers([]string{"w1", "w2", "w3", "w4"})
+
+		assert.Len(t, r.changes, 2)
+		assert.Empty(t, r.changes[1].Acquired)
+		assert.Len(t, r.changes[1].Released, 20-len(s.Owned()))
+		assert.Equal(t, sr.Epoch(), r.changes[1].Epoch)
+
+		s.SetWorkers([]string{"w1"})
+
+		assert.Len(t, r.changes, 3)
+		assert.Equal(t, r.changes[1].Released, r.changes[2].Acquired)
+		assert.Equal(t, tasksSorted(tasks(20)), s.Owned())
+	})
+
+	t.Run("should reassign on changes of the skeleton while running", func(t *testing.T) {
+		r := &recorder{}
+
+		sr, err := rendezvous.NewSkeletonRendezvous(rendezvous.HashAlgorithmByName("fnv64a"))
+		assert.NoError(t, err)
+
+		s := New(sr, "w1", r.record)
+		s.SetWorkers([]string{"w1", "w2"})
+		s.SetTasks(tasks(20))
+
+		owned := len(s.Owned())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+
+		go func() {
+			done <- s.Run(ctx)
+		}()
+
+		sr.RemoveNodes([]string{"w2"})
+
+		assert.Eventually(t, func() bool {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+
+			last := r.changes[len(r.changes)-1]
+
+			return len(last.Acquired) == 20-owned
+		}, time.Second, time.Millisecond)
+
+		cancel()
+
+		assert.ErrorIs(t, <-done, context.Canceled)
+		assert.Len(t, s.Owned(), 20)
+	})
+
+	t.Run("should let onChange change the tasks", func(t *testing.T) {
+		sr, err := rendezvous.NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"w1"})
+
+		var s *Scheduler
+		var changes []Change
+
+		s = New(sr, "w1", func(change Change) {
+			changes = append(changes, change)
+
+			if len(changes) == 1 {
+				s.SetTasks(tasks(5))
+			}
+		})
+
+		done := make(chan struct{})
+
+		go func() {
+			s.SetTasks(tasks(10))
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("SetTasks blocked when called by onChange")
+		}
+
+		assert.Len(t, changes, 2)
+		assert.Equal(t, tasksSorted(tasks(10)), changes[0].Acquired)
+		assert.Equal(t, tasksSorted(tasks(10)[5:]), changes[1].Released)
+		assert.Equal(t, tasksSorted(tasks(5)), s.Owned())
+	})
+}
+
+func tasksSorted(tasks []string) []string {
+	sorted := append([]string(nil), tasks...)
+
+	sort.Strings(sorted)
+
+	return sorted
+}
@@ -0,0 +1,252 @@
+// Package shardmap is a concurrent map whose keys are spread over shards by
+// the skeleton rendezvous. Every shard has its own lock, so operations on
+// keys of different shards do not contend, and resizing only migrates the
+// keys whose shard changed:
+//
+//	m, err := shardmap.New[int](8)
+//
+//	m.Set("user-1", 42)
+//	value, ok := m.Get("user-1")
+//
+//	moved, err := m.Resize(12)
+package shardmap
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+)
+
+// Map is a concurrent map of string keys sharded by the skeleton
+// rendezvous, the zero value is not usable.
+type Map[V any] struct {
+	// mu is held for writing while resizing, so the shards and the skeleton
+	// change together
+	mu       sync.RWMutex
+	skeleton *rendezvous.SkeletonRendezvous
+	shards   map[string]*shard[V]
+}
+
+type shard[V any] struct {
+	mu      sync.RWMutex
+	entries map[string]V
+}
+
+// New creates the map with the number of shards, the options configure the
+// skeleton spreading the keys over the shards. The skeleton balances the
+// overflowing branches unless another overflow policy is given, so every
+// shard receives an even share of the keys.
+func New[V any](shards int, options ...rendezvous.Option) (*Map[V], error) {
+	if shards < 1 {
+		return nil, fmt.Errorf("%w: shards must be at least 1, got %d", rendezvous.ErrInvalidOption, shards)
+	}
+
+	options = append([]rendezvous.Option{rendezvous.Overflow(rendezvous.BalanceOverflow)}, options...)
+
+	sr, err := rendezvous.NewSkeletonRendezvous(options...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Map[V]{
+		skeleton: sr,
+		shards:   make(map[string]*shard[V], shards),
+	}
+
+	names := shardNames(shards)
+
+	for _, name := range names {
+		m.shards[name] = &shard[V]{entries: make(map[string]V)}
+	}
+
+	sr.SetNodes(names)
+
+	return m, nil
+}
+
+// Get returns the value of the key, false when the key does not exist.
+func (m *Map[V]) Get(key string) (V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	s := m.shard(key)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	value, ok := s.entries[key]
+
+	return value, ok
+}
+
+// Set sets the value of the key.
+func (m *Map[V]) Set(key string, value V) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	s := m.shard(key)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[key] = value
+}
+
+// Delete removes the key.
+func (m *Map[V]) Delete(key string) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	s := m.shard(key)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+}
+
+// Len returns the number of keys.
+func (m *Map[V]) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	n := 0
+
+	for _, s := range m.shards {
+		s.mu.RLock()
+		n += len(s.entries)
+		s.mu.RUnlock()
+	}
+
+	return n
+}
+
+// Range calls fn for every key and value, shard by shard, until fn returns
+// false. fn must not modify the map.
+func (m *Map[V]) Range(fn func(key string, value V) bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, name := range m.skeleton.Nodes {
+		s := m.shards[name]
+
+		s.mu.RLock()
+
+		for key, value := range s.entries {
+			if !fn(key, value) {
+				s.mu.RUnlock()
+
+				return
+			}
+		}
+
+		s.mu.RUnlock()
+	}
+}
+
+// ShardOf returns the shard of the key.
+func (m *Map[V]) ShardOf(key string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	node, _ := m.skeleton.FindNode(key)
+
+	return node
+}
+
+// ShardLens returns the number of keys of every shard.
+func (m *Map[V]) ShardLens() map[string]int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	lens := make(map[string]int, len(m.shards))
+
+	for name, s := range m.shards {
+		s.mu.RLock()
+		lens[name] = len(s.entries)
+		s.mu.RUnlock()
+	}
+
+	return lens
+}
+
+// Resize changes the number of shards, only the keys whose shard changed
+// are migrated. It returns the number of migrated keys, operations wait for
+// the resize to finish.
+func (m *Map[V]) Resize(shards int) (int, error) {
+	if shards < 1 {
+		return 0, fmt.Errorf("%w: shards must be at least 1, got %d", rendezvous.ErrInvalidOption, shards)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	staged := m.skeleton.Clone()
+	staged.SyncNodes(shardNames(shards))
+
+	for _, name := range staged.Nodes {
+		if _, ok := m.shards[name]; !ok {
+			m.shards[name] = &shard[V]{entries: make(map[string]V)}
+		}
+	}
+
+	moved := 0
+
+	for name, s := range m.shards {
+		for key, value := range s.entries {
+			owner, err := staged.FindNode(key)
+
+			if err != nil {
+				return moved, err
+			}
+
+			if owner != name {
+				m.shards[owner].entries[key] = value
+				delete(s.entries, key)
+				moved++
+			}
+		}
+	}
+
+	if err := m.skeleton.Swap(staged); err != nil {
+		return moved, err
+	}
+
+	for name, s := range m.shards {
+		if len(s.entries) == 0 && !contains(staged.Nodes, name) {
+			delete(m.shards, name)
+		}
+	}
+
+	return moved, nil
+}
+
+// shard returns the shard of the key, the caller must hold the read lock.
+func (m *Map[V]) shard(key string) *shard[V] {
+	node, _ := m.skeleton.FindNode(key)
+
+	return m.shards[node]
+}
+
+func shardNames(shards int) []string {
+	names := make([]string, shards)
+
+	for i := range names {
+		names[i] = "shard-" + strconv.Itoa(i)
+	}
+
+	return names
+}
+
+func contains(nodes []string, node string) bool {
+	for _, n := range nodes {
+		if n == node {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,201 @@
+package shardmap
+
+import (
+	"math/rand"
+	"strconv"
+	"sync"
+	"testing"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+	"github.com/stretchr/testify/assert"
+)
+
+// randomKeys returns random keys, sequential keys share a prefix which fnv
+// spreads poorly over the shards.
+func randomKeys(n int) []string {
+	random := rand.New(rand.NewSource(1))
+	keys := make([]string, n)
+
+	for i := range keys {
+		keys[i] = strconv.FormatUint(random.Uint64(), 36)
+	}
+
+	return keys
+}
+
+func TestMap(t *testing.T) {
+	t.Run("should set, get and delete keys", func(t *testing.T) {
+		m, err := New[int](4)
+		assert.NoError(t, err)
+
+		m.Set("user-1", 1)
+		m.Set("user-2", 2)
+		m.Set("user-1", 3)
+
+		value, ok := m.Get("user-1")
+
+		assert.True(t, ok)
+		assert.Equal(t, 3, value)
+		assert.Equal(t, 2, m.Len())
+
+		m.Delete("user-1")
+
+		_, ok = m.Get("user-1")
+
+		assert.False(t, ok)
+		assert.Equal(t, 1, m.Len())
+	})
+
+	t.Run("should spread keys over the shards", func(t *testing.T) {
+		m, err := New[int](4)
+		assert.NoError(t, err)
+
+		for i, key := range randomKeys(4000) {
+			m.Set(key, i)
+		}
+
+		lens := m.ShardLens()
+
+		assert.Len(t, lens, 4)
+
+		for _, n := range lens {
+			assert.InDelta(t, 1000, n, 250)
+		}
+	})
+
+	t.Run("should only migrate keys whose shard changed", func(t *testing.T) {
+		m, err := New[int](4)
+		assert.NoError(t, err)
+
+		keys := randomKeys(2000)
+		before := make(map[string]string)
+
+		for i, key := range keys {
+			m.Set(key, i)
+			before[key] = m.ShardOf(key)
+		}
+
+		moved, err := m.Resize(6)
+		assert.NoError(t, err)
+
+		changed := 0
+
+		for i, key := range keys {
+			if m.ShardOf(key) != before[key] {
+				changed++
+			}
+
+			value, ok := m.Get(key)
+
+			assert.True(t, ok)
+			assert.Equal(t, i, value)
+		}
+
+		assert.Equal(t, changed, moved)
+		assert.Less(t, moved, len(keys))
+		assert.Equal(t, len(keys), m.Len())
+		assert.Len(t, m.ShardLens(), 6)
+
+		_, err = m.Resize(3)
+		assert.NoError(t, err)
+
+		assert.Equal(t, len(keys), m.Len())
+		assert.Len(t, m.ShardLens(), 3)
+
+		for i, key := range keys {
+			value, ok := m.Get(key)
+
+			assert.True(t, ok)
+			assert.Equal(t, i, value)
+		}
+	})
+
+	t.Run("should range over every key until stopped", func(t *testing.T) {
+		m, err := New[int](3)
+		assert.NoError(t, err)
+
+		for i, key := range randomKeys(30) {
+			m.Set(key, i)
+		}
+
+		seen := 0
+
+		m.Range(func(key string, value int) bool {
+			seen++
+
+			return true
+		})
+
+		assert.Equal(t, 30, seen)
+
+		seen = 0
+
+		m.Range(func(key string, value int) bool {
+			seen++
+
+			return seen < 5
+		})
+
+		assert.Equal(t, 5, seen)
+	})
+
+	t.Run("should be safe for concurrent use while resizing", func(t *testing.T) {
+		m, err := New[int](4)
+		assert.NoError(t, err)
+
+		keys := randomKeys(500)
+
+		var wg sync.WaitGroup
+
+		for w := 0; w < 4; w++ {
+			wg.Add(1)
+
+			go func(w int) {
+				defer wg.Done()
+
+				for i, key := range keys {
+					if i%4 == w {
+						m.Set(key, i)
+					}
+				}
+			}(w)
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for shards := 5; shards <= 8; shards++ {
+				_, err := m.Resize(shards)
+
+				assert.NoError(t, err)
+			}
+		}()
+
+		wg.Wait()
+
+		assert.Equal(t, len(keys), m.Len())
+
+		for i, key := range keys {
+			value, ok := m.Get(key)
+
+			assert.True(t, ok)
+			assert.Equal(t, i, value)
+		}
+	})
+
+	t.Run("should reject invalid shards or options", func(t *testing.T) {
+		_, err := New[int](0)
+		assert.ErrorIs(t, err, rendezvous.ErrInvalidOption)
+
+		_, err = New[int](2, rendezvous.FanOut(1))
+		assert.ErrorIs(t, err, rendezvous.ErrInvalidOption)
+
+		m, err := New[int](2)
+		assert.NoError(t, err)
+
+		_, err = m.Resize(0)
+		assert.ErrorIs(t, err, rendezvous.ErrInvalidOption)
+	})
+}
@@ -0,0 +1,172 @@
+// Package sim replays scripted membership changes against a keyspace and
+// reports how many keys each change remaps and how balanced the keys stay,
+// to evaluate the options of a skeleton rendezvous before deploying it.
+package sim
+
+import (
+	"sort"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+	"github.com/RiskyFeryansyahP/go-skeleton-rendezvous/analyze"
+)
+
+// Event is a membership change happening at a step of the script.
+type Event struct {
+	// At is the step of the change, events of the same step are applied
+	// together
+	At int
+
+	// Add are the nodes joining
+	Add []string
+
+	// Remove are the nodes leaving, their clusters are restructured
+	Remove []string
+
+	// Down are the nodes failing, they are marked down without
+	// restructuring the clusters
+	Down []string
+
+	// Up are the failed nodes recovering
+	Up []string
+}
+
+// Config is the scenario to replay.
+type Config struct {
+	// Options configure the simulated skeleton
+	Options []rendezvous.Option
+
+	// Nodes are the nodes before the first event
+	Nodes []string
+
+	// Keys is the keyspace placed on the nodes
+	Keys []string
+
+	// Events is the script of changes
+	Events []Event
+}
+
+// Step is the disruption caused by the events of a step.
+type Step struct {
+	// At is the step of the events
+	At int
+
+	// Moved is the number of keys changing node
+	Moved int
+
+	// Remapped is the fraction of the keys changing node
+	Remapped float64
+
+	// Balance is the distribution of the keys after the step
+	Balance analyze.Report
+}
+
+// Result is the outcome of a scenario.
+type Result struct {
+	// Initial is the distribution of the keys before the first event
+	Initial analyze.Report
+
+	// Steps are the steps of the script in order
+	Steps []Step
+
+	// Moved is the number of key moves over every step, a key moving
+	// twice counts twice
+	Moved int
+}
+
+// Run replays the events of the scenario on a new skeleton.
+func Run(config Config) (Result, error) {
+	sr, err := rendezvous.NewSkeletonRendezvous(config.Options...)
+
+	if err != nil {
+		return Result{}, err
+	}
+
+	sr.SetNodes(config.Nodes)
+
+	var result Result
+
+	if result.Initial, err = analyze.Keys(sr, config.Keys); err != nil {
+		return Result{}, err
+	}
+
+	events := append([]Event(nil), config.Events...)
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].At < events[j].At
+	})
+
+	for i := 0; i < len(events); {
+		before, err := owners(sr, config.Keys)
+
+		if err != nil {
+			return Result{}, err
+		}
+
+		step := Step{At: events[i].At}
+
+		for ; i < len(events) && events[i].At == step.At; i++ {
+			apply(sr, events[i])
+		}
+
+		after, err := owners(sr, config.Keys)
+
+		if err != nil {
+			return Result{}, err
+		}
+
+		for _, key := range config.Keys {
+			if before[key] != after[key] {
+				step.Moved++
+			}
+		}
+
+		if len(config.Keys) > 0 {
+			step.Remapped = float64(step.Moved) / float64(len(config.Keys))
+		}
+
+		if step.Balance, err = analyze.Keys(sr, config.Keys); err != nil {
+			return Result{}, err
+		}
+
+		result.Steps = append(result.Steps, step)
+		result.Moved += step.Moved
+	}
+
+	return result, nil
+}
+
+func apply(sr *rendezvous.SkeletonRendezvous, event Event) {
+	if len(event.Add) > 0 {
+		sr.AddNodes(event.Add)
+	}
+
+	if len(event.Remove) > 0 {
+		sr.RemoveNodes(event.Remove)
+	}
+
+	if len(event.Down) > 0 {
+		sr.MarkDown(event.Down...)
+	}
+
+	if len(event.Up) > 0 {
+		sr.MarkUp(event.Up...)
+	}
+}
+
+// owners returns the node of every key, the lookups are not counted as
+// assignments with bounded load.
+func owners(sr *rendezvous.SkeletonRendezvous, keys []string) (map[string]string, error) {
+	nodes := make(map[string]string, len(keys))
+
+	for _, key := range keys {
+		node, err := sr.PeekNode(key)
+
+		if err != nil {
+			return nil, err
+		}
+
+		nodes[key] = node
+	}
+
+	return nodes, nil
+}
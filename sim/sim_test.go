@@ -0,0 +1,86 @@
+package sim
+
+import (
+	"strconv"
+	"testing"
+
+	rendezvous "github.com/RiskyFeryansyahP/go-skeleton-rendezvous"
+	"github.com/stretchr/testify/assert"
+)
+
+func newKeys(n int) []string {
+	keys := make([]string, n)
+
+	for i := range keys {
+		keys[i] = "key-" + strconv.Itoa(i)
+	}
+
+	return keys
+}
+
+func TestRun(t *testing.T) {
+	nodes := []string{"jg1", "jg2", "jg3", "jg4", "jg5", "jg6"}
+
+	t.Run("should report the disruption of each step", func(t *testing.T) {
+		result, err := Run(Config{
+			Nodes: nodes,
+			Keys:  newKeys(1000),
+			Events: []Event{
+				{At: 30, Down: []string{"jg1", "jg2"}},
+				{At: 5, Add: []string{"jg7"}},
+				{At: 30, Remove: []string{"jg3"}},
+				{At: 40, Up: []string{"jg1", "jg2"}},
+			},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1000, result.Initial.Keys)
+		assert.Len(t, result.Initial.NodeCounts, 6)
+		assert.Len(t, result.Steps, 3)
+
+		moved := 0
+
+		for i, at := range []int{5, 30, 40} {
+			step := result.Steps[i]
+
+			assert.Equal(t, at, step.At)
+			assert.Greater(t, step.Moved, 0)
+			assert.Equal(t, float64(step.Moved)/1000, step.Remapped)
+
+			moved += step.Moved
+		}
+
+		assert.Equal(t, moved, result.Moved)
+		assert.Zero(t, result.Steps[1].Balance.NodeCounts["jg1"])
+		assert.NotContains(t, result.Steps[1].Balance.NodeCounts, "jg3")
+	})
+
+	t.Run("should not move keys of nodes which stay up", func(t *testing.T) {
+		result, err := Run(Config{
+			Nodes:  nodes,
+			Keys:   newKeys(1000),
+			Events: []Event{{At: 1, Down: []string{"jg1"}}},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, result.Initial.NodeCounts["jg1"], result.Steps[0].Moved)
+	})
+
+	t.Run("should not move keys when nothing changes with bounded load", func(t *testing.T) {
+		result, err := Run(Config{
+			Options: []rendezvous.Option{rendezvous.ClusterSize(6), rendezvous.BoundedLoad(0.1)},
+			Nodes:   nodes,
+			Keys:    newKeys(1000),
+			Events:  []Event{{At: 1, Up: []string{"jg1"}}},
+		})
+
+		assert.NoError(t, err)
+		assert.Zero(t, result.Steps[0].Moved)
+	})
+
+	t.Run("should return error for invalid options", func(t *testing.T) {
+		_, err := Run(Config{Options: []rendezvous.Option{rendezvous.FanOut(1)}})
+
+		assert.ErrorIs(t, err, rendezvous.ErrInvalidOption)
+	})
+}
@@ -0,0 +1,98 @@
+package rendezvous
+
+// SimulateRemove reports the blast radius of removing nodes without
+// mutating the skeleton. It returns, for each given key that would move,
+// the node the key would be placed on after the removal. Keys that keep
+// their node are omitted.
+func (sr *SkeletonRendezvous) SimulateRemove(nodes []string, keys []string) map[string]string {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+
+	simulated := sr.clone()
+	simulated.removeNodes(nodes)
+
+	moved := make(map[string]string)
+
+	for _, key := range keys {
+		newNode, _ := simulated.peekNode(key)
+		oldNode, _ := sr.peekNode(key)
+
+		if newNode != oldNode {
+			moved[key] = newNode
+		}
+	}
+
+	return moved
+}
+
+// copyClusters copies every cluster, so the copy is not changed along
+// with the topology.
+func copyClusters(clusters [][]string) [][]string {
+	copied := make([][]string, len(clusters))
+
+	for i, cluster := range clusters {
+		copied[i] = append(make([]string, 0, len(cluster)), cluster...)
+	}
+
+	return copied
+}
+
+// clone copies the topology, the caller must hold the read lock. The clone
+// shares the hasher, but not the loads nor the watchers.
+func (sr *SkeletonRendezvous) clone() *SkeletonRendezvous {
+	cloned := &SkeletonRendezvous{
+		options:      sr.options,
+		Clusters:     copyClusters(sr.Clusters),
+		Nodes:        append(make([]string, 0, len(sr.Nodes)), sr.Nodes...),
+		VirtualNodes: sr.VirtualNodes,
+		hasher:       sr.hasher,
+
+		// the tables are never modified once built, so they are shared
+		clusterIndexes: sr.clusterIndexes,
+		spans:          sr.spans,
+		hashedNodes:    sr.hashedNodes,
+	}
+
+	cloned.setNodeWeights(sr.nodeWeights)
+
+	if sr.nodeInfo != nil {
+		cloned.nodeInfo = make(map[string]Node, len(sr.nodeInfo))
+
+		for id, node := range sr.nodeInfo {
+			cloned.nodeInfo[id] = node.clone()
+		}
+	}
+
+	cloned.setClusterWeights(sr.clusterWeights)
+	cloned.setHealth(sr.health)
+
+	for node, state := range sr.states {
+		cloned.setState(state, []string{node})
+	}
+
+	for key, node := range sr.pins {
+		if cloned.pins == nil {
+			cloned.pins = make(map[string]string, len(sr.pins))
+		}
+
+		cloned.pins[key] = node
+	}
+
+	for node, join := range sr.joined {
+		if cloned.joined == nil {
+			cloned.joined = make(map[string]nodeJoin, len(sr.joined))
+		}
+
+		cloned.joined[node] = join
+	}
+
+	for node, factor := range sr.ramps {
+		if cloned.ramps == nil {
+			cloned.ramps = make(map[string]float64, len(sr.ramps))
+		}
+
+		cloned.ramps[node] = factor
+	}
+
+	return cloned
+}
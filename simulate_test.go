@@ -0,0 +1,46 @@
+package rendezvous
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulateRemove(t *testing.T) {
+	t.Run("should report moved keys without mutating skeleton", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(2), MinClusterSize(2))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4", "jg5", "jg6"})
+
+		keys := make([]string, 0)
+		before := make(map[string]string)
+
+		for i := 0; i < 200; i++ {
+			key := "key-" + strconv.Itoa(i)
+			keys = append(keys, key)
+			before[key] = mustFindNode(t, sr, key)
+		}
+
+		clusters := sr.Clusters
+
+		moved := sr.SimulateRemove([]string{"jg2"}, keys)
+
+		assert.Equal(t, clusters, sr.Clusters)
+
+		for _, key := range keys {
+			assert.Equal(t, before[key], mustFindNode(t, sr, key))
+
+			if before[key] == "jg2" {
+				assert.Contains(t, moved, key)
+			}
+		}
+
+		for key, node := range moved {
+			assert.NotEqual(t, "jg2", node)
+			assert.NotEqual(t, before[key], node)
+		}
+	})
+}
@@ -0,0 +1,307 @@
+package rendezvous
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// snapshotVersion is the version of the snapshot format, the first byte of
+// the binary form.
+const snapshotVersion = 1
+
+// snapshot is the persisted form of the skeleton. Maps are encoded with
+// sorted keys, so the same skeleton always encodes to the same bytes.
+type snapshot struct {
+	Epoch                 uint64               `json:"epoch"`
+	FanOut                int                  `json:"fan_out"`
+	HashName              string               `json:"hash_name,omitempty"`
+	Seed                  uint64               `json:"seed,omitempty"`
+	ClusterSize           int                  `json:"cluster_size"`
+	MinClusterSize        int                  `json:"min_cluster_size"`
+	Replicas              int                  `json:"replicas"`
+	SelectMin             bool                 `json:"select_min,omitempty"`
+	StableClusterCount    bool                 `json:"stable_cluster_count,omitempty"`
+	OverflowPolicy        OverflowPolicy       `json:"overflow_policy,omitempty"`
+	BoundedLoad           bool                 `json:"bounded_load,omitempty"`
+	LoadEpsilon           float64              `json:"load_epsilon,omitempty"`
+	HashedAssignment      bool                 `json:"hashed_assignment,omitempty"`
+	Placement             PlacementPolicy      `json:"placement,omitempty"`
+	ZoneLabel             string               `json:"zone_label"`
+	DisableRedistribution bool                 `json:"disable_redistribution,omitempty"`
+	NodeTTL               time.Duration        `json:"node_ttl,omitempty"`
+	Depth                 int                  `json:"depth,omitempty"`
+	RampUp                time.Duration        `json:"ramp_up,omitempty"`
+	RampUpEpochs          int                  `json:"ramp_up_epochs,omitempty"`
+	Clusters              [][]string           `json:"clusters"`
+	Nodes                 []string             `json:"nodes"`
+	VirtualNodes          int                  `json:"virtual_nodes"`
+	ClusterWeights        []float64            `json:"cluster_weights,omitempty"`
+	NodeWeights           map[string]float64   `json:"node_weights,omitempty"`
+	Health                map[string]float64   `json:"health,omitempty"`
+	NodeInfo              map[string]Node      `json:"node_info,omitempty"`
+	States                map[string]NodeState `json:"states,omitempty"`
+	Pins                  map[string]string    `json:"pins,omitempty"`
+}
+
+// MarshalJSON encodes the options and the topology of the skeleton. The hash
+// is encoded by its registered name, a hash without name is not encoded and
+// the skeleton decoding the snapshot keeps its own hash.
+func (sr *SkeletonRendezvous) MarshalJSON() ([]byte, error) {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+
+	return json.Marshal(sr.snapshot())
+}
+
+// UnmarshalJSON restores the options and the topology encoded by MarshalJSON.
+func (sr *SkeletonRendezvous) UnmarshalJSON(data []byte) error {
+	var snap snapshot
+
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return err
+	}
+
+	return sr.restore(snap)
+}
+
+// MarshalBinary encodes the skeleton like MarshalJSON, prefixed with the
+// version of the snapshot format.
+func (sr *SkeletonRendezvous) MarshalBinary() ([]byte, error) {
+	data, err := sr.MarshalJSON()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte{snapshotVersion}, data...), nil
+}
+
+// UnmarshalBinary restores the skeleton encoded by MarshalBinary.
+func (sr *SkeletonRendezvous) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] != snapshotVersion {
+		return fmt.Errorf("%w: unknown snapshot version", ErrInvalidOption)
+	}
+
+	return sr.UnmarshalJSON(data[1:])
+}
+
+// snapshot copies the skeleton into its persisted form, the caller must
+// hold the read lock.
+func (sr *SkeletonRendezvous) snapshot() snapshot {
+	return snapshot{
+		Epoch:                 sr.epoch,
+		FanOut:                sr.options.fanOut,
+		HashName:              sr.options.hashName,
+		Seed:                  sr.options.seed,
+		ClusterSize:           sr.options.clusterSize,
+		MinClusterSize:        sr.options.minClusterSize,
+		Replicas:              sr.options.replicas,
+		SelectMin:             sr.options.selectMin,
+		StableClusterCount:    sr.options.stableClusterCount,
+		OverflowPolicy:        sr.options.overflowPolicy,
+		BoundedLoad:           sr.options.boundedLoad,
+		LoadEpsilon:           sr.options.loadEpsilon,
+		HashedAssignment:      sr.options.hashedAssignment,
+		Placement:             sr.options.placement,
+		ZoneLabel:             sr.options.zoneLabel,
+		DisableRedistribution: sr.options.disableRedistribution,
+		NodeTTL:               sr.options.nodeTTL,
+		Depth:                 sr.options.depth,
+		RampUp:                sr.options.rampUp,
+		RampUpEpochs:          sr.options.rampUpEpochs,
+		Clusters:              sr.Clusters,
+		Nodes:                 sr.Nodes,
+		VirtualNodes:          sr.VirtualNodes,
+		ClusterWeights:        sr.clusterWeights,
+		NodeWeights:           sr.nodeWeights,
+		Health:                sr.health,
+		NodeInfo:              sr.nodeInfo,
+		States:                sr.states,
+		Pins:                  sr.pins,
+	}
+}
+
+// validate checks the topology of the snapshot is the one the skeleton
+// would build with the options, so a corrupted snapshot is rejected before
+// it replaces anything: Nodes must be the union of the clusters without
+// duplicates, no cluster may be empty and the virtual nodes must be the
+// depth for the clusters.
+func (snap snapshot) validate(opts Options) error {
+	clusterNodes := make(map[string]bool, len(snap.Nodes))
+
+	for clusterIndex, cluster := range snap.Clusters {
+		if len(cluster) == 0 {
+			return fmt.Errorf("%w: cluster %d of snapshot is empty", ErrInvalidTopology, clusterIndex)
+		}
+
+		for _, node := range cluster {
+			if clusterNodes[node] {
+				return fmt.Errorf("%w: node %s of snapshot exists in more than one cluster", ErrInvalidTopology, node)
+			}
+
+			clusterNodes[node] = true
+		}
+	}
+
+	nodes := make(map[string]bool, len(snap.Nodes))
+
+	for _, node := range snap.Nodes {
+		if nodes[node] {
+			return fmt.Errorf("%w: node %s exists more than once in snapshot nodes", ErrInvalidTopology, node)
+		}
+
+		if !clusterNodes[node] {
+			return fmt.Errorf("%w: node %s of snapshot does not exist in any cluster", ErrInvalidTopology, node)
+		}
+
+		nodes[node] = true
+	}
+
+	if len(nodes) != len(clusterNodes) {
+		return fmt.Errorf("%w: %d nodes exist in snapshot clusters, but snapshot has %d nodes",
+			ErrInvalidTopology, len(clusterNodes), len(nodes))
+	}
+
+	if depth := virtualNodesFor(len(snap.Clusters), opts.fanOut, opts.depth); snap.VirtualNodes != depth {
+		return fmt.Errorf("%w: %d virtual nodes in snapshot for %d clusters with fan out %d, expected %d",
+			ErrInvalidTopology, snap.VirtualNodes, len(snap.Clusters), opts.fanOut, depth)
+	}
+
+	return nil
+}
+
+// restore replaces the options and the topology with the snapshot, the
+// options are checked like the options given to the constructor and the
+// topology like validate does.
+func (sr *SkeletonRendezvous) restore(snap snapshot) error {
+	options := []Option{
+		FanOut(snap.FanOut),
+		ClusterSize(snap.ClusterSize),
+		MinClusterSize(snap.MinClusterSize),
+		Replicas(snap.Replicas),
+		SelectMin(snap.SelectMin),
+		StableClusterCount(snap.StableClusterCount),
+		Overflow(snap.OverflowPolicy),
+		Seed(snap.Seed),
+		HashedAssignment(snap.HashedAssignment),
+		Placement(snap.Placement),
+		ZoneLabel(snap.ZoneLabel),
+		DisableRedistribution(snap.DisableRedistribution),
+	}
+
+	if snap.BoundedLoad {
+		options = append(options, BoundedLoad(snap.LoadEpsilon))
+	}
+
+	if snap.NodeTTL > 0 {
+		options = append(options, NodeTTL(snap.NodeTTL))
+	}
+
+	if snap.Depth > 0 {
+		options = append(options, Depth(snap.Depth))
+	}
+
+	if snap.RampUp > 0 {
+		options = append(options, RampUp(snap.RampUp))
+	}
+
+	if snap.RampUpEpochs > 0 {
+		options = append(options, RampUpEpochs(snap.RampUpEpochs))
+	}
+
+	if snap.HashName != "" {
+		options = append(options, HashAlgorithmByName(snap.HashName))
+	}
+
+	var err error
+
+	sr.updateTopology(func() {
+		opts := sr.options
+		opts.boundedLoad = false
+		opts.loadEpsilon = 0
+		opts.nodeTTL = 0
+		opts.depth = 0
+		opts.rampUp = 0
+		opts.rampUpEpochs = 0
+
+		for _, option := range options {
+			if err = option(&opts); err != nil {
+				return
+			}
+		}
+
+		if err = opts.validate(); err != nil {
+			return
+		}
+
+		if err = snap.validate(opts); err != nil {
+			return
+		}
+
+		// a new hasher is only created when the hash changes, a hasher
+		// shared with a clone keeps being shared.
+		if snap.HashName != "" || opts.seed != sr.options.seed {
+			sr.hasher = newHasher(opts)
+		}
+
+		sr.options = opts
+
+		sr.Clusters = make([][]string, 0, len(snap.Clusters))
+
+		for _, cluster := range snap.Clusters {
+			sr.Clusters = append(sr.Clusters, append(make([]string, 0, len(cluster)), cluster...))
+		}
+
+		sr.Nodes = append(make([]string, 0, len(snap.Nodes)), snap.Nodes...)
+		sr.VirtualNodes = snap.VirtualNodes
+
+		sr.nodeInfo = nil
+
+		for id, node := range snap.NodeInfo {
+			if sr.nodeInfo == nil {
+				sr.nodeInfo = make(map[string]Node, len(snap.NodeInfo))
+			}
+
+			sr.nodeInfo[id] = node.clone()
+		}
+
+		sr.deadlines = nil
+		sr.register(sr.Nodes)
+
+		sr.states = nil
+
+		for node, state := range snap.States {
+			sr.setState(state, []string{node})
+		}
+
+		sr.refreshUnavailable()
+
+		sr.pins = nil
+
+		for key, node := range snap.Pins {
+			if sr.pins == nil {
+				sr.pins = make(map[string]string, len(snap.Pins))
+			}
+
+			sr.pins[key] = node
+		}
+
+		// the restored nodes are not ramped up, they had joined before.
+		sr.joined = nil
+		sr.ramps = nil
+
+		sr.setNodeWeights(snap.NodeWeights)
+		sr.setHealth(snap.Health)
+		sr.setClusterWeights(snap.ClusterWeights)
+		sr.topologyChanged()
+
+		// the epoch keeps increasing, an older epoch of the snapshot would
+		// give the restored topology the epoch of another one.
+		if snap.Epoch > sr.epoch {
+			sr.epoch = snap.Epoch
+		}
+	})
+
+	return err
+}
@@ -0,0 +1,229 @@
+package rendezvous
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshot(t *testing.T) {
+	newTopology := func(t *testing.T) *SkeletonRendezvous {
+		sr, err := NewSkeletonRendezvous(FanOut(4), ClusterSize(3), Replicas(2), Seed(7), HashAlgorithmByName("fnv64a"))
+
+		assert.NoError(t, err)
+
+		sr.SetNodeList([]Node{
+			{ID: "jg1", Addr: "10.0.0.1:80", Weight: 2},
+			{ID: "jg2", Labels: map[string]string{"zone": "a"}},
+			{ID: "jg3"}, {ID: "jg4"}, {ID: "jg5"}, {ID: "jg6"}, {ID: "jg7"},
+		})
+		sr.SetHealth(map[string]float64{"jg3": 0.5})
+
+		return sr
+	}
+
+	t.Run("should restore topology and placement from json", func(t *testing.T) {
+		sr := newTopology(t)
+
+		data, err := json.Marshal(sr)
+
+		assert.NoError(t, err)
+
+		restored, err := NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+		assert.NoError(t, json.Unmarshal(data, restored))
+
+		assert.True(t, sr.Equal(restored))
+
+		node, ok := restored.NodeInfo("jg1")
+
+		assert.True(t, ok)
+		assert.Equal(t, "10.0.0.1:80", node.Addr)
+
+		for i := 0; i < 200; i++ {
+			key := "key-" + strconv.Itoa(i)
+
+			assert.Equal(t, mustFindNode(t, sr, key), mustFindNode(t, restored, key))
+		}
+
+		again, err := json.Marshal(restored)
+
+		assert.NoError(t, err)
+		assert.Equal(t, data, again)
+	})
+
+	t.Run("should restore topology from binary", func(t *testing.T) {
+		sr := newTopology(t)
+
+		data, err := sr.MarshalBinary()
+
+		assert.NoError(t, err)
+
+		restored, err := NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+		assert.NoError(t, restored.UnmarshalBinary(data))
+		assert.True(t, sr.Equal(restored))
+
+		again, err := restored.MarshalBinary()
+
+		assert.NoError(t, err)
+		assert.Equal(t, data, again)
+	})
+
+	t.Run("should keep own hash when snapshot hash has no name", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(HashFunc(fnv64aSum))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3"})
+
+		data, err := json.Marshal(sr)
+
+		assert.NoError(t, err)
+
+		restored, err := NewSkeletonRendezvous(HashFunc(fnv64aSum))
+
+		assert.NoError(t, err)
+		assert.NoError(t, json.Unmarshal(data, restored))
+		assert.True(t, sr.Equal(restored))
+	})
+
+	t.Run("should emit one event with a newer epoch when restoring", func(t *testing.T) {
+		source, err := NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		source.SetNodes([]string{"a", "b", "c", "d"})
+
+		data, err := json.Marshal(source)
+
+		assert.NoError(t, err)
+
+		sr, err := NewSkeletonRendezvous(EpochHistory(2))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"x", "y"})
+		assert.Equal(t, source.Epoch(), sr.Epoch())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events := sr.WatchTopology(ctx)
+
+		assert.NoError(t, json.Unmarshal(data, sr))
+
+		var event TopologyEvent
+
+		select {
+		case event = <-events:
+		case <-time.After(time.Second):
+			assert.FailNow(t, "no topology event")
+		}
+
+		assert.Equal(t, uint64(2), event.Epoch)
+		assert.Equal(t, uint64(2), sr.Epoch())
+		assert.ElementsMatch(t, []string{"a", "b", "c", "d"}, event.NodesAdded)
+		assert.ElementsMatch(t, []string{"x", "y"}, event.NodesRemoved)
+
+		previous, err := sr.PreviousOwner("key", 1)
+
+		assert.NoError(t, err)
+		assert.Contains(t, []string{"x", "y"}, previous)
+
+		select {
+		case event := <-events:
+			assert.Fail(t, "unexpected topology event", "%+v", event)
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+
+	t.Run("should keep the newer epoch when restoring", func(t *testing.T) {
+		source, err := NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		for i := 0; i < 5; i++ {
+			source.SetNodes([]string{"a", "b", "c", "d", strconv.Itoa(i)})
+		}
+
+		newer, err := json.Marshal(source)
+
+		assert.NoError(t, err)
+
+		sr, err := NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"x", "y"})
+
+		assert.NoError(t, json.Unmarshal(newer, sr))
+		assert.Equal(t, source.Epoch(), sr.Epoch())
+
+		older, err := NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		older.SetNodes([]string{"a", "b"})
+
+		data, err := json.Marshal(older)
+
+		assert.NoError(t, err)
+
+		epoch := sr.Epoch()
+
+		assert.NoError(t, json.Unmarshal(data, sr))
+		assert.Equal(t, epoch+1, sr.Epoch())
+	})
+
+	t.Run("should reject invalid snapshot", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		assert.ErrorIs(t, json.Unmarshal([]byte(`{"fan_out": 1}`), sr), ErrInvalidOption)
+		assert.ErrorIs(t, sr.UnmarshalBinary([]byte{0}), ErrInvalidOption)
+		assert.Equal(t, 3, sr.options.fanOut)
+	})
+
+	t.Run("should reject snapshot with malformed topology", func(t *testing.T) {
+		snapshots := map[string]string{
+			"too many virtual nodes": `{"fan_out":2,"cluster_size":1,"min_cluster_size":1,"replicas":1,` +
+				`"clusters":[["jg1"],["jg2"]],"nodes":["jg1","jg2"],"virtual_nodes":80}`,
+			"no virtual nodes": `{"fan_out":2,"cluster_size":1,"min_cluster_size":1,"replicas":1,` +
+				`"clusters":[["jg1"],["jg2"]],"nodes":["jg1","jg2"],"virtual_nodes":0}`,
+			"duplicated node": `{"fan_out":2,"cluster_size":1,"min_cluster_size":1,"replicas":1,` +
+				`"clusters":[["jg1"],["jg2"]],"nodes":["jg1","jg2","jg2"],"virtual_nodes":1}`,
+			"node in two clusters": `{"fan_out":2,"cluster_size":1,"min_cluster_size":1,"replicas":1,` +
+				`"clusters":[["jg1"],["jg1"]],"nodes":["jg1"],"virtual_nodes":1}`,
+			"node without cluster": `{"fan_out":2,"cluster_size":1,"min_cluster_size":1,"replicas":1,` +
+				`"clusters":[["jg1"],["jg2"]],"nodes":["jg1","jg2","jg3"],"virtual_nodes":1}`,
+			"cluster node missing from nodes": `{"fan_out":2,"cluster_size":1,"min_cluster_size":1,"replicas":1,` +
+				`"clusters":[["jg1"],["jg2"]],"nodes":["jg1"],"virtual_nodes":1}`,
+			"empty cluster": `{"fan_out":2,"cluster_size":1,"min_cluster_size":1,"replicas":1,` +
+				`"clusters":[["jg1"],[]],"nodes":["jg1"],"virtual_nodes":1}`,
+		}
+
+		for name, data := range snapshots {
+			sr := newTopology(t)
+			nodes := append([]string(nil), sr.Nodes...)
+			clusters := copyClusters(sr.Clusters)
+			epoch := sr.Epoch()
+
+			assert.ErrorIs(t, json.Unmarshal([]byte(data), sr), ErrInvalidTopology, name)
+			assert.ErrorIs(t, sr.UnmarshalBinary(append([]byte{snapshotVersion}, data...)), ErrInvalidTopology, name)
+
+			assert.Equal(t, 4, sr.options.fanOut, name)
+			assert.Equal(t, nodes, sr.Nodes, name)
+			assert.Equal(t, clusters, sr.Clusters, name)
+			assert.Equal(t, epoch, sr.Epoch(), name)
+			assert.NoError(t, sr.Validate(), name)
+		}
+	})
+}
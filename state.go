@@ -0,0 +1,126 @@
+package rendezvous
+
+// NodeState is the availability of a node for new keys.
+type NodeState int
+
+const (
+	// NodeUp receives keys as usual
+	NodeUp NodeState = iota
+
+	// NodeDraining stops receiving keys, but is still reported as the
+	// previous owner of its keys so they can be migrated gracefully
+	NodeDraining
+
+	// NodeDown is excluded from selection entirely
+	NodeDown
+)
+
+// MarkUp makes the nodes receive keys again.
+func (sr *SkeletonRendezvous) MarkUp(nodes ...string) {
+	sr.update(func() {
+		sr.setState(NodeUp, nodes)
+	})
+}
+
+// MarkDraining stops the nodes from receiving keys, their keys are selected
+// as if the nodes were down while FindPreviousNode still reports them.
+func (sr *SkeletonRendezvous) MarkDraining(nodes ...string) {
+	sr.update(func() {
+		sr.setState(NodeDraining, nodes)
+	})
+}
+
+// MarkDown excludes the nodes from selection without restructuring the
+// clusters, their keys fall back to the next highest score.
+func (sr *SkeletonRendezvous) MarkDown(nodes ...string) {
+	sr.update(func() {
+		sr.setState(NodeDown, nodes)
+	})
+}
+
+// SyncNodeStates makes the nodes of the skeleton match the given nodes like
+// SyncNodes and sets their states in the same change, so lookups never
+// observe the nodes before their states. The nodes missing from states are
+// up.
+func (sr *SkeletonRendezvous) SyncNodeStates(nodes []string, states map[string]NodeState) {
+	sr.updateTopology(func() {
+		sr.syncNodes(nodes)
+
+		byState := make(map[NodeState][]string)
+
+		for _, node := range nodes {
+			byState[states[node]] = append(byState[states[node]], node)
+		}
+
+		for _, state := range []NodeState{NodeUp, NodeDraining, NodeDown} {
+			sr.setState(state, byState[state])
+		}
+	})
+}
+
+// State returns the state of the node, nodes are up unless marked otherwise.
+func (sr *SkeletonRendezvous) State(node string) NodeState {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+
+	return sr.states[node]
+}
+
+// FindPreviousNode find selected node like FindNode, but treats draining
+// nodes as up. It returns the node which owned the key before draining
+// started, so a key whose previous node differs from its node has to be
+// migrated.
+func (sr *SkeletonRendezvous) FindPreviousNode(key string) (string, error) {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+
+	return sr.selectNode(key, sr.nodesIn(NodeDown), false)
+}
+
+func (sr *SkeletonRendezvous) setState(state NodeState, nodes []string) {
+	if sr.states == nil {
+		sr.states = make(map[string]NodeState)
+	}
+
+	for _, node := range nodes {
+		if state == NodeUp {
+			delete(sr.states, node)
+
+			continue
+		}
+
+		sr.states[node] = state
+	}
+
+	sr.refreshUnavailable()
+}
+
+// unavailableNodes returns the nodes which do not receive keys, nil when
+// every node is up. The set is shared and must not be modified.
+func (sr *SkeletonRendezvous) unavailableNodes() map[string]struct{} {
+	return sr.unavailable
+}
+
+// refreshUnavailable rebuilds the unavailable nodes, it must be called
+// whenever the states change.
+func (sr *SkeletonRendezvous) refreshUnavailable() {
+	sr.unavailable = sr.nodesIn(NodeDraining, NodeDown)
+}
+
+func (sr *SkeletonRendezvous) nodesIn(states ...NodeState) map[string]struct{} {
+	var nodes map[string]struct{}
+
+	for node, nodeState := range sr.states {
+		for _, state := range states {
+			if nodeState == state {
+				if nodes == nil {
+					nodes = make(map[string]struct{})
+				}
+
+				nodes[node] = struct{}{}
+			}
+		}
+	}
+
+	return nodes
+}
@@ -0,0 +1,139 @@
+package rendezvous
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeState(t *testing.T) {
+	newSkeleton := func(t *testing.T) *SkeletonRendezvous {
+		sr, err := NewSkeletonRendezvous(ClusterSize(3), HashAlgorithm(newMixedHash()))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4", "jg5", "jg6"})
+
+		return sr
+	}
+
+	t.Run("should exclude down node without restructuring clusters", func(t *testing.T) {
+		sr := newSkeleton(t)
+		clusters := sr.Clusters
+
+		sr.MarkDown("jg1")
+
+		assert.Equal(t, clusters, sr.Clusters)
+		assert.Equal(t, NodeDown, sr.State("jg1"))
+
+		for i := 0; i < 300; i++ {
+			assert.NotEqual(t, "jg1", mustFindNode(t, sr, "key-"+strconv.Itoa(i)))
+		}
+	})
+
+	t.Run("should fall back to other clusters when a cluster is down", func(t *testing.T) {
+		sr := newSkeleton(t)
+
+		sr.MarkDown(sr.Clusters[0]...)
+
+		for i := 0; i < 300; i++ {
+			assert.Contains(t, sr.Clusters[1], mustFindNode(t, sr, "key-"+strconv.Itoa(i)))
+		}
+	})
+
+	t.Run("should report draining node as previous owner", func(t *testing.T) {
+		sr := newSkeleton(t)
+
+		before := make([]string, 300)
+
+		for i := range before {
+			before[i] = mustFindNode(t, sr, "key-"+strconv.Itoa(i))
+		}
+
+		sr.MarkDraining("jg2")
+
+		for i, node := range before {
+			key := "key-" + strconv.Itoa(i)
+
+			previous, err := sr.FindPreviousNode(key)
+
+			assert.NoError(t, err)
+			assert.Equal(t, node, previous)
+			assert.NotEqual(t, "jg2", mustFindNode(t, sr, key))
+		}
+	})
+
+	t.Run("should receive keys again once marked up", func(t *testing.T) {
+		sr := newSkeleton(t)
+
+		expected := mustFindNode(t, sr, "key")
+
+		sr.MarkDown(expected)
+		assert.NotEqual(t, expected, mustFindNode(t, sr, "key"))
+
+		sr.MarkUp(expected)
+		assert.Equal(t, expected, mustFindNode(t, sr, "key"))
+		assert.Equal(t, NodeUp, sr.State(expected))
+	})
+
+	t.Run("should return ErrNoNodes when every node is down", func(t *testing.T) {
+		sr := newSkeleton(t)
+
+		sr.MarkDown(sr.Nodes...)
+
+		_, err := sr.FindNode("key")
+
+		assert.ErrorIs(t, err, ErrNoNodes)
+	})
+
+	t.Run("should sync nodes along with their states in one change", func(t *testing.T) {
+		sr := newSkeleton(t)
+
+		keys := make([]string, 0, 300)
+
+		for i := 0; i < 300; i++ {
+			keys = append(keys, "key-"+strconv.Itoa(i))
+		}
+
+		placed := make(map[string]bool)
+
+		sr.Watch(keys, func(key, oldNode, newNode string) {
+			placed[newNode] = true
+		})
+
+		sr.SyncNodeStates([]string{"jg2", "jg3", "jg4", "jg5", "jg6", "jg7"}, map[string]NodeState{
+			"jg3": NodeDraining,
+			"jg7": NodeDown,
+		})
+
+		assert.Equal(t, []string{"jg2", "jg3", "jg4", "jg5", "jg6", "jg7"}, sr.Nodes)
+		assert.Equal(t, NodeDraining, sr.State("jg3"))
+		assert.Equal(t, NodeDown, sr.State("jg7"))
+		assert.Equal(t, NodeUp, sr.State("jg2"))
+		assert.NotEmpty(t, placed)
+		assert.False(t, placed["jg7"])
+
+		sr.SyncNodeStates(sr.Nodes, nil)
+
+		assert.Equal(t, NodeUp, sr.State("jg3"))
+		assert.Equal(t, NodeUp, sr.State("jg7"))
+	})
+
+	t.Run("should keep states in snapshot", func(t *testing.T) {
+		sr := newSkeleton(t)
+
+		sr.MarkDraining("jg3")
+
+		data, err := json.Marshal(sr)
+
+		assert.NoError(t, err)
+
+		restored, err := NewSkeletonRendezvous(HashAlgorithm(newMixedHash()))
+
+		assert.NoError(t, err)
+		assert.NoError(t, json.Unmarshal(data, restored))
+		assert.Equal(t, NodeDraining, restored.State("jg3"))
+	})
+}
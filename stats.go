@@ -0,0 +1,82 @@
+package rendezvous
+
+// RingStats is a point in time summary of the skeleton topology,
+// suitable for periodic metrics emission.
+type RingStats struct {
+	// Nodes is the number of nodes known by the skeleton
+	Nodes int
+
+	// Clusters is the number of generated clusters
+	Clusters int
+
+	// VirtualNodes is the depth of the skeleton branch tree
+	VirtualNodes int
+
+	// Positions is the number of branch positions, the fan out to the power
+	// of the virtual nodes, the positions beyond the last cluster are mapped
+	// by the overflow policy
+	Positions int
+
+	// MinClusterSize is the number of nodes in the smallest cluster
+	MinClusterSize int
+
+	// MaxClusterSize is the number of nodes in the biggest cluster
+	MaxClusterSize int
+
+	// AvgClusterSize is the average number of nodes per cluster
+	AvgClusterSize float64
+
+	// EmptyClusters is the number of clusters without any node
+	EmptyClusters int
+}
+
+// Stats returns statistics of the current topology, computed
+// in a single pass over the clusters.
+func (sr *SkeletonRendezvous) Stats() RingStats {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+
+	return sr.stats()
+}
+
+func (sr *SkeletonRendezvous) stats() RingStats {
+	stats := RingStats{
+		Nodes:        len(sr.Nodes),
+		Clusters:     len(sr.Clusters),
+		VirtualNodes: sr.VirtualNodes,
+	}
+
+	if len(sr.Clusters) == 0 {
+		return stats
+	}
+
+	stats.Positions = 1
+
+	for i := 0; i < sr.VirtualNodes; i++ {
+		stats.Positions *= sr.options.fanOut
+	}
+
+	total := 0
+	stats.MinClusterSize = len(sr.Clusters[0])
+
+	for _, cluster := range sr.Clusters {
+		size := len(cluster)
+		total += size
+
+		if size < stats.MinClusterSize {
+			stats.MinClusterSize = size
+		}
+
+		if size > stats.MaxClusterSize {
+			stats.MaxClusterSize = size
+		}
+
+		if size == 0 {
+			stats.EmptyClusters++
+		}
+	}
+
+	stats.AvgClusterSize = float64(total) / float64(len(sr.Clusters))
+
+	return stats
+}
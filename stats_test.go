@@ -0,0 +1,35 @@
+package rendezvous
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStats(t *testing.T) {
+	t.Run("should return zero stats on empty skeleton", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+		assert.Equal(t, RingStats{}, sr.Stats())
+	})
+
+	t.Run("should summarize clusters of the skeleton", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(2), MinClusterSize(2))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4", "jg5"})
+
+		stats := sr.Stats()
+
+		assert.Equal(t, 5, stats.Nodes)
+		assert.Equal(t, 2, stats.Clusters)
+		assert.Equal(t, sr.VirtualNodes, stats.VirtualNodes)
+		assert.Equal(t, 3, stats.Positions)
+		assert.Equal(t, 2, stats.MinClusterSize)
+		assert.Equal(t, 3, stats.MaxClusterSize)
+		assert.Equal(t, 2.5, stats.AvgClusterSize)
+		assert.Equal(t, 0, stats.EmptyClusters)
+	})
+}
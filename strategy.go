@@ -0,0 +1,139 @@
+package rendezvous
+
+import (
+	"fmt"
+)
+
+// Strategy assigns the nodes into clusters when the clusters are generated,
+// such as to place nodes by weight or to keep a pre-existing assignment.
+// The nodes carry their metadata and are ordered like the default strategy
+// receives them. Every node should be placed in exactly one cluster: nodes
+// left out are appended to the last cluster, while unknown and duplicated
+// nodes and empty clusters are dropped.
+type Strategy interface {
+	Assign(nodes []Node, options AssignOptions) [][]string
+}
+
+// StrategyFunc is a function used as a Strategy.
+type StrategyFunc func(nodes []Node, options AssignOptions) [][]string
+
+// Assign calls the function.
+func (f StrategyFunc) Assign(nodes []Node, options AssignOptions) [][]string {
+	return f(nodes, options)
+}
+
+// AssignOptions are the options of the skeleton a strategy is given.
+type AssignOptions struct {
+	// ClusterSize is the number of nodes in a cluster
+	ClusterSize int
+
+	// MinClusterSize is the minimum number of nodes in a cluster
+	MinClusterSize int
+
+	// DisableRedistribution keeps an undersized last cluster instead of
+	// spreading its nodes into the other clusters
+	DisableRedistribution bool
+
+	// ZoneLabel is the node label holding the zone of a node
+	ZoneLabel string
+}
+
+// DefaultStrategy fills the clusters with the nodes in order up to the
+// cluster size, an undersized last cluster is spread over the other clusters
+// unless the redistribution is disabled. Strategies may reorder the nodes
+// and delegate to it.
+var DefaultStrategy Strategy = StrategyFunc(func(nodes []Node, options AssignOptions) [][]string {
+	ids := make([]string, 0, len(nodes))
+
+	for _, node := range nodes {
+		ids = append(ids, node.ID)
+	}
+
+	return chunkClusters(ids, options)
+})
+
+// AssignmentStrategy sets the strategy assigning the nodes into clusters,
+// it takes precedence over the placement policy. Clusters assigned by a
+// strategy are generated again when nodes are added, and are not backfilled
+// when nodes are removed, so the assignment of the strategy holds.
+func AssignmentStrategy(strategy Strategy) Option {
+	return func(o *Options) error {
+		if strategy == nil {
+			return fmt.Errorf("%w: strategy is nil", ErrInvalidOption)
+		}
+
+		o.strategy = strategy
+
+		return nil
+	}
+}
+
+// packedInOrder reports whether the clusters are packed in order by the
+// default strategy, which AddNodes and RemoveNodes maintain incrementally.
+func (sr *SkeletonRendezvous) packedInOrder() bool {
+	return sr.options.strategy == nil && sr.options.placement == PlaceInOrder
+}
+
+func (sr *SkeletonRendezvous) assignOptions() AssignOptions {
+	return AssignOptions{
+		ClusterSize:           sr.options.clusterSize,
+		MinClusterSize:        sr.options.minClusterSize,
+		DisableRedistribution: sr.options.disableRedistribution,
+		ZoneLabel:             sr.options.zoneLabel,
+	}
+}
+
+// assignClusters assigns the nodes into clusters with the strategy, the
+// clusters are sanitized so every node is in exactly one cluster.
+func (sr *SkeletonRendezvous) assignClusters(nodes []string) [][]string {
+	if sr.options.strategy == nil {
+		return chunkClusters(nodes, sr.assignOptions())
+	}
+
+	infos := make([]Node, 0, len(nodes))
+	pending := make(map[string]bool, len(nodes))
+
+	for _, id := range nodes {
+		info, ok := sr.nodeInfo[id]
+
+		if !ok {
+			info = Node{ID: id}
+		}
+
+		infos = append(infos, info.clone())
+		pending[id] = true
+	}
+
+	clusters := make([][]string, 0)
+
+	for _, cluster := range sr.options.strategy.Assign(infos, sr.assignOptions()) {
+		newCluster := make([]string, 0, len(cluster))
+
+		for _, node := range cluster {
+			if pending[node] {
+				newCluster = append(newCluster, node)
+				delete(pending, node)
+			}
+		}
+
+		if len(newCluster) > 0 {
+			clusters = append(clusters, newCluster)
+		}
+	}
+
+	if len(pending) == 0 {
+		return clusters
+	}
+
+	if len(clusters) == 0 {
+		clusters = append(clusters, make([]string, 0, len(pending)))
+	}
+
+	for _, node := range nodes {
+		if pending[node] {
+			clusters[len(clusters)-1] = append(clusters[len(clusters)-1], node)
+		}
+	}
+
+	return clusters
+}
@@ -0,0 +1,98 @@
+package rendezvous
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// byWeight places the heaviest nodes first, then packs them in order.
+var byWeight = StrategyFunc(func(nodes []Node, options AssignOptions) [][]string {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return nodes[i].Weight > nodes[j].Weight
+	})
+
+	return DefaultStrategy.Assign(nodes, options)
+})
+
+func TestAssignmentStrategy(t *testing.T) {
+	t.Run("should assign clusters with the strategy", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(AssignmentStrategy(byWeight))
+		assert.NoError(t, err)
+
+		sr.SetNodeList([]Node{
+			{ID: "jg1", Weight: 1},
+			{ID: "jg2", Weight: 4},
+			{ID: "jg3", Weight: 2},
+			{ID: "jg4", Weight: 3},
+		})
+
+		assert.Equal(t, [][]string{{"jg2", "jg4"}, {"jg3", "jg1"}}, sr.Clusters)
+		assert.Equal(t, []string{"jg1", "jg2", "jg3", "jg4"}, sr.Nodes)
+		assert.Empty(t, sr.Verify())
+	})
+
+	t.Run("should place the same clusters as the default strategy", func(t *testing.T) {
+		nodes := []string{"jg1", "jg2", "jg3", "jg4", "jg5", "jg6", "jg7"}
+
+		sr, err := NewSkeletonRendezvous(ClusterSize(3))
+		assert.NoError(t, err)
+
+		other, err := NewSkeletonRendezvous(ClusterSize(3), AssignmentStrategy(DefaultStrategy))
+		assert.NoError(t, err)
+
+		sr.SetNodes(nodes)
+		other.SetNodes(nodes)
+
+		assert.Equal(t, sr.Clusters, other.Clusters)
+		assert.False(t, sr.Equal(other))
+	})
+
+	t.Run("should generate clusters again when nodes are added or removed", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(AssignmentStrategy(byWeight))
+		assert.NoError(t, err)
+
+		sr.SetNodeList([]Node{{ID: "jg1", Weight: 1}, {ID: "jg2", Weight: 2}})
+		sr.SetNodeList([]Node{{ID: "jg3", Weight: 3}})
+
+		assert.Equal(t, [][]string{{"jg3", "jg2", "jg1"}}, sr.Clusters)
+
+		sr.AddNodes([]string{"jg4"})
+
+		assert.Equal(t, [][]string{{"jg3", "jg2"}, {"jg1", "jg4"}}, sr.Clusters)
+
+		sr.RemoveNodes([]string{"jg2", "jg3"})
+
+		assert.Equal(t, [][]string{{"jg1", "jg4"}}, sr.Clusters)
+	})
+
+	t.Run("should sanitize the clusters of the strategy", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(AssignmentStrategy(StrategyFunc(func(nodes []Node, options AssignOptions) [][]string {
+			return [][]string{{"jg1", "unknown"}, {}, {"jg1", "jg2"}}
+		})))
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		assert.Equal(t, [][]string{{"jg1"}, {"jg2", "jg3", "jg4"}}, sr.Clusters)
+		assert.Equal(t, []string{"jg1", "jg2", "jg3", "jg4"}, sr.Nodes)
+	})
+
+	t.Run("should place every node when the strategy returns no cluster", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(AssignmentStrategy(StrategyFunc(func(nodes []Node, options AssignOptions) [][]string {
+			return nil
+		})))
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3"})
+
+		assert.Equal(t, [][]string{{"jg1", "jg2", "jg3"}}, sr.Clusters)
+	})
+
+	t.Run("should reject nil strategy", func(t *testing.T) {
+		_, err := NewSkeletonRendezvous(AssignmentStrategy(nil))
+
+		assert.ErrorIs(t, err, ErrInvalidOption)
+	})
+}
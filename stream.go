@@ -0,0 +1,114 @@
+package rendezvous
+
+import (
+	"context"
+	"fmt"
+)
+
+// streamBatchSize is the largest number of keys AssignStream looks up under
+// one lock, it is also the capacity of the assignments channel.
+const streamBatchSize = 64
+
+// Assignment is the node selected for a key of a stream.
+type Assignment struct {
+	// Key is the assigned key
+	Key string
+
+	// Node is the node selected for the key, empty on error
+	Node string
+
+	// Err is the error of the lookup, such as ErrNoNodes
+	Err error
+}
+
+// AssignStream find the selected node of each key received from the keys
+// like FindNodes, emitting the assignments in the order of the keys. The
+// keys waiting in the channel are looked up in batches, and no key is read
+// while the assignments are not consumed. The assignments channel is closed
+// once the keys channel is closed and drained, or the context is done.
+func (sr *SkeletonRendezvous) AssignStream(ctx context.Context, keys <-chan string) (<-chan Assignment, error) {
+	if keys == nil {
+		return nil, fmt.Errorf("%w: keys channel is nil", ErrInvalidOption)
+	}
+
+	assignments := make(chan Assignment, streamBatchSize)
+
+	go func() {
+		defer close(assignments)
+
+		batch := make([]string, 0, streamBatchSize)
+
+		for {
+			batch = batch[:0]
+
+			select {
+			case key, ok := <-keys:
+				if !ok {
+					return
+				}
+
+				batch = append(batch, key)
+			case <-ctx.Done():
+				return
+			}
+
+			batch, open := receiveWaiting(keys, batch)
+
+			for _, assignment := range sr.assignBatch(batch) {
+				select {
+				case assignments <- assignment:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			if !open {
+				return
+			}
+		}
+	}()
+
+	return assignments, nil
+}
+
+// receiveWaiting appends the keys waiting in the channel to the batch until
+// it is full, it reports whether the channel is still open.
+func receiveWaiting(keys <-chan string, batch []string) ([]string, bool) {
+	for len(batch) < streamBatchSize {
+		select {
+		case key, ok := <-keys:
+			if !ok {
+				return batch, false
+			}
+
+			batch = append(batch, key)
+		default:
+			return batch, true
+		}
+	}
+
+	return batch, true
+}
+
+// assignBatch find the selected node of each key under one lock.
+func (sr *SkeletonRendezvous) assignBatch(keys []string) []Assignment {
+	var events []LookupEvent
+
+	// deferred first, the observers are notified once the lock is released.
+	defer func() { sr.notifyLookups(events) }()
+
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+
+	unavailable := sr.unavailableNodes()
+	assignments := make([]Assignment, len(keys))
+
+	for i, key := range keys {
+		start := sr.lookupStart()
+		node, err := sr.findNodeIn(key, unavailable)
+		sr.recordLookup(&events, key, node, err, start)
+		assignments[i] = Assignment{Key: key, Node: node, Err: err}
+	}
+
+	return assignments
+}
@@ -0,0 +1,125 @@
+package rendezvous
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssignStream(t *testing.T) {
+	// produce sends the keys into the returned channel, closing it at the
+	// end, and counts the sent keys.
+	produce := func(ctx context.Context, keys int) (<-chan string, *int64) {
+		stream := make(chan string)
+
+		var sent int64
+
+		go func() {
+			defer close(stream)
+
+			for i := 0; i < keys; i++ {
+				select {
+				case stream <- "key-" + strconv.Itoa(i):
+					atomic.AddInt64(&sent, 1)
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+
+		return stream, &sent
+	}
+
+	t.Run("should assign every key in order", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4", "jg5"})
+		sr.MarkDown("jg2")
+
+		keys, _ := produce(context.Background(), 1000)
+
+		assignments, err := sr.AssignStream(context.Background(), keys)
+		assert.NoError(t, err)
+
+		i := 0
+
+		for assignment := range assignments {
+			key := "key-" + strconv.Itoa(i)
+
+			assert.Equal(t, key, assignment.Key)
+			assert.Equal(t, mustFindNode(t, sr, key), assignment.Node)
+			assert.NoError(t, assignment.Err)
+
+			i++
+		}
+
+		assert.Equal(t, 1000, i)
+	})
+
+	t.Run("should report the error of a key", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		keys, _ := produce(context.Background(), 1)
+
+		assignments, err := sr.AssignStream(context.Background(), keys)
+		assert.NoError(t, err)
+
+		assignment := <-assignments
+
+		assert.ErrorIs(t, assignment.Err, ErrNoNodes)
+		assert.Empty(t, assignment.Node)
+	})
+
+	t.Run("should stop reading keys while the assignments are not consumed", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3"})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		keys, sent := produce(ctx, 10000)
+
+		_, err = sr.AssignStream(ctx, keys)
+		assert.NoError(t, err)
+
+		time.Sleep(50 * time.Millisecond)
+
+		// the buffered assignments and one batch waiting to be sent.
+		assert.LessOrEqual(t, atomic.LoadInt64(sent), int64(3*streamBatchSize))
+	})
+
+	t.Run("should close the assignments once the context is done", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3"})
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		assignments, err := sr.AssignStream(ctx, make(chan string))
+		assert.NoError(t, err)
+
+		cancel()
+
+		_, ok := <-assignments
+
+		assert.False(t, ok)
+	})
+
+	t.Run("should return an error for a nil channel", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		_, err = sr.AssignStream(context.Background(), nil)
+
+		assert.ErrorIs(t, err, ErrInvalidOption)
+	})
+}
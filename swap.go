@@ -0,0 +1,72 @@
+package rendezvous
+
+import (
+	"fmt"
+	"time"
+)
+
+// Clone returns a mutable copy of the skeleton, such as to stage a batch of
+// membership changes which are swapped into the skeleton at once. The copy
+// has the options, the topology and the node state of the skeleton, but
+// neither its watchers nor its observers.
+func (sr *SkeletonRendezvous) Clone() *SkeletonRendezvous {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+
+	cloned := sr.clone()
+	cloned.epoch = sr.epoch
+	cloned.clock = sr.clock
+	cloned.cache = newLookupCache(sr.options.lookupCacheSize)
+
+	for node, deadline := range sr.deadlines {
+		if cloned.deadlines == nil {
+			cloned.deadlines = make(map[string]time.Time, len(sr.deadlines))
+		}
+
+		cloned.deadlines[node] = deadline
+	}
+
+	return cloned
+}
+
+// Swap replaces the topology and the node state of the skeleton with the
+// ones of the staged skeleton in a single change, so lookups never observe
+// the intermediate states of the staged changes. The staged skeleton must
+// be valid and have the same options, it is left untouched and may be
+// swapped again. The epoch advances and the watchers are notified like for
+// any other change.
+func (sr *SkeletonRendezvous) Swap(staged *SkeletonRendezvous) error {
+	if err := staged.Validate(); err != nil {
+		return err
+	}
+
+	// the staged skeleton is copied before locking the skeleton, so two
+	// skeletons swapped into each other never wait on each other's lock.
+	next := staged.Clone()
+
+	var err error
+
+	sr.updateTopology(func() {
+		if !sr.options.equal(next.options) {
+			err = fmt.Errorf("%w: staged skeleton has different options", ErrInvalidOption)
+
+			return
+		}
+
+		sr.Clusters = next.Clusters
+		sr.Nodes = next.Nodes
+		sr.VirtualNodes = next.VirtualNodes
+		sr.nodeInfo = next.nodeInfo
+		sr.nodeWeights = next.nodeWeights
+		sr.clusterWeights = next.clusterWeights
+		sr.health = next.health
+		sr.states = next.states
+		sr.pins = next.pins
+		sr.deadlines = next.deadlines
+
+		sr.refreshUnavailable()
+		sr.topologyChanged()
+	})
+
+	return err
+}
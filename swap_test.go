@@ -0,0 +1,91 @@
+package rendezvous
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloneSwap(t *testing.T) {
+	newSkeleton := func(t *testing.T, options ...Option) *SkeletonRendezvous {
+		sr, err := NewSkeletonRendezvous(options...)
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		return sr
+	}
+
+	t.Run("should clone without sharing state", func(t *testing.T) {
+		sr := newSkeleton(t)
+		sr.MarkDown("jg1")
+
+		cloned := sr.Clone()
+
+		assert.True(t, sr.Equal(cloned))
+		assert.Equal(t, sr.Epoch(), cloned.Epoch())
+		assert.Equal(t, NodeDown, cloned.State("jg1"))
+
+		cloned.AddNodes([]string{"jg5", "jg6"})
+		cloned.MarkUp("jg1")
+
+		assert.Len(t, sr.Nodes, 4)
+		assert.Equal(t, NodeDown, sr.State("jg1"))
+	})
+
+	t.Run("should swap staged changes in a single change", func(t *testing.T) {
+		sr := newSkeleton(t, LookupCache(16))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events := sr.WatchTopology(ctx)
+
+		before := mustFindNode(t, sr, "key-1")
+		epoch := sr.Epoch()
+
+		staged := sr.Clone()
+		staged.RemoveNodes([]string{before})
+		staged.AddNodes([]string{"jg5", "jg6"})
+		assert.NoError(t, staged.PinKey("key-2", "jg6"))
+
+		assert.Equal(t, before, mustFindNode(t, sr, "key-1"))
+		assert.NoError(t, sr.Swap(staged))
+
+		assert.Equal(t, staged.Nodes, sr.Nodes)
+		assert.Equal(t, staged.Clusters, sr.Clusters)
+		assert.Equal(t, epoch+1, sr.Epoch())
+		assert.NotEqual(t, before, mustFindNode(t, sr, "key-1"))
+		assert.Equal(t, "jg6", mustFindNode(t, sr, "key-2"))
+		assert.Empty(t, sr.Verify())
+
+		for i := 0; i < 100; i++ {
+			key := "key-" + strconv.Itoa(i)
+
+			assert.Equal(t, mustFindNode(t, staged, key), mustFindNode(t, sr, key))
+		}
+
+		event := <-events
+
+		assert.ElementsMatch(t, []string{"jg5", "jg6"}, event.NodesAdded)
+		assert.Equal(t, []string{before}, event.NodesRemoved)
+
+		staged.AddNodes([]string{"jg7"})
+
+		assert.Len(t, sr.Nodes, 5)
+	})
+
+	t.Run("should reject staged skeleton with different options or invalid topology", func(t *testing.T) {
+		sr := newSkeleton(t)
+
+		assert.ErrorIs(t, sr.Swap(newSkeleton(t, FanOut(4))), ErrInvalidOption)
+
+		staged := sr.Clone()
+		staged.VirtualNodes = 0
+
+		assert.ErrorIs(t, sr.Swap(staged), ErrInvalidTopology)
+		assert.Len(t, sr.Nodes, 4)
+	})
+}
@@ -0,0 +1,166 @@
+package rendezvous
+
+import (
+	"context"
+	"sync"
+)
+
+// TopologyEvent describes a change of the nodes or the clusters.
+type TopologyEvent struct {
+	// NodesAdded are the nodes which joined the skeleton
+	NodesAdded []string
+
+	// NodesRemoved are the nodes which left the skeleton
+	NodesRemoved []string
+
+	// ClustersRebuilt reports whether nodes kept by the change were moved
+	// into another cluster
+	ClustersRebuilt bool
+
+	// Epoch is the epoch of the topology after the change
+	Epoch uint64
+}
+
+// WatchTopology returns a channel receiving an event after every change of
+// the nodes or the clusters, in the order of the changes. The channel is
+// closed once the context is done. Events are never dropped, they are queued
+// for a receiver which falls behind, so it never delays the changes of the
+// skeleton.
+func (sr *SkeletonRendezvous) WatchTopology(ctx context.Context) <-chan TopologyEvent {
+	watcher := newEventQueue[TopologyEvent]()
+
+	sr.mu.Lock()
+	sr.topologyWatchers = append(sr.topologyWatchers, watcher)
+	sr.mu.Unlock()
+
+	go watcher.run(ctx, func() {
+		sr.mu.Lock()
+		defer sr.mu.Unlock()
+
+		for i, w := range sr.topologyWatchers {
+			if w == watcher {
+				sr.topologyWatchers = append(sr.topologyWatchers[:i:i], sr.topologyWatchers[i+1:]...)
+
+				break
+			}
+		}
+	})
+
+	return watcher.events
+}
+
+// eventQueue delivers events to a channel in their order from its own
+// goroutine, so the sender never blocks on a slow receiver, for instance
+// while holding a lock the receiver needs.
+type eventQueue[T any] struct {
+	mu      sync.Mutex
+	pending []T
+	ready   chan struct{}
+	events  chan T
+}
+
+func newEventQueue[T any]() *eventQueue[T] {
+	return &eventQueue[T]{
+		ready:  make(chan struct{}, 1),
+		events: make(chan T),
+	}
+}
+
+// send queues the event without blocking.
+func (q *eventQueue[T]) send(event T) {
+	q.mu.Lock()
+	q.pending = append(q.pending, event)
+	q.mu.Unlock()
+
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
+
+// run delivers the queued events until the context is done, then calls done
+// and closes the channel.
+func (q *eventQueue[T]) run(ctx context.Context, done func()) {
+	defer close(q.events)
+	defer done()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-q.ready:
+		}
+
+		for {
+			q.mu.Lock()
+
+			if len(q.pending) == 0 {
+				q.mu.Unlock()
+
+				break
+			}
+
+			var zero T
+
+			event := q.pending[0]
+			q.pending[0] = zero
+			q.pending = q.pending[1:]
+
+			q.mu.Unlock()
+
+			select {
+			case q.events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// topologyEvent compares the topology before a change with the current one,
+// the caller must hold the lock.
+func (sr *SkeletonRendezvous) topologyEvent(nodes []string, clusters [][]string) TopologyEvent {
+	event := TopologyEvent{
+		NodesAdded:   make([]string, 0),
+		NodesRemoved: make([]string, 0),
+		Epoch:        sr.epoch,
+	}
+
+	before := clusterIndexes(clusters)
+	after := clusterIndexes(sr.Clusters)
+
+	for _, node := range sr.Nodes {
+		if _, ok := before[node]; !ok {
+			event.NodesAdded = append(event.NodesAdded, node)
+		}
+	}
+
+	for _, node := range nodes {
+		index, ok := after[node]
+
+		if !ok {
+			event.NodesRemoved = append(event.NodesRemoved, node)
+
+			continue
+		}
+
+		if before[node] != index {
+			event.ClustersRebuilt = true
+		}
+	}
+
+	return event
+}
+
+// clusterIndexes maps every node to the index of its cluster.
+func clusterIndexes(clusters [][]string) map[string]int {
+	indexes := make(map[string]int)
+
+	for i, cluster := range clusters {
+		for _, node := range cluster {
+			indexes[node] = i
+		}
+	}
+
+	return indexes
+}
@@ -0,0 +1,187 @@
+package rendezvous
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatchTopology(t *testing.T) {
+	t.Run("should receive added and removed nodes", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events := sr.WatchTopology(ctx)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+		sr.RemoveNodes([]string{"jg4"})
+		sr.AddNodes([]string{"jg5"})
+
+		assert.Equal(t, TopologyEvent{
+			NodesAdded:   []string{"jg1", "jg2", "jg3", "jg4"},
+			NodesRemoved: []string{},
+			Epoch:        1,
+		}, <-events)
+
+		assert.Equal(t, TopologyEvent{
+			NodesAdded:   []string{},
+			NodesRemoved: []string{"jg4"},
+			// jg3 is left alone in its cluster and spread into the first one
+			ClustersRebuilt: true,
+			Epoch:           2,
+		}, <-events)
+
+		assert.Equal(t, TopologyEvent{
+			NodesAdded:   []string{"jg5"},
+			NodesRemoved: []string{},
+			Epoch:        3,
+		}, <-events)
+	})
+
+	t.Run("should report only the added node when a cluster is filled", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(ClusterSize(3))
+
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sr.SetNodes([]string{"a", "b", "c", "d", "e"})
+
+		events := sr.WatchTopology(ctx)
+
+		sr.AddNodes([]string{"f"})
+
+		event := <-events
+
+		assert.Equal(t, []string{"f"}, event.NodesAdded)
+		assert.Empty(t, event.NodesRemoved)
+	})
+
+	t.Run("should report clusters rebuilt when kept nodes move", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sr.SetClusters([][]string{{"jg1", "jg2"}, {"jg3", "jg4"}})
+
+		events := sr.WatchTopology(ctx)
+
+		sr.SetClusters([][]string{{"jg1", "jg3"}, {"jg2", "jg4"}})
+
+		event := <-events
+
+		assert.True(t, event.ClustersRebuilt)
+		assert.Empty(t, event.NodesAdded)
+		assert.Empty(t, event.NodesRemoved)
+	})
+
+	t.Run("should not receive event when only scoring changes", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events := sr.WatchTopology(ctx)
+
+		sr.SetHealth(map[string]float64{"jg1": 0.5})
+		sr.SetNodes([]string{"jg1"})
+
+		assert.Equal(t, uint64(1), (<-events).Epoch)
+	})
+
+	t.Run("should close channel once context is done", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		events := sr.WatchTopology(ctx)
+
+		cancel()
+
+		_, ok := <-events
+
+		assert.False(t, ok)
+
+		sr.SetNodes([]string{"jg1"})
+	})
+
+	t.Run("should not block changes while the receiver looks up keys", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2"})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events := sr.WatchTopology(ctx)
+
+		var last uint64
+
+		go func() {
+			// a slow receiver lets the events pile up while it waits on the
+			// lock of the writers.
+			for event := range events {
+				time.Sleep(100 * time.Microsecond)
+
+				_, _ = sr.FindNode("key-1")
+
+				atomic.StoreUint64(&last, event.Epoch)
+			}
+		}()
+
+		var wg sync.WaitGroup
+
+		for writer := 0; writer < 4; writer++ {
+			wg.Add(1)
+
+			go func(writer int) {
+				defer wg.Done()
+
+				node := "writer-" + strconv.Itoa(writer)
+
+				for i := 0; i < 100; i++ {
+					sr.AddNodes([]string{node})
+					sr.RemoveNodes([]string{node})
+				}
+			}(writer)
+		}
+
+		done := make(chan struct{})
+
+		go func() {
+			wg.Wait()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(10 * time.Second):
+			t.Fatal("changes blocked on the receiver")
+		}
+
+		epoch := sr.Epoch()
+
+		assert.Eventually(t, func() bool {
+			return atomic.LoadUint64(&last) == epoch
+		}, 5*time.Second, time.Millisecond)
+	})
+}
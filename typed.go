@@ -0,0 +1,131 @@
+package rendezvous
+
+import (
+	"sync"
+)
+
+// Identifiable is a node type which identifies itself with an ID.
+type Identifiable interface {
+	ID() string
+}
+
+// TypedSkeleton is a skeleton rendezvous storing the caller own node
+// values, such as connections or peers, instead of plain node names.
+// Nodes are placed by their ID.
+type TypedSkeleton[N Identifiable] struct {
+	mu       sync.RWMutex
+	skeleton *SkeletonRendezvous
+	nodes    map[string]N
+}
+
+// NewTypedSkeleton creates a skeleton rendezvous over nodes of type N.
+func NewTypedSkeleton[N Identifiable](options ...Option) (*TypedSkeleton[N], error) {
+	skeleton, err := NewSkeletonRendezvous(options...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &TypedSkeleton[N]{
+		skeleton: skeleton,
+		nodes:    make(map[string]N),
+	}, nil
+}
+
+// Skeleton returns the underlying skeleton rendezvous placing node IDs.
+func (ts *TypedSkeleton[N]) Skeleton() *SkeletonRendezvous {
+	return ts.skeleton
+}
+
+// SetNodes set new nodes into cluster, a node with an existing ID
+// replaces the stored node value.
+func (ts *TypedSkeleton[N]) SetNodes(nodes []N) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	ids := make([]string, 0, len(nodes))
+
+	for _, node := range nodes {
+		ts.nodes[node.ID()] = node
+		ids = append(ids, node.ID())
+	}
+
+	ts.skeleton.SetNodes(ids)
+}
+
+// RemoveNodes remove nodes from the cluster by their ID.
+func (ts *TypedSkeleton[N]) RemoveNodes(nodes []N) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	ids := make([]string, 0, len(nodes))
+
+	for _, node := range nodes {
+		delete(ts.nodes, node.ID())
+		ids = append(ids, node.ID())
+	}
+
+	ts.skeleton.RemoveNodes(ids)
+}
+
+// FindNode given specific key, find selected node with highest hash score.
+// It returns false when no node is selected.
+func (ts *TypedSkeleton[N]) FindNode(key string) (N, bool) {
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+
+	id, err := ts.skeleton.FindNode(key)
+
+	if err != nil {
+		var empty N
+
+		return empty, false
+	}
+
+	node, ok := ts.nodes[id]
+
+	return node, ok
+}
+
+// FindN find up to n nodes for the key ordered by preference.
+func (ts *TypedSkeleton[N]) FindN(key string, n int) ([]N, error) {
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+
+	ids, err := ts.skeleton.FindN(key, n)
+
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]N, 0, len(ids))
+
+	for _, id := range ids {
+		nodes = append(nodes, ts.nodes[id])
+	}
+
+	return nodes, nil
+}
+
+// Clusters returns the node values of every cluster.
+func (ts *TypedSkeleton[N]) Clusters() [][]N {
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+
+	ts.skeleton.mu.RLock()
+	defer ts.skeleton.mu.RUnlock()
+
+	clusters := make([][]N, 0, len(ts.skeleton.Clusters))
+
+	for _, cluster := range ts.skeleton.Clusters {
+		nodes := make([]N, 0, len(cluster))
+
+		for _, id := range cluster {
+			nodes = append(nodes, ts.nodes[id])
+		}
+
+		clusters = append(clusters, nodes)
+	}
+
+	return clusters
+}
@@ -0,0 +1,76 @@
+package rendezvous
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testPeer struct {
+	name string
+	addr string
+}
+
+func (p *testPeer) ID() string {
+	return p.name
+}
+
+func TestTypedSkeleton(t *testing.T) {
+	peers := make([]*testPeer, 0)
+
+	for i := 1; i <= 6; i++ {
+		peers = append(peers, &testPeer{name: "jg" + strconv.Itoa(i), addr: "10.0.0." + strconv.Itoa(i)})
+	}
+
+	t.Run("should find own node values", func(t *testing.T) {
+		ts, err := NewTypedSkeleton[*testPeer](FanOut(3), ClusterSize(2), MinClusterSize(2))
+
+		assert.NoError(t, err)
+
+		_, ok := ts.FindNode("key")
+		assert.False(t, ok)
+
+		ts.SetNodes(peers)
+
+		for i := 0; i < 100; i++ {
+			key := "key-" + strconv.Itoa(i)
+
+			peer, ok := ts.FindNode(key)
+
+			assert.True(t, ok)
+			assert.Equal(t, mustFindNode(t, ts.Skeleton(), key), peer.name)
+
+			replicas, err := ts.FindN(key, 2)
+
+			assert.NoError(t, err)
+			assert.Equal(t, peer, replicas[0])
+		}
+
+		clusters := ts.Clusters()
+
+		assert.Equal(t, [][]*testPeer{{peers[0], peers[1]}, {peers[2], peers[3]}, {peers[4], peers[5]}}, clusters)
+	})
+
+	t.Run("should remove node values", func(t *testing.T) {
+		ts, err := NewTypedSkeleton[*testPeer](FanOut(3), ClusterSize(2), MinClusterSize(2))
+
+		assert.NoError(t, err)
+
+		ts.SetNodes(peers)
+		ts.RemoveNodes(peers[1:])
+
+		for i := 0; i < 100; i++ {
+			peer, ok := ts.FindNode("key-" + strconv.Itoa(i))
+
+			assert.True(t, ok)
+			assert.Equal(t, peers[0], peer)
+		}
+	})
+
+	t.Run("should reject invalid options", func(t *testing.T) {
+		_, err := NewTypedSkeleton[*testPeer](FanOut(1))
+
+		assert.ErrorIs(t, err, ErrInvalidOption)
+	})
+}
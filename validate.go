@@ -0,0 +1,118 @@
+package rendezvous
+
+import (
+	"fmt"
+)
+
+// Validate checks the skeleton topology is consistent for routing, the
+// branches must be able to address every cluster, every cluster must be
+// reachable from a branch and Nodes must be the union of the clusters. It
+// returns the first violation of these invariants, Verify reports all of
+// them along with the invariants routing does not depend on.
+func (sr *SkeletonRendezvous) Validate() error {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+
+	if violations := sr.routingViolations(); len(violations) > 0 {
+		return violations[0]
+	}
+
+	return nil
+}
+
+// routingViolations returns one error per violation of the invariants
+// routing depends on, wrapping ErrInvalidTopology, the caller must hold the
+// read lock. A node missing from the clusters wraps ErrNodeNotInCluster as
+// well.
+func (sr *SkeletonRendezvous) routingViolations() []error {
+	var violations []error
+
+	violate := func(format string, args ...interface{}) {
+		violations = append(violations, fmt.Errorf("%w: "+format, append([]interface{}{ErrInvalidTopology}, args...)...))
+	}
+
+	positions := 1
+
+	for i := 0; i < sr.VirtualNodes; i++ {
+		positions *= sr.options.fanOut
+	}
+
+	if positions < len(sr.Clusters) {
+		violate("%d virtual nodes with fan out %d address %d branches, less than %d clusters",
+			sr.VirtualNodes, sr.options.fanOut, positions, len(sr.Clusters))
+	} else {
+		reachable := make([]bool, len(sr.Clusters))
+
+		for position := 0; position < positions; position++ {
+			clusterIndex := sr.clusterIndex(position)
+
+			if clusterIndex >= 0 && clusterIndex < len(sr.Clusters) {
+				reachable[clusterIndex] = true
+			}
+		}
+
+		for clusterIndex, ok := range reachable {
+			if !ok {
+				violate("cluster %d is not reachable from any branch", clusterIndex)
+			}
+		}
+	}
+
+	clusterOf := make(map[string]int, len(sr.Nodes))
+	clusterNodes := make([]string, 0, len(sr.Nodes))
+
+	for clusterIndex, cluster := range sr.Clusters {
+		for _, node := range cluster {
+			if other, ok := clusterOf[node]; ok {
+				violate("node %s exists in clusters %d and %d", node, other, clusterIndex)
+
+				continue
+			}
+
+			clusterOf[node] = clusterIndex
+			clusterNodes = append(clusterNodes, node)
+		}
+	}
+
+	nodes := make(map[string]int, len(sr.Nodes))
+
+	for _, node := range sr.Nodes {
+		nodes[node]++
+
+		if nodes[node] == 2 {
+			violate("node %s exists more than once in nodes", node)
+		}
+
+		if _, ok := clusterOf[node]; !ok && nodes[node] == 1 {
+			violations = append(violations, fmt.Errorf("%w: %w: node %s does not exist in any cluster",
+				ErrInvalidTopology, ErrNodeNotInCluster, node))
+		}
+	}
+
+	for _, node := range clusterNodes {
+		if nodes[node] == 0 {
+			violate("node %s of cluster %d does not exist in nodes", node, clusterOf[node])
+		}
+	}
+
+	return violations
+}
+
+// Ready reports whether the skeleton is populated and able to route keys,
+// that is it has a node, a cluster with nodes and at least one virtual node.
+func (sr *SkeletonRendezvous) Ready() bool {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+
+	if len(sr.Nodes) == 0 || sr.VirtualNodes < 1 {
+		return false
+	}
+
+	for _, cluster := range sr.Clusters {
+		if len(cluster) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
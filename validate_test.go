@@ -0,0 +1,109 @@
+package rendezvous
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("should keep topology consistent after removing nodes", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(2), MinClusterSize(2))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4", "jg5", "jg6"})
+
+		assert.NoError(t, sr.Validate())
+		assert.Equal(t, 3, len(sr.Clusters))
+		assert.Equal(t, 1, sr.VirtualNodes)
+
+		sr.RemoveNodes([]string{"jg2", "jg4", "jg6"})
+
+		assert.NoError(t, sr.Validate())
+		assert.Equal(t, []string{"jg1", "jg3", "jg5"}, sr.Nodes)
+		assert.Equal(t, [][]string{{"jg1", "jg5", "jg3"}}, sr.Clusters)
+		assert.Equal(t, 1, sr.VirtualNodes)
+
+		for i := 0; i < 100; i++ {
+			assert.Contains(t, sr.Nodes, mustFindNode(t, sr, "key-"+strconv.Itoa(i)))
+		}
+	})
+
+	t.Run("should hold invariant after any mutation", func(t *testing.T) {
+		for _, fanOut := range []int{2, 3, 4} {
+			for size := 1; size <= 40; size++ {
+				sr, err := NewSkeletonRendezvous(FanOut(fanOut), ClusterSize(2), MinClusterSize(2))
+
+				assert.NoError(t, err)
+
+				nodes := make([]string, 0)
+
+				for i := 0; i < size; i++ {
+					nodes = append(nodes, "jg"+strconv.Itoa(i))
+				}
+
+				sr.SetNodes(nodes[:size/2])
+				assert.NoError(t, sr.Validate())
+
+				sr.SetNodes(nodes[size/2:])
+				assert.NoError(t, sr.Validate())
+				assert.Equal(t, size, len(sr.Nodes))
+
+				sr.RemoveNodes(nodes[:size/3])
+				assert.NoError(t, sr.Validate())
+				assert.Equal(t, size-size/3, len(sr.Nodes))
+			}
+		}
+	})
+
+	t.Run("should detect cluster which is not addressable", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(3))
+
+		assert.NoError(t, err)
+
+		sr.SetClusters([][]string{{"jg1"}, {"jg2"}, {"jg3"}, {"jg4"}})
+		sr.VirtualNodes = 1
+
+		assert.ErrorIs(t, sr.Validate(), ErrInvalidTopology)
+	})
+
+	t.Run("should detect node which is not in any cluster", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+		sr.Nodes[0] = "jg5"
+
+		err = sr.Validate()
+
+		assert.ErrorIs(t, err, ErrNodeNotInCluster)
+		assert.NotErrorIs(t, err, ErrNodeNotFound)
+	})
+}
+
+func TestReady(t *testing.T) {
+	t.Run("should not be ready before nodes are set", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+		assert.False(t, sr.Ready())
+
+		sr.SetClusters([][]string{{}})
+		assert.False(t, sr.Ready())
+	})
+
+	t.Run("should be ready once nodes are set", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1"})
+		assert.True(t, sr.Ready())
+
+		sr.RemoveNodes([]string{"jg1"})
+		assert.False(t, sr.Ready())
+	})
+}
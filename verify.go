@@ -0,0 +1,80 @@
+package rendezvous
+
+import (
+	"fmt"
+)
+
+// Verify checks every structural invariant of the skeleton and returns one
+// error per violation, wrapping ErrInvalidTopology, or nil when they all
+// hold. It reports the violations Validate checks first, but unlike Validate
+// it does not stop at the first one, so it suits property tests and fuzzers.
+// The invariants are:
+//
+//   - every node is in exactly one cluster and Nodes is the union of the
+//     clusters, without duplicates
+//   - no cluster is empty, and no cluster but the last one is below the
+//     minimum cluster size when the clusters are packed in order by the
+//     default strategy and the undersized clusters are redistributed
+//   - the depth is the smallest able to address every cluster, and every
+//     cluster is reachable from a branch position
+//   - the precomputed branch table maps every position like the overflow
+//     policy does
+func (sr *SkeletonRendezvous) Verify() []error {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+
+	violations := sr.routingViolations()
+
+	violate := func(format string, args ...interface{}) {
+		violations = append(violations, fmt.Errorf("%w: "+format, append([]interface{}{ErrInvalidTopology}, args...)...))
+	}
+
+	packed := len(sr.Clusters) > 1 && !sr.options.disableRedistribution &&
+		!sr.options.stableClusterCount && sr.packedInOrder()
+
+	for clusterIndex, cluster := range sr.Clusters {
+		if len(cluster) == 0 {
+			violate("cluster %d is empty", clusterIndex)
+
+			continue
+		}
+
+		if packed && clusterIndex < len(sr.Clusters)-1 && len(cluster) < sr.options.minClusterSize {
+			violate("cluster %d has %d nodes, below the minimum cluster size %d",
+				clusterIndex, len(cluster), sr.options.minClusterSize)
+		}
+	}
+
+	if len(sr.Clusters) == 0 {
+		if sr.VirtualNodes != 0 {
+			violate("%d virtual nodes without clusters", sr.VirtualNodes)
+		}
+
+		return violations
+	}
+
+	positions := 1
+
+	for i := 0; i < sr.VirtualNodes; i++ {
+		positions *= sr.options.fanOut
+	}
+
+	// too few virtual nodes to address the clusters is a routing violation
+	// already.
+	if depth := sr.countVirtualNodes(len(sr.Clusters), sr.options.fanOut); sr.VirtualNodes != depth && positions >= len(sr.Clusters) {
+		violate("%d virtual nodes for %d clusters with fan out %d, expected %d",
+			sr.VirtualNodes, len(sr.Clusters), sr.options.fanOut, depth)
+	}
+
+	if len(sr.clusterIndexes) != positions {
+		violate("branch table has %d positions, expected %d", len(sr.clusterIndexes), positions)
+	}
+
+	for position := 0; position < positions; position++ {
+		if clusterIndex := sr.lookupClusterIndex(position); clusterIndex != sr.clusterIndex(position) {
+			violate("branch position %d maps into cluster %d, expected %d", position, clusterIndex, sr.clusterIndex(position))
+		}
+	}
+
+	return violations
+}
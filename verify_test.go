@@ -0,0 +1,92 @@
+package rendezvous
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerify(t *testing.T) {
+	t.Run("should hold invariants after random mutations", func(t *testing.T) {
+		random := rand.New(rand.NewSource(1))
+
+		for _, fanOut := range []int{2, 3, 5} {
+			sr, err := NewSkeletonRendezvous(FanOut(fanOut), ClusterSize(3), MinClusterSize(2))
+			assert.NoError(t, err)
+
+			assert.Empty(t, sr.Verify())
+
+			for i := 0; i < 200; i++ {
+				node := "jg" + strconv.Itoa(random.Intn(40))
+
+				switch random.Intn(4) {
+				case 0:
+					sr.SetNodes([]string{node})
+				case 1:
+					sr.AddNodes([]string{node})
+				case 2:
+					sr.RemoveNodes([]string{node})
+				default:
+					sr.SyncNodes(sr.Nodes[:len(sr.Nodes)/2])
+				}
+
+				assert.Empty(t, sr.Verify(), "fan out %d, mutation %d", fanOut, i)
+			}
+		}
+	})
+
+	t.Run("should report every violation", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(ClusterSize(3), MinClusterSize(2))
+		assert.NoError(t, err)
+
+		sr.SetClusters([][]string{{"jg1"}, {"jg2", "jg3"}, {"jg4", "jg5"}})
+
+		sr.Nodes = append(sr.Nodes, "jg6")
+		sr.Clusters[2] = append(sr.Clusters[2], "jg2")
+
+		violations := sr.Verify()
+
+		assert.Len(t, violations, 3)
+
+		for _, violation := range violations {
+			assert.ErrorIs(t, violation, ErrInvalidTopology)
+		}
+
+		assert.EqualError(t, violations[0], "rendezvous: invalid topology: node jg2 exists in clusters 1 and 2")
+		assert.EqualError(t, violations[1], "rendezvous: invalid topology: rendezvous: node not in cluster: node jg6 does not exist in any cluster")
+		assert.ErrorIs(t, violations[1], ErrNodeNotInCluster)
+		assert.EqualError(t, violations[2], "rendezvous: invalid topology: cluster 0 has 1 nodes, below the minimum cluster size 2")
+	})
+
+	t.Run("should report stale depth and branch table", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		sr.VirtualNodes = 2
+
+		violations := sr.Verify()
+
+		assert.Len(t, violations, 2)
+		assert.Contains(t, violations[0].Error(), "2 virtual nodes for 2 clusters with fan out 3, expected 1")
+		assert.Contains(t, violations[1].Error(), "branch table has 3 positions, expected 9")
+	})
+
+	t.Run("should report the first violation through validate", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(ClusterSize(3), MinClusterSize(2))
+		assert.NoError(t, err)
+
+		sr.SetClusters([][]string{{"jg1"}, {"jg2", "jg3"}, {"jg4", "jg5"}})
+
+		// undersized clusters do not break routing, only Verify reports them
+		assert.NoError(t, sr.Validate())
+		assert.Len(t, sr.Verify(), 1)
+
+		sr.Clusters[2] = append(sr.Clusters[2], "jg2")
+
+		assert.Equal(t, sr.Verify()[0], sr.Validate())
+	})
+}
@@ -0,0 +1,52 @@
+package rendezvous
+
+// View is a read-only routing view of a skeleton frozen at the moment it
+// was taken, its lookups never change while the skeleton keeps changing.
+// It is safe for concurrent use.
+type View struct {
+	sr *SkeletonRendezvous
+}
+
+// Snapshot returns a read-only view of the current nodes, clusters, node
+// states and epoch, such as for a long running migration job which needs a
+// stable routing table. Keys are placed without bounded load in the view,
+// since the loads change with every lookup.
+func (sr *SkeletonRendezvous) Snapshot() *View {
+	frozen := sr.Clone()
+	frozen.options.boundedLoad = false
+
+	return &View{sr: frozen}
+}
+
+// FindNode find selected node for the key like the skeleton did when the
+// view was taken.
+func (v *View) FindNode(key string) (string, error) {
+	return v.sr.FindNode(key)
+}
+
+// FindN find up to n nodes for the key ordered by preference like FindN of
+// the skeleton.
+func (v *View) FindN(key string, n int) ([]string, error) {
+	return v.sr.FindN(key, n)
+}
+
+// Nodes returns a copy of the nodes of the view.
+func (v *View) Nodes() []string {
+	return append(make([]string, 0, len(v.sr.Nodes)), v.sr.Nodes...)
+}
+
+// Clusters returns a copy of the clusters of the view.
+func (v *View) Clusters() [][]string {
+	clusters := make([][]string, 0, len(v.sr.Clusters))
+
+	for _, cluster := range v.sr.Clusters {
+		clusters = append(clusters, append(make([]string, 0, len(cluster)), cluster...))
+	}
+
+	return clusters
+}
+
+// Epoch returns the epoch of the topology the view was taken at.
+func (v *View) Epoch() uint64 {
+	return v.sr.epoch
+}
@@ -0,0 +1,63 @@
+package rendezvous
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestView(t *testing.T) {
+	t.Run("should keep routing while the skeleton changes", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4"})
+
+		placed := make(map[string]string)
+
+		for i := 0; i < 100; i++ {
+			key := "key-" + strconv.Itoa(i)
+
+			placed[key] = mustFindNode(t, sr, key)
+		}
+
+		view := sr.Snapshot()
+		epoch := sr.Epoch()
+
+		sr.RemoveNodes([]string{placed["key-1"]})
+		sr.AddNodes([]string{"jg5", "jg6"})
+		sr.MarkDown("jg2")
+
+		assert.Equal(t, epoch, view.Epoch())
+		assert.Equal(t, []string{"jg1", "jg2", "jg3", "jg4"}, view.Nodes())
+		assert.Equal(t, [][]string{{"jg1", "jg2"}, {"jg3", "jg4"}}, view.Clusters())
+
+		for key, node := range placed {
+			found, err := view.FindNode(key)
+
+			assert.NoError(t, err)
+			assert.Equal(t, node, found)
+		}
+
+		nodes, err := view.FindN("key-1", 4)
+
+		assert.NoError(t, err)
+		assert.Equal(t, placed["key-1"], nodes[0])
+	})
+
+	t.Run("should not share the returned slices", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2"})
+
+		view := sr.Snapshot()
+
+		view.Nodes()[0] = "changed"
+		view.Clusters()[0][0] = "changed"
+
+		assert.Equal(t, []string{"jg1", "jg2"}, view.Nodes())
+		assert.Equal(t, [][]string{{"jg1", "jg2"}}, view.Clusters())
+	})
+}
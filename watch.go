@@ -0,0 +1,81 @@
+package rendezvous
+
+import (
+	"sync/atomic"
+)
+
+// keyWatcher tracks the node assigned to each watched key
+type keyWatcher struct {
+	keys     []string
+	assigned map[string]string
+	callback func(key, oldNode, newNode string)
+
+	// stopped is set once the watch is stopped, the moves collected before
+	// are dropped rather than delivered
+	stopped atomic.Bool
+}
+
+// Watch records the node currently assigned to each key, and after every
+// change of the topology or placement calls the callback for each watched
+// key which is moved to another node, in the order of the given keys. The
+// returned stop func ends the watch, the callback is not called once it has
+// returned, and it may be called more than once or from the callback.
+func (sr *SkeletonRendezvous) Watch(keys []string, callback func(key, oldNode, newNode string)) (stop func()) {
+	watcher := &keyWatcher{
+		keys:     append(make([]string, 0, len(keys)), keys...),
+		assigned: make(map[string]string, len(keys)),
+		callback: callback,
+	}
+
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+
+	for _, key := range watcher.keys {
+		watcher.assigned[key], _ = sr.peekNode(key)
+	}
+
+	sr.watchers = append(sr.watchers, watcher)
+
+	return func() {
+		sr.mu.Lock()
+		defer sr.mu.Unlock()
+
+		watcher.stopped.Store(true)
+
+		for i, w := range sr.watchers {
+			if w == watcher {
+				sr.watchers = append(sr.watchers[:i:i], sr.watchers[i+1:]...)
+
+				break
+			}
+		}
+	}
+}
+
+// watchEvent is a watched key moved to another node
+type watchEvent struct {
+	key     string
+	oldNode string
+	newNode string
+	watcher *keyWatcher
+}
+
+// collectWatchEvents recomputes the node of every watched key and returns
+// the keys which are moved, callbacks are called once the lock is released.
+func (sr *SkeletonRendezvous) collectWatchEvents() []watchEvent {
+	events := make([]watchEvent, 0)
+
+	for _, watcher := range sr.watchers {
+		for _, key := range watcher.keys {
+			oldNode := watcher.assigned[key]
+			newNode, _ := sr.peekNode(key)
+
+			if oldNode != newNode {
+				watcher.assigned[key] = newNode
+				events = append(events, watchEvent{key: key, oldNode: oldNode, newNode: newNode, watcher: watcher})
+			}
+		}
+	}
+
+	return events
+}
@@ -0,0 +1,141 @@
+package rendezvous
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatch(t *testing.T) {
+	t.Run("should notify moved keys after removing nodes", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(2), MinClusterSize(2))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2", "jg3", "jg4", "jg5", "jg6"})
+
+		keys := make([]string, 0)
+
+		for i := 0; i < 200; i++ {
+			keys = append(keys, "key-"+strconv.Itoa(i))
+		}
+
+		expected := sr.SimulateRemove([]string{"jg2"}, keys)
+		moved := make(map[string]string)
+
+		sr.Watch(keys, func(key, oldNode, newNode string) {
+			assert.NotEqual(t, oldNode, newNode)
+			assert.Equal(t, newNode, mustFindNode(t, sr, key))
+
+			moved[key] = newNode
+		})
+
+		sr.RemoveNodes([]string{"jg2"})
+
+		assert.Equal(t, expected, moved)
+	})
+
+	t.Run("should notify moved keys after changing placement", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(3), ClusterSize(2), MinClusterSize(2))
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2"})
+
+		notified := 0
+
+		sr.Watch([]string{"key-1", "key-2", "key-3"}, func(key, oldNode, newNode string) {
+			assert.Equal(t, "jg1", oldNode)
+			assert.Equal(t, "jg2", newNode)
+
+			notified++
+		})
+
+		before := len(sr.KeysForNode("jg1", []string{"key-1", "key-2", "key-3"}))
+
+		sr.SetHealth(map[string]float64{"jg1": 0})
+
+		assert.Equal(t, before, notified)
+
+		sr.SetHealth(map[string]float64{"jg1": 0})
+
+		assert.Equal(t, before, notified)
+	})
+
+	t.Run("should let callbacks change the topology again", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		_ = sr.WatchTopology(ctx)
+
+		sr.SetNodes([]string{"jg1", "jg2"})
+
+		node := mustFindNode(t, sr, "key-1")
+		added := false
+
+		sr.Watch([]string{"key-1"}, func(key, oldNode, newNode string) {
+			if !added {
+				added = true
+
+				sr.AddNodes([]string{node})
+			}
+		})
+
+		sr.RemoveNodes([]string{node})
+
+		assert.True(t, added)
+		assert.Equal(t, node, mustFindNode(t, sr, "key-1"))
+	})
+
+	t.Run("should not notify once the watch is stopped", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous()
+
+		assert.NoError(t, err)
+
+		sr.SetNodes([]string{"jg1", "jg2"})
+
+		keys := make([]string, 0)
+
+		for i := 0; i < 100; i++ {
+			keys = append(keys, "key-"+strconv.Itoa(i))
+		}
+
+		notified := 0
+		stopped := 0
+
+		stop := sr.Watch(keys, func(key, oldNode, newNode string) {
+			notified++
+		})
+
+		// stopped by its own callback, the other keys moved by the same
+		// change are not delivered
+		var stopSelf func()
+
+		stopSelf = sr.Watch(keys, func(key, oldNode, newNode string) {
+			stopped++
+			stopSelf()
+		})
+
+		sr.RemoveNodes([]string{"jg1"})
+
+		assert.Positive(t, notified)
+		assert.Equal(t, 1, stopped)
+
+		stop()
+		stop()
+
+		before := notified
+
+		sr.SetNodes([]string{"jg3", "jg4"})
+
+		assert.Equal(t, before, notified)
+		assert.Equal(t, 1, stopped)
+		assert.Empty(t, sr.watchers)
+	})
+}
@@ -0,0 +1,67 @@
+package rendezvous
+
+import (
+	"sort"
+)
+
+// SetNodesWeighted set new nodes into cluster like SetNodes, along with the
+// weight of each node relative to its capacity. A node with weight 2
+// receives twice as many keys as a node with weight 1, both within its
+// cluster and across clusters since each cluster is selected by the sum
+// of its node weights. New nodes are added heaviest first, then by name.
+func (sr *SkeletonRendezvous) SetNodesWeighted(nodes map[string]float64) {
+	sr.updateTopology(func() {
+		weights := make(map[string]float64, len(sr.nodeWeights)+len(nodes))
+
+		for node, weight := range sr.nodeWeights {
+			weights[node] = weight
+		}
+
+		newNodes := make([]string, 0, len(nodes))
+
+		for node, weight := range nodes {
+			weights[node] = weight
+			newNodes = append(newNodes, node)
+		}
+
+		sort.Slice(newNodes, func(i, j int) bool {
+			if nodes[newNodes[i]] != nodes[newNodes[j]] {
+				return nodes[newNodes[i]] > nodes[newNodes[j]]
+			}
+
+			return newNodes[i] < newNodes[j]
+		})
+
+		sr.nodeWeights = weights
+		sr.setNodes(newNodes)
+	})
+}
+
+func (sr *SkeletonRendezvous) setNodeWeights(weights map[string]float64) {
+	if weights == nil {
+		sr.nodeWeights = nil
+
+		return
+	}
+
+	sr.nodeWeights = make(map[string]float64, len(weights))
+
+	for node, weight := range weights {
+		sr.nodeWeights[node] = weight
+	}
+}
+
+// nodeWeight returns the weight of the node, 1 when it has no weight
+func (sr *SkeletonRendezvous) nodeWeight(node string) float64 {
+	weight, ok := sr.nodeWeights[node]
+
+	if !ok {
+		return 1
+	}
+
+	if weight < 0 {
+		return 0
+	}
+
+	return weight
+}
@@ -0,0 +1,45 @@
+package rendezvous
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetNodesWeighted(t *testing.T) {
+	t.Run("should distribute keys proportionally to node weights", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(FanOut(2), ClusterSize(2), MinClusterSize(2), HashAlgorithm(newMixedHash()))
+
+		assert.NoError(t, err)
+
+		sr.SetNodesWeighted(map[string]float64{"jg1": 1, "jg2": 1, "jg3": 2, "jg4": 4})
+
+		assert.Equal(t, []string{"jg4", "jg3", "jg1", "jg2"}, sr.Nodes)
+		assert.NoError(t, sr.Validate())
+
+		counts := make(map[string]int)
+
+		for i := 0; i < 16000; i++ {
+			counts[mustFindNode(t, sr, "key-"+strconv.Itoa(i))]++
+		}
+
+		assert.InDelta(t, 2000, counts["jg1"], 300)
+		assert.InDelta(t, 2000, counts["jg2"], 300)
+		assert.InDelta(t, 4000, counts["jg3"], 400)
+		assert.InDelta(t, 8000, counts["jg4"], 400)
+	})
+
+	t.Run("should keep weights of existing nodes", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(ClusterSize(4))
+
+		assert.NoError(t, err)
+
+		sr.SetNodesWeighted(map[string]float64{"jg1": 3})
+		sr.SetNodes([]string{"jg2"})
+
+		assert.Equal(t, []string{"jg1", "jg2"}, sr.Nodes)
+		assert.Equal(t, 3.0, sr.nodeWeight("jg1"))
+		assert.Equal(t, 1.0, sr.nodeWeight("jg2"))
+	})
+}
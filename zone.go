@@ -0,0 +1,150 @@
+package rendezvous
+
+import (
+	"fmt"
+	"sort"
+)
+
+// PlacementPolicy decides how nodes are placed into clusters when the
+// clusters are generated.
+type PlacementPolicy int
+
+const (
+	// PlaceInOrder fills the clusters with the nodes in the order they
+	// are given
+	PlaceInOrder PlacementPolicy = iota
+
+	// SpreadByZone places the nodes of a zone into different clusters, so a
+	// cluster holds at most one node of each zone as long as no zone has
+	// more nodes than there are clusters
+	SpreadByZone
+
+	// GroupByZone places only nodes of the same zone into a cluster
+	GroupByZone
+)
+
+// DefaultZoneLabel is the node label holding the zone of a node.
+const DefaultZoneLabel = "zone"
+
+// Placement sets the policy to place nodes into clusters, the zone of a
+// node is read from its labels set through SetNodeList.
+func Placement(policy PlacementPolicy) Option {
+	return func(o *Options) error {
+		if policy != PlaceInOrder && policy != SpreadByZone && policy != GroupByZone {
+			return fmt.Errorf("%w: unknown placement policy %d", ErrInvalidOption, policy)
+		}
+
+		o.placement = policy
+
+		return nil
+	}
+}
+
+// ZoneLabel sets the node label holding the zone of a node, "zone" by
+// default. Nodes without the label belong to the same unnamed zone.
+func ZoneLabel(label string) Option {
+	return func(o *Options) error {
+		o.zoneLabel = label
+
+		return nil
+	}
+}
+
+// zoneClusters generates the clusters of the nodes by the placement policy.
+func (sr *SkeletonRendezvous) zoneClusters(nodes []string) [][]string {
+	zones, zoneNodes := sr.groupByZone(nodes)
+
+	if sr.options.placement == GroupByZone {
+		clusters := make([][]string, 0)
+
+		for _, zone := range zones {
+			clusters = append(clusters, chunkClusters(zoneNodes[zone], sr.assignOptions())...)
+		}
+
+		return clusters
+	}
+
+	clusterAmount := sr.clusterAmount(len(nodes))
+	clusters := make([][]string, clusterAmount)
+
+	// striping the nodes grouped by zone over the clusters puts the nodes
+	// of a zone into consecutive, thus different, clusters.
+	i := 0
+
+	for _, zone := range zones {
+		for _, node := range zoneNodes[zone] {
+			clusters[i%clusterAmount] = append(clusters[i%clusterAmount], node)
+			i++
+		}
+	}
+
+	return clusters
+}
+
+// groupByZone returns the zones sorted by name along with their nodes.
+func (sr *SkeletonRendezvous) groupByZone(nodes []string) ([]string, map[string][]string) {
+	zones := make([]string, 0)
+	zoneNodes := make(map[string][]string)
+
+	for _, node := range nodes {
+		zone := sr.nodeInfo[node].Labels[sr.options.zoneLabel]
+
+		if _, ok := zoneNodes[zone]; !ok {
+			zones = append(zones, zone)
+		}
+
+		zoneNodes[zone] = append(zoneNodes[zone], node)
+	}
+
+	sort.Strings(zones)
+
+	return zones, zoneNodes
+}
+
+// chunkClusters splits the nodes into clusters of the cluster size, an
+// undersized last cluster is spread over the other clusters unless the
+// redistribution is disabled.
+func chunkClusters(nodes []string, options AssignOptions) [][]string {
+	clusters := make([][]string, 0, (len(nodes)+options.ClusterSize-1)/options.ClusterSize)
+
+	for start := 0; start < len(nodes); start += options.ClusterSize {
+		end := start + options.ClusterSize
+
+		if end > len(nodes) {
+			end = len(nodes)
+		}
+
+		clusters = append(clusters, append(make([]string, 0, end-start), nodes[start:end]...))
+	}
+
+	if len(clusters) > 1 && !options.DisableRedistribution {
+		lastCluster := clusters[len(clusters)-1]
+
+		if len(lastCluster) < options.MinClusterSize {
+			clusters = clusters[:len(clusters)-1]
+
+			for i, node := range lastCluster {
+				clusters[i%len(clusters)] = append(clusters[i%len(clusters)], node)
+			}
+		}
+	}
+
+	return clusters
+}
+
+// clusterAmount returns the number of clusters for the nodes, fewer clusters
+// are used when balanced clusters would fall below the minimum cluster size,
+// unless the redistribution is disabled.
+func (sr *SkeletonRendezvous) clusterAmount(nodes int) int {
+	clusterAmount := (nodes + sr.options.clusterSize - 1) / sr.options.clusterSize
+
+	if sr.options.disableRedistribution {
+		return clusterAmount
+	}
+
+	for clusterAmount > 1 && nodes/clusterAmount < sr.options.minClusterSize {
+		clusterAmount--
+	}
+
+	return clusterAmount
+}
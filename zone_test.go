@@ -0,0 +1,99 @@
+package rendezvous
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func zonedNodes(zones ...string) []Node {
+	nodes := make([]Node, 0, len(zones))
+
+	for i, zone := range zones {
+		nodes = append(nodes, Node{ID: "jg" + strconv.Itoa(i+1), Labels: map[string]string{"zone": zone}})
+	}
+
+	return nodes
+}
+
+func TestPlacement(t *testing.T) {
+	t.Run("should never place two nodes of a zone into a cluster when spreading", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(ClusterSize(3), Placement(SpreadByZone))
+
+		assert.NoError(t, err)
+
+		sr.SetNodeList(zonedNodes("a", "a", "a", "b", "b", "b", "c", "c", "c"))
+
+		assert.Len(t, sr.Clusters, 3)
+
+		for _, cluster := range sr.ClusterNodes() {
+			zones := make(map[string]bool)
+
+			for _, node := range cluster {
+				assert.False(t, zones[node.Labels["zone"]])
+
+				zones[node.Labels["zone"]] = true
+			}
+		}
+
+		assert.NoError(t, sr.Validate())
+	})
+
+	t.Run("should place only nodes of a zone into a cluster when grouping", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(ClusterSize(2), Placement(GroupByZone))
+
+		assert.NoError(t, err)
+
+		sr.SetNodeList(zonedNodes("a", "b", "a", "b", "a", "c", "c"))
+
+		assert.Equal(t, [][]string{{"jg1", "jg3", "jg5"}, {"jg2", "jg4"}, {"jg6", "jg7"}}, sr.Clusters)
+		assert.NoError(t, sr.Validate())
+	})
+
+	t.Run("should read zone from the given label", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(ClusterSize(2), Placement(GroupByZone), ZoneLabel("rack"))
+
+		assert.NoError(t, err)
+
+		sr.SetNodeList([]Node{
+			{ID: "jg1", Labels: map[string]string{"rack": "r1"}},
+			{ID: "jg2", Labels: map[string]string{"rack": "r2"}},
+			{ID: "jg3", Labels: map[string]string{"rack": "r1"}},
+			{ID: "jg4", Labels: map[string]string{"rack": "r2"}},
+		})
+
+		assert.Equal(t, [][]string{{"jg1", "jg3"}, {"jg2", "jg4"}}, sr.Clusters)
+	})
+
+	t.Run("should keep zone placement when adding and removing nodes", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(ClusterSize(2), MinClusterSize(1), Placement(SpreadByZone))
+
+		assert.NoError(t, err)
+
+		sr.SetNodeList(zonedNodes("a", "a", "b", "b"))
+		sr.RemoveNodes([]string{"jg3"})
+
+		assert.Equal(t, [][]string{{"jg1"}, {"jg2", "jg4"}}, sr.Clusters)
+
+		sr.SetNodeList([]Node{{ID: "jg5", Labels: map[string]string{"zone": "c"}}})
+		sr.AddNodes([]string{"jg6"})
+
+		for _, cluster := range sr.ClusterNodes() {
+			zones := make(map[string]bool)
+
+			for _, node := range cluster {
+				assert.False(t, zones[node.Labels["zone"]])
+
+				zones[node.Labels["zone"]] = true
+			}
+		}
+	})
+
+	t.Run("should reject unknown placement policy", func(t *testing.T) {
+		sr, err := NewSkeletonRendezvous(Placement(PlacementPolicy(3)))
+
+		assert.ErrorIs(t, err, ErrInvalidOption)
+		assert.Nil(t, sr)
+	})
+}